@@ -1,5 +1,7 @@
 package gobot
 
+import "context"
+
 // Adaptor is the interface that describes an adaptor in gobot
 type Adaptor interface {
 	// Name returns the label for the Adaptor
@@ -14,3 +16,17 @@ type Adaptor interface {
 type Porter interface {
 	Port() string
 }
+
+// ContextConnector is the interface that describes an adaptor which can abort
+// its Connect when ctx is cancelled. The Connect of other adaptors is
+// abandoned when ctx is cancelled, and finalized should it succeed afterwards.
+// OpenContext and CloseOnDone help adaptors of serial ports implement it.
+type ContextConnector interface {
+	ConnectContext(ctx context.Context) []error
+}
+
+// ContextFinalizer is the interface that describes an adaptor which can bound
+// its Finalize by ctx
+type ContextFinalizer interface {
+	FinalizeContext(ctx context.Context) []error
+}
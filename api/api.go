@@ -1,10 +1,10 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,6 +12,7 @@ import (
 	"github.com/bmizerany/pat"
 	"github.com/hybridgroup/gobot"
 	"github.com/hybridgroup/gobot/api/robeaux"
+	"github.com/hybridgroup/gobot/rules"
 )
 
 // API represents an API server
@@ -22,26 +23,34 @@ type API struct {
 	Port     string
 	Cert     string
 	Key      string
+	MaxJobs  int
+	Rules    *rules.Engine
+	Metrics  *gobot.Metrics
 	handlers []func(http.ResponseWriter, *http.Request)
 	start    func(*API)
+	jobs     *jobs
 }
 
 // NewAPI returns a new api instance
 func NewAPI(g *gobot.Gobot) *API {
 	return &API{
-		gobot:  g,
-		router: pat.New(),
-		Port:   "3000",
+		gobot:   g,
+		router:  pat.New(),
+		Port:    "3000",
+		MaxJobs: DefaultMaxJobs,
+		jobs:    newJobs(),
+		Rules:   rules.NewEngine(g),
+		Metrics: gobot.DefaultMetrics,
 		start: func(a *API) {
-			log.Println("Initializing API on " + a.Host + ":" + a.Port + "...")
+			a.gobot.Logger().Log(gobot.InfoLevel, "Initializing API on "+a.Host+":"+a.Port+"...", nil)
 			http.Handle("/", a)
 
 			go func() {
 				if a.Cert != "" && a.Key != "" {
 					http.ListenAndServeTLS(a.Host+":"+a.Port, a.Cert, a.Key, nil)
 				} else {
-					log.Println("WARNING: API using insecure connection. " +
-						"We recommend using an SSL certificate with Gobot.")
+					a.gobot.Logger().Log(gobot.WarnLevel, "API using insecure connection. "+
+						"We recommend using an SSL certificate with Gobot.", nil)
 					http.ListenAndServe(a.Host+":"+a.Port, nil)
 				}
 			}()
@@ -105,6 +114,8 @@ func (a *API) Start() {
 	mcpCommandRoute := "/api/commands/:command"
 	robotDeviceCommandRoute := "/api/robots/:robot/devices/:device/commands/:command"
 	robotCommandRoute := "/api/robots/:robot/commands/:command"
+	groupCommandRoute := "/api/groups/:group/commands/:command"
+	groupDeviceCommandRoute := "/api/groups/:group/devices/:device/commands/:command"
 
 	a.Get("/api/commands", a.mcpCommands)
 	a.Get(mcpCommandRoute, a.executeMcpCommand)
@@ -117,11 +128,29 @@ func (a *API) Start() {
 	a.Get("/api/robots/:robot/devices", a.robotDevices)
 	a.Get("/api/robots/:robot/devices/:device", a.robotDevice)
 	a.Get("/api/robots/:robot/devices/:device/events/:event", a.robotDeviceEvent)
+	a.Get("/api/robots/:robot/devices/:device/events/:event/history", a.robotDeviceEventHistory)
 	a.Get("/api/robots/:robot/devices/:device/commands", a.robotDeviceCommands)
 	a.Get(robotDeviceCommandRoute, a.executeRobotDeviceCommand)
 	a.Post(robotDeviceCommandRoute, a.executeRobotDeviceCommand)
 	a.Get("/api/robots/:robot/connections", a.robotConnections)
 	a.Get("/api/robots/:robot/connections/:connection", a.robotConnection)
+	a.Get("/api/groups", a.groups)
+	a.Get("/api/groups/:group", a.group)
+	a.Get(groupCommandRoute, a.executeGroupCommand)
+	a.Post(groupCommandRoute, a.executeGroupCommand)
+	a.Get(groupDeviceCommandRoute, a.executeGroupDeviceCommand)
+	a.Post(groupDeviceCommandRoute, a.executeGroupDeviceCommand)
+	a.Get("/api/groups/:group/events/:event", a.groupEvent)
+	a.Get("/api/groups/:group/devices/:device/events/:event", a.groupEvent)
+	a.Get("/api/rules", a.rulesIndex)
+	a.Post("/api/rules", a.addRule)
+	a.Get("/api/rules/:rule", a.rule)
+	a.Put("/api/rules/:rule", a.addRule)
+	a.Delete("/api/rules/:rule", a.removeRule)
+	a.Get("/api/jobs", a.jobsIndex)
+	a.Get("/api/jobs/:id", a.job)
+	a.Delete("/api/jobs/:id", a.cancelJob)
+	a.Get("/metrics", a.metrics)
 	a.Get("/api/", a.mcp)
 
 	a.Get("/", func(res http.ResponseWriter, req *http.Request) {
@@ -167,7 +196,8 @@ func (a *API) mcp(res http.ResponseWriter, req *http.Request) {
 // mcpCommands returns commands route handler.
 // Writes JSON with global commands representation
 func (a *API) mcpCommands(res http.ResponseWriter, req *http.Request) {
-	a.writeJSON(map[string]interface{}{"commands": gobot.NewJSONGobot(a.gobot).Commands}, res)
+	mcp := gobot.NewJSONGobot(a.gobot)
+	a.writeJSON(map[string]interface{}{"commands": mcp.Commands, "schemas": mcp.CommandSchemas}, res)
 }
 
 // robots returns route handler.
@@ -196,7 +226,7 @@ func (a *API) robotCommands(res http.ResponseWriter, req *http.Request) {
 	if robot, err := a.jsonRobotFor(req.URL.Query().Get(":robot")); err != nil {
 		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
 	} else {
-		a.writeJSON(map[string]interface{}{"commands": robot.Commands}, res)
+		a.writeJSON(map[string]interface{}{"commands": robot.Commands, "schemas": robot.CommandSchemas}, res)
 	}
 }
 
@@ -238,10 +268,17 @@ func (a *API) robotDeviceEvent(res http.ResponseWriter, req *http.Request) {
 	if event := a.gobot.Robot(req.URL.Query().Get(":robot")).
 		Device(req.URL.Query().Get(":device")).(gobot.Eventer).
 		Event(req.URL.Query().Get(":event")); event != nil {
-		gobot.On(event, func(data interface{}) {
+		done := make(chan struct{})
+		defer close(done)
+
+		sub, _ := gobot.On(event, func(data interface{}) {
 			d, _ := json.Marshal(data)
-			dataChan <- string(d)
+			select {
+			case dataChan <- string(d):
+			case <-done:
+			}
 		})
+		defer sub.Unsubscribe()
 
 		for {
 			select {
@@ -249,7 +286,7 @@ func (a *API) robotDeviceEvent(res http.ResponseWriter, req *http.Request) {
 				fmt.Fprintf(res, "data: %v\n\n", data)
 				f.Flush()
 			case <-closer:
-				log.Println("Closing connection")
+				a.gobot.Logger().Log(gobot.DebugLevel, "Closing connection", gobot.Fields{"url": req.URL.String()})
 				return
 			}
 		}
@@ -266,7 +303,7 @@ func (a *API) robotDeviceCommands(res http.ResponseWriter, req *http.Request) {
 	if device, err := a.jsonDeviceFor(req.URL.Query().Get(":robot"), req.URL.Query().Get(":device")); err != nil {
 		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
 	} else {
-		a.writeJSON(map[string]interface{}{"commands": device.Commands}, res)
+		a.writeJSON(map[string]interface{}{"commands": device.Commands, "schemas": device.CommandSchemas}, res)
 	}
 }
 
@@ -297,7 +334,9 @@ func (a *API) robotConnection(res http.ResponseWriter, req *http.Request) {
 
 // executeMcpCommand calls a global command asociated to requested route
 func (a *API) executeMcpCommand(res http.ResponseWriter, req *http.Request) {
-	a.executeCommand(a.gobot.Command(req.URL.Query().Get(":command")),
+	a.executeCommand(a.gobot, req.URL.Query().Get(":command"),
+		gobot.Protect,
+		context.Background(),
 		res,
 		req,
 	)
@@ -308,11 +347,15 @@ func (a *API) executeRobotDeviceCommand(res http.ResponseWriter, req *http.Reque
 	if _, err := a.jsonDeviceFor(req.URL.Query().Get(":robot"),
 		req.URL.Query().Get(":device")); err != nil {
 		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
+	} else if err := a.robotRunning(req.URL.Query().Get(":robot")); err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusConflict, res)
 	} else {
+		robot := a.gobot.Robot(req.URL.Query().Get(":robot"))
 		a.executeCommand(
-			a.gobot.Robot(req.URL.Query().Get(":robot")).
-				Device(req.URL.Query().Get(":device")).(gobot.Commander).
-				Command(req.URL.Query().Get(":command")),
+			robot.Device(req.URL.Query().Get(":device")).(gobot.Commander),
+			req.URL.Query().Get(":command"),
+			robot.Protect,
+			robot.JobContext(),
 			res,
 			req,
 		)
@@ -323,18 +366,30 @@ func (a *API) executeRobotDeviceCommand(res http.ResponseWriter, req *http.Reque
 func (a *API) executeRobotCommand(res http.ResponseWriter, req *http.Request) {
 	if _, err := a.jsonRobotFor(req.URL.Query().Get(":robot")); err != nil {
 		a.writeJSON(map[string]interface{}{"error": err.Error()}, res)
+	} else if err := a.robotRunning(req.URL.Query().Get(":robot")); err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusConflict, res)
 	} else {
+		robot := a.gobot.Robot(req.URL.Query().Get(":robot"))
 		a.executeCommand(
-			a.gobot.Robot(req.URL.Query().Get(":robot")).
-				Command(req.URL.Query().Get(":command")),
+			robot,
+			req.URL.Query().Get(":command"),
+			robot.Protect,
+			robot.JobContext(),
 			res,
 			req,
 		)
 	}
 }
 
-// executeCommand writes JSON response with `f` returned value.
-func (a *API) executeCommand(f func(map[string]interface{}) interface{},
+// executeCommand writes JSON response with the value returned by the named
+// command of `c`. Params not matching the schema of the command are rejected
+// with a 400 response. `protect` runs the command, recovering from its panics.
+// Asynchronous commands are started as a job, cancelled once ctx is done, and a
+// 202 response with the status of the job is written instead.
+func (a *API) executeCommand(c gobot.Commander,
+	name string,
+	protect func(func()) error,
+	ctx context.Context,
 	res http.ResponseWriter,
 	req *http.Request,
 ) {
@@ -342,24 +397,59 @@ func (a *API) executeCommand(f func(map[string]interface{}) interface{},
 	body := make(map[string]interface{})
 	json.NewDecoder(req.Body).Decode(&body)
 
-	if f != nil {
-		a.writeJSON(map[string]interface{}{"result": f(body)}, res)
-	} else {
+	f := c.Command(name)
+	if f == nil {
 		a.writeJSON(map[string]interface{}{"error": "Unknown Command"}, res)
+		return
+	}
+	commander := gobot.CommanderOf(c)
+	if sc, ok := commander.(gobot.SchemaCommander); ok {
+		if schema := sc.CommandSchema(name); schema != nil {
+			valid, err := schema.Validate(body)
+			if err != nil {
+				a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusBadRequest, res)
+				return
+			}
+			body = valid
+		}
 	}
+
+	if ac, ok := commander.(gobot.AsyncCommander); ok {
+		if async := ac.AsyncCommand(name); async != nil {
+			job := a.jobs.start(ctx, name, async, body, protect, a.MaxJobs)
+			res.Header().Set("Location", "/api/jobs/"+job.ID())
+			a.writeJSONStatus(map[string]interface{}{"job": job.Status()}, http.StatusAccepted, res)
+			return
+		}
+	}
+
+	var result interface{}
+	if err := protect(func() { result = f(body) }); err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusInternalServerError, res)
+		return
+	}
+	a.writeJSON(map[string]interface{}{"result": result}, res)
 }
 
 // writeJSON writes `j` as JSON in response
 func (a *API) writeJSON(j interface{}, res http.ResponseWriter) {
+	a.writeJSONStatus(j, http.StatusOK, res)
+}
+
+// writeJSONStatus writes `j` as JSON in response with the given status code
+func (a *API) writeJSONStatus(j interface{}, status int, res http.ResponseWriter) {
 	data, _ := json.Marshal(j)
 	res.Header().Set("Content-Type", "application/json; charset=utf-8")
+	res.WriteHeader(status)
 	res.Write(data)
 }
 
-// Debug add handler to api that prints each request
+// Debug add handler to api that logs each request
 func (a *API) Debug() {
 	a.AddHandler(func(res http.ResponseWriter, req *http.Request) {
-		log.Println(req)
+		a.gobot.Logger().Log(gobot.DebugLevel, req.Method+" "+req.URL.String(), gobot.Fields{
+			"remote": req.RemoteAddr,
+		})
 	})
 }
 
@@ -372,6 +462,14 @@ func (a *API) jsonRobotFor(name string) (jrobot *gobot.JSONRobot, err error) {
 	return
 }
 
+// robotRunning returns an error unless the named robot is running
+func (a *API) robotRunning(name string) (err error) {
+	if state := a.gobot.Robot(name).State(); state != gobot.StateRunning {
+		err = fmt.Errorf("Robot %v is %v, not running", name, state)
+	}
+	return
+}
+
 func (a *API) jsonDeviceFor(robot string, name string) (jdevice *gobot.JSONDevice, err error) {
 	if device := a.gobot.Robot(robot).Device(name); device != nil {
 		jdevice = gobot.NewJSONDevice(device)
@@ -3,11 +3,13 @@ package api
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -25,6 +27,7 @@ func initTestAPI() *API {
 	g.AddRobot(newTestRobot("Robot1"))
 	g.AddRobot(newTestRobot("Robot2"))
 	g.AddRobot(newTestRobot("Robot3"))
+	g.Robots().Start()
 	g.AddCommand("TestFunction", func(params map[string]interface{}) interface{} {
 		message := params["message"].(string)
 		return fmt.Sprintf("hey %v", message)
@@ -230,6 +233,331 @@ func TestExecuteRobotCommand(t *testing.T) {
 	gobot.Assert(t, body.(map[string]interface{})["error"], "No Robot found with the name UnknownRobot1")
 }
 
+func TestExecuteCommandRobotNotRunning(t *testing.T) {
+	var body interface{}
+	a := initTestAPI()
+	a.gobot.Robot("Robot1").Stop()
+
+	for _, path := range []string{
+		"/api/robots/Robot1/commands/robotTestFunction",
+		"/api/robots/Robot1/devices/Device1/commands/TestDriverCommand",
+	} {
+		request, _ := http.NewRequest("GET", path, bytes.NewBufferString(`{"message":"Beep Boop"}`))
+		request.Header.Add("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		a.ServeHTTP(response, request)
+
+		gobot.Assert(t, response.Code, http.StatusConflict)
+		json.NewDecoder(response.Body).Decode(&body)
+		gobot.Assert(t, body.(map[string]interface{})["error"], "Robot Robot1 is stopped, not running")
+	}
+}
+
+func TestExecuteCommandPanic(t *testing.T) {
+	var body interface{}
+	a := initTestAPI()
+	robot := a.gobot.Robot("Robot1")
+	robot.AddCommand("panic", func(params map[string]interface{}) interface{} {
+		panic("boom")
+	})
+	errs := make(chan error, 1)
+	gobot.On(robot.Event(gobot.ErrorEvent), func(data interface{}) {
+		errs <- data.(error)
+	})
+
+	request, _ := http.NewRequest("GET", "/api/robots/Robot1/commands/panic", bytes.NewBufferString(`{}`))
+	request.Header.Add("Content-Type", "application/json")
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+
+	gobot.Assert(t, response.Code, http.StatusInternalServerError)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body.(map[string]interface{})["error"], "panic: boom")
+
+	select {
+	case err := <-errs:
+		gobot.Assert(t, err.Error(), "panic: boom")
+	case <-time.After(time.Second):
+		t.Error("error was not published")
+	}
+	gobot.Assert(t, robot.State(), gobot.StateRunning)
+
+	a.gobot.AddCommand("panic", func(params map[string]interface{}) interface{} {
+		panic("boom")
+	})
+	request, _ = http.NewRequest("GET", "/api/commands/panic", bytes.NewBufferString(`{}`))
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusInternalServerError)
+}
+
+func TestExecuteCommandSchema(t *testing.T) {
+	var body interface{}
+	a := initTestAPI()
+	robot := a.gobot.Robot("Robot1")
+	robot.Commander.(gobot.SchemaCommander).AddCommandSchema("move", &gobot.CommandSchema{
+		Params: []gobot.Param{
+			gobot.Param{Name: "angle", Type: gobot.IntegerParam, Required: true}.Range(0, 180),
+		},
+	}, func(params map[string]interface{}) interface{} {
+		return params["angle"]
+	})
+
+	request, _ := http.NewRequest("GET", "/api/robots/Robot1/commands/move", bytes.NewBufferString(`{"angle":90}`))
+	request.Header.Add("Content-Type", "application/json")
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusOK)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body.(map[string]interface{})["result"], 90.0)
+
+	request, _ = http.NewRequest("GET", "/api/robots/Robot1/commands/move", bytes.NewBufferString(`{"angle":"up"}`))
+	request.Header.Add("Content-Type", "application/json")
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusBadRequest)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body.(map[string]interface{})["error"], `Param "angle" must be an integer, got up`)
+
+	request, _ = http.NewRequest("GET", "/api/robots/Robot1/commands", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	schema := body.(map[string]interface{})["schemas"].(map[string]interface{})["move"]
+	param := schema.(map[string]interface{})["params"].([]interface{})[0].(map[string]interface{})
+	gobot.Assert(t, param["name"], "angle")
+	gobot.Assert(t, param["type"], "integer")
+	gobot.Assert(t, param["max"], 180.0)
+}
+
+func TestExecuteAsyncCommand(t *testing.T) {
+	var body map[string]interface{}
+	a := initTestAPI()
+	robot := a.gobot.Robot("Robot1")
+	started := make(chan struct{})
+	robot.Commander.(gobot.AsyncCommander).AddAsyncCommand("wait", nil, func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		progress(0.5)
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	request, _ := http.NewRequest("POST", "/api/robots/Robot1/commands/wait", bytes.NewBufferString(`{}`))
+	request.Header.Add("Content-Type", "application/json")
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusAccepted)
+	gobot.Assert(t, response.Header().Get("Location"), "/api/jobs/1")
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["job"].(map[string]interface{})["id"], "1")
+	gobot.Assert(t, body["job"].(map[string]interface{})["command"], "wait")
+
+	<-started
+	request, _ = http.NewRequest("GET", "/api/jobs/1", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["job"].(map[string]interface{})["state"], "running")
+	gobot.Assert(t, body["job"].(map[string]interface{})["progress"], 0.5)
+
+	request, _ = http.NewRequest("GET", "/api/jobs", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, len(body["jobs"].([]interface{})), 1)
+
+	request, _ = http.NewRequest("DELETE", "/api/jobs/1", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusOK)
+	gobot.Assert(t, a.Job("1").Wait().State, gobot.JobCancelled)
+
+	request, _ = http.NewRequest("GET", "/api/jobs/1", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["job"].(map[string]interface{})["state"], "cancelled")
+
+	request, _ = http.NewRequest("DELETE", "/api/jobs/2", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusNotFound)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["error"], "No Job found with the id 2")
+}
+
+func TestAsyncCommandCancelledOnStop(t *testing.T) {
+	a := initTestAPI()
+	robot := a.gobot.Robot("Robot1")
+	started := make(chan struct{})
+	robot.Commander.(gobot.AsyncCommander).AddAsyncCommand("wait", nil, func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	request, _ := http.NewRequest("POST", "/api/robots/Robot1/commands/wait", bytes.NewBufferString(`{}`))
+	request.Header.Add("Content-Type", "application/json")
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusAccepted)
+
+	<-started
+	robot.Stop()
+	gobot.Assert(t, a.Job("1").Wait().State, gobot.JobCancelled)
+}
+
+func TestMaxJobs(t *testing.T) {
+	a := initTestAPI()
+	a.MaxJobs = 1
+	a.gobot.Commander.(gobot.AsyncCommander).AddAsyncCommand("done", nil, func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		return nil
+	})
+
+	for i := 0; i < 3; i++ {
+		request, _ := http.NewRequest("POST", "/api/commands/done", bytes.NewBufferString(`{}`))
+		response := httptest.NewRecorder()
+		a.ServeHTTP(response, request)
+		gobot.Assert(t, response.Code, http.StatusAccepted)
+		a.Job(fmt.Sprint(i + 1)).Wait()
+	}
+
+	gobot.Assert(t, a.Job("1"), (*gobot.Job)(nil))
+	gobot.Refute(t, a.Job("3"), (*gobot.Job)(nil))
+}
+
+func TestGroups(t *testing.T) {
+	var body map[string]interface{}
+	a := initTestAPI()
+	a.gobot.Robot("Robot1").AddTag("swarm")
+	a.gobot.Robot("Robot2").AddTag("swarm")
+
+	request, _ := http.NewRequest("GET", "/api/groups", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, len(body["groups"].([]interface{})), 1)
+
+	request, _ = http.NewRequest("GET", "/api/groups/swarm", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["group"].(map[string]interface{})["robots"], []interface{}{"Robot1", "Robot2"})
+
+	request, _ = http.NewRequest("GET", "/api/groups/unknown", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusNotFound)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["error"], "No Group found with the name unknown")
+}
+
+func TestExecuteGroupCommand(t *testing.T) {
+	var body map[string]interface{}
+	a := initTestAPI()
+	a.gobot.Robot("Robot1").AddTag("swarm")
+	a.gobot.Robot("Robot2").AddTag("swarm")
+	a.gobot.Robot("Robot2").Stop()
+
+	request, _ := http.NewRequest("POST",
+		"/api/groups/swarm/devices/Device1/commands/TestDriverCommand",
+		bytes.NewBufferString(`{"name":"human"}`),
+	)
+	request.Header.Add("Content-Type", "application/json")
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	results := body["results"].([]interface{})
+	gobot.Assert(t, results[0], map[string]interface{}{"robot": "Robot1", "result": "hello human"})
+	gobot.Assert(t, results[1], map[string]interface{}{
+		"robot":  "Robot2",
+		"result": nil,
+		"error":  "Robot Robot2 is stopped, not running",
+	})
+
+	request, _ = http.NewRequest("POST",
+		"/api/groups/swarm/commands/robotTestFunction",
+		bytes.NewBufferString(`{"message":"Beep Boop","robot":"Robot1"}`),
+	)
+	request.Header.Add("Content-Type", "application/json")
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	results = body["results"].([]interface{})
+	gobot.Assert(t, results[0].(map[string]interface{})["result"], "hey Robot1, Beep Boop")
+}
+
+func TestRules(t *testing.T) {
+	var body map[string]interface{}
+	a := initTestAPI()
+	rule := `{"name":"hello","robot":"Robot1",
+		"when":{"device":"Device1","event":"TestEvent"},
+		"then":[{"device":"Device2","command":"TestDriverCommand","params":{"name":"$data"}}]}`
+
+	request, _ := http.NewRequest("POST", "/api/rules", bytes.NewBufferString(rule))
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusOK)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["rule"].(map[string]interface{})["name"], "hello")
+
+	gobot.Publish(a.gobot.Robot("Robot1").Device("Device1").(gobot.Eventer).Event("TestEvent"), "human")
+	<-time.After(10 * time.Millisecond)
+
+	request, _ = http.NewRequest("GET", "/api/rules/hello", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["rule"].(map[string]interface{})["runs"], 1.0)
+
+	request, _ = http.NewRequest("PUT", "/api/rules/hello", bytes.NewBufferString(`{"robot":"Robot1",
+		"when":{"device":"Device1","event":"Unknown"},
+		"then":[{"device":"Device2","command":"TestDriverCommand"}]}`))
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusBadRequest)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, body["error"], `Rule "hello": No Event found with the name Unknown`)
+
+	request, _ = http.NewRequest("GET", "/api/rules", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	json.NewDecoder(response.Body).Decode(&body)
+	gobot.Assert(t, len(body["rules"].([]interface{})), 1)
+
+	request, _ = http.NewRequest("DELETE", "/api/rules/hello", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusOK)
+
+	request, _ = http.NewRequest("GET", "/api/rules/hello", nil)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, http.StatusNotFound)
+}
+
+func TestMetrics(t *testing.T) {
+	a := initTestAPI()
+
+	request, _ := http.NewRequest("GET", "/api/commands/TestFunction", bytes.NewBufferString(`{"message":"Beep Boop"}`))
+	a.ServeHTTP(httptest.NewRecorder(), request)
+
+	request, _ = http.NewRequest("GET", "/metrics", nil)
+	response := httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Code, 200)
+	gobot.Assert(t, response.Header().Get("Content-Type"), "text/plain; version=0.0.4; charset=utf-8")
+	gobot.Assert(t, strings.Contains(response.Body.String(), "# TYPE gobot_command_invocations_total counter\n"), true)
+	gobot.Assert(t, strings.Contains(response.Body.String(), `gobot_command_invocations_total{command="TestFunction"}`), true)
+
+	a.Metrics = gobot.NewMetrics()
+	a.Metrics.Counter("test_total", "Test counter.", "robot").With("Robot1").Add(2)
+	response = httptest.NewRecorder()
+	a.ServeHTTP(response, request)
+	gobot.Assert(t, response.Body.String(),
+		"# HELP test_total Test counter.\n# TYPE test_total counter\ntest_total{robot=\"Robot1\"} 2\n")
+}
+
 func TestRobotDevice(t *testing.T) {
 	a := initTestAPI()
 
@@ -243,7 +571,11 @@ func TestRobotDevice(t *testing.T) {
 
 	var body map[string]interface{}
 	json.NewDecoder(response.Body).Decode(&body)
-	gobot.Assert(t, body["device"].(map[string]interface{})["name"].(string), "Device1")
+	device := body["device"].(map[string]interface{})
+	gobot.Assert(t, device["name"].(string), "Device1")
+	gobot.Assert(t, device["pin"].(string), "0")
+	gobot.Assert(t, device["state"], map[string]interface{}{"ready": true})
+	gobot.Assert(t, device["events"], []interface{}{"TestEvent"})
 
 	// unknown device
 	request, _ = http.NewRequest("GET",
@@ -406,6 +738,12 @@ func TestRobotDeviceEvent(t *testing.T) {
 
 	server.CloseClientConnections()
 
+	// the subscription is removed once the client disconnects
+	for i := 0; event.SubscriberCount() > 0 && i < 100; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	gobot.Assert(t, event.SubscriberCount(), 0)
+
 	// unknown event
 	response, _ := http.Get(server.URL + eventsUrl + "UnknownEvent")
 
@@ -414,6 +752,54 @@ func TestRobotDeviceEvent(t *testing.T) {
 	gobot.Assert(t, body["error"], "No Event found with the name UnknownEvent")
 }
 
+func TestRobotDeviceEventHistory(t *testing.T) {
+	a := initTestAPI()
+	historyUrl := "/api/robots/Robot1/devices/Device1/events/TestEvent/history"
+	get := func(url string) (int, map[string]interface{}) {
+		request, _ := http.NewRequest("GET", url, nil)
+		response := httptest.NewRecorder()
+		a.ServeHTTP(response, request)
+		var body map[string]interface{}
+		json.NewDecoder(response.Body).Decode(&body)
+		return response.Code, body
+	}
+
+	// no history kept
+	code, body := get(historyUrl)
+	gobot.Assert(t, code, 404)
+	gobot.Assert(t, body["error"], "Event TestEvent keeps no history")
+
+	event := a.gobot.Robot("Robot1").Device("Device1").(gobot.Eventer).Event("TestEvent")
+	event.SetHistory(10, 0)
+	event.Write("first")
+	since := gobot.Now()
+	time.Sleep(time.Millisecond)
+	event.Write("second")
+
+	code, body = get(historyUrl)
+	gobot.Assert(t, code, 200)
+	history := body["history"].([]interface{})
+	gobot.Assert(t, len(history), 2)
+	gobot.Assert(t, history[0].(map[string]interface{})["data"], "first")
+
+	code, body = get(historyUrl + "?since=" + since.Format(time.RFC3339Nano))
+	gobot.Assert(t, code, 200)
+	history = body["history"].([]interface{})
+	gobot.Assert(t, len(history), 1)
+	gobot.Assert(t, history[0].(map[string]interface{})["data"], "second")
+
+	code, _ = get(historyUrl + "?since=yesterday")
+	gobot.Assert(t, code, 400)
+
+	code, body = get("/api/robots/Robot1/devices/Device1/events/UnknownEvent/history")
+	gobot.Assert(t, code, 404)
+	gobot.Assert(t, body["error"], "No Event found with the name UnknownEvent")
+
+	code, body = get("/api/robots/Robot1/devices/UnknownDevice/events/TestEvent/history")
+	gobot.Assert(t, code, 404)
+	gobot.Assert(t, body["error"], "No Device found with the name UnknownDevice")
+}
+
 func TestAPIRouter(t *testing.T) {
 	a := initTestAPI()
 
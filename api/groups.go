@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// groupFor returns the group given a name or an error if it has no robots.
+func (a *API) groupFor(name string) (*gobot.Group, error) {
+	if group := a.gobot.Group(name); group.Len() > 0 {
+		return group, nil
+	}
+	return nil, fmt.Errorf("No Group found with the name %v", name)
+}
+
+// groups returns groups route handler.
+// Writes JSON with groups representation
+func (a *API) groups(res http.ResponseWriter, req *http.Request) {
+	jsonGroups := []*gobot.JSONGroup{}
+	for _, name := range a.gobot.Groups() {
+		jsonGroups = append(jsonGroups, gobot.NewJSONGroup(a.gobot.Group(name)))
+	}
+	a.writeJSON(map[string]interface{}{"groups": jsonGroups}, res)
+}
+
+// group returns group route handler.
+// Writes JSON with group representation
+func (a *API) group(res http.ResponseWriter, req *http.Request) {
+	if group, err := a.groupFor(req.URL.Query().Get(":group")); err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusNotFound, res)
+	} else {
+		a.writeJSON(map[string]interface{}{"group": gobot.NewJSONGroup(group)}, res)
+	}
+}
+
+// executeGroupCommand calls a robot command on each robot of the group
+// asociated to requested route
+func (a *API) executeGroupCommand(res http.ResponseWriter, req *http.Request) {
+	a.executeGroup(res, req, func(g *gobot.Group, params map[string]interface{}) gobot.GroupResults {
+		return g.Command(req.URL.Query().Get(":command"), params)
+	})
+}
+
+// executeGroupDeviceCommand calls a device command on each robot of the group
+// asociated to requested route
+func (a *API) executeGroupDeviceCommand(res http.ResponseWriter, req *http.Request) {
+	a.executeGroup(res, req, func(g *gobot.Group, params map[string]interface{}) gobot.GroupResults {
+		return g.DeviceCommand(req.URL.Query().Get(":device"), req.URL.Query().Get(":command"), params)
+	})
+}
+
+// executeGroup writes JSON response with the result or error of the command
+// called by `f` for each robot of the requested group.
+func (a *API) executeGroup(res http.ResponseWriter,
+	req *http.Request,
+	f func(*gobot.Group, map[string]interface{}) gobot.GroupResults,
+) {
+	group, err := a.groupFor(req.URL.Query().Get(":group"))
+	if err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusNotFound, res)
+		return
+	}
+
+	body := make(map[string]interface{})
+	json.NewDecoder(req.Body).Decode(&body)
+
+	results := []map[string]interface{}{}
+	for _, r := range f(group, body) {
+		result := map[string]interface{}{"robot": r.Robot, "result": r.Result}
+		if r.Err != nil {
+			result["error"] = r.Err.Error()
+		}
+		results = append(results, result)
+	}
+	a.writeJSON(map[string]interface{}{"results": results}, res)
+}
+
+// groupEvent streams the merged robot or device event of each robot of the
+// group asociated to requested route
+func (a *API) groupEvent(res http.ResponseWriter, req *http.Request) {
+	group, err := a.groupFor(req.URL.Query().Get(":group"))
+	if err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusNotFound, res)
+		return
+	}
+
+	f, _ := res.(http.Flusher)
+	c, _ := res.(http.CloseNotifier)
+
+	dataChan := make(chan string)
+	closer := c.CloseNotify()
+	done := make(chan struct{})
+	defer close(done)
+
+	send := func(e gobot.GroupEvent) {
+		d, _ := json.Marshal(e)
+		select {
+		case dataChan <- string(d):
+		case <-done:
+		}
+	}
+
+	var sub *gobot.GroupSubscription
+	if device := req.URL.Query().Get(":device"); device != "" {
+		sub = group.OnDevice(device, req.URL.Query().Get(":event"), send)
+	} else {
+		sub = group.On(req.URL.Query().Get(":event"), send)
+	}
+	defer sub.Unsubscribe()
+
+	res.Header().Set("Content-Type", "text/event-stream")
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set("Connection", "keep-alive")
+
+	for {
+		select {
+		case data := <-dataChan:
+			fmt.Fprintf(res, "data: %v\n\n", data)
+			f.Flush()
+		case <-closer:
+			a.gobot.Logger().Log(gobot.DebugLevel, "Closing connection", gobot.Fields{"url": req.URL.String()})
+			return
+		}
+	}
+}
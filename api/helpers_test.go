@@ -33,6 +33,9 @@ func (t *testDriver) Halt() (errs []error)         { return }
 func (t *testDriver) Name() string                 { return t.name }
 func (t *testDriver) Pin() string                  { return t.pin }
 func (t *testDriver) Connection() gobot.Connection { return t.connection }
+func (t *testDriver) ReportState() map[string]interface{} {
+	return map[string]interface{}{"ready": true}
+}
 
 func newTestDriver(adaptor *testAdaptor, name string, pin string) *testDriver {
 	t := &testDriver{
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// robotDeviceEventHistory returns device event history route handler.
+// Writes JSON with the values kept by the event, written after the RFC 3339
+// time of the optional since parameter
+func (a *API) robotDeviceEventHistory(res http.ResponseWriter, req *http.Request) {
+	name := req.URL.Query().Get(":event")
+	var since time.Time
+	if s := req.URL.Query().Get("since"); s != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			a.writeJSONStatus(map[string]interface{}{"error": "since must be an RFC 3339 time: " + err.Error()}, http.StatusBadRequest, res)
+			return
+		}
+	}
+
+	device := a.gobot.Robot(req.URL.Query().Get(":robot")).Device(req.URL.Query().Get(":device"))
+	if device == nil {
+		a.writeJSONStatus(map[string]interface{}{"error": "No Device found with the name " + req.URL.Query().Get(":device")}, http.StatusNotFound, res)
+		return
+	}
+	var event *gobot.Event
+	if eventer, ok := device.(gobot.Eventer); ok {
+		event = eventer.Event(name)
+	}
+	if event == nil {
+		a.writeJSONStatus(map[string]interface{}{"error": "No Event found with the name " + name}, http.StatusNotFound, res)
+		return
+	}
+
+	history := event.History(since)
+	if history == nil {
+		a.writeJSONStatus(map[string]interface{}{"error": "Event " + name + " keeps no history"}, http.StatusNotFound, res)
+		return
+	}
+	a.writeJSON(map[string]interface{}{"history": history}, res)
+}
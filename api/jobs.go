@@ -0,0 +1,105 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// DefaultMaxJobs is the number of finished jobs an API keeps by default
+const DefaultMaxJobs = 100
+
+// jobs keeps the jobs started by the API.
+type jobs struct {
+	mutex sync.RWMutex
+	next  int
+	jobs  map[string]*gobot.Job
+	order []string
+}
+
+func newJobs() *jobs {
+	return &jobs{jobs: make(map[string]*gobot.Job)}
+}
+
+// start starts f as a new job, cancelled once ctx is done, and forgets the
+// oldest finished jobs beyond max.
+func (j *jobs) start(ctx context.Context, command string, f gobot.AsyncCommand, params map[string]interface{}, protect func(func()) error, max int) *gobot.Job {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.next++
+	job := gobot.StartJobContext(ctx, strconv.Itoa(j.next), command, f, params, protect)
+	j.jobs[job.ID()] = job
+	j.order = append(j.order, job.ID())
+
+	finished := 0
+	for _, id := range j.order {
+		if j.jobs[id].State() != gobot.JobRunning {
+			finished++
+		}
+	}
+	order := j.order[:0]
+	for _, id := range j.order {
+		if finished > max && j.jobs[id].State() != gobot.JobRunning {
+			delete(j.jobs, id)
+			finished--
+			continue
+		}
+		order = append(order, id)
+	}
+	j.order = order
+
+	return job
+}
+
+func (j *jobs) find(id string) *gobot.Job {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.jobs[id]
+}
+
+func (j *jobs) statuses() []gobot.JobStatus {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	statuses := []gobot.JobStatus{}
+	for _, id := range j.order {
+		statuses = append(statuses, j.jobs[id].Status())
+	}
+	return statuses
+}
+
+// Job returns the job started by the API given an id. Returns nil if the job
+// is not found.
+func (a *API) Job(id string) *gobot.Job {
+	return a.jobs.find(id)
+}
+
+// jobsIndex returns jobs route handler.
+// Writes JSON with the status of all jobs
+func (a *API) jobsIndex(res http.ResponseWriter, req *http.Request) {
+	a.writeJSON(map[string]interface{}{"jobs": a.jobs.statuses()}, res)
+}
+
+// job returns job route handler.
+// Writes JSON with the status of the job
+func (a *API) job(res http.ResponseWriter, req *http.Request) {
+	if job := a.jobs.find(req.URL.Query().Get(":id")); job != nil {
+		a.writeJSON(map[string]interface{}{"job": job.Status()}, res)
+	} else {
+		a.writeJSONStatus(map[string]interface{}{"error": "No Job found with the id " + req.URL.Query().Get(":id")}, http.StatusNotFound, res)
+	}
+}
+
+// cancelJob returns job route handler for DELETE.
+// Cancels the job and writes JSON with its status
+func (a *API) cancelJob(res http.ResponseWriter, req *http.Request) {
+	if job := a.jobs.find(req.URL.Query().Get(":id")); job != nil {
+		job.Cancel()
+		a.writeJSON(map[string]interface{}{"job": job.Status()}, res)
+	} else {
+		a.writeJSONStatus(map[string]interface{}{"error": "No Job found with the id " + req.URL.Query().Get(":id")}, http.StatusNotFound, res)
+	}
+}
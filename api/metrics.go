@@ -0,0 +1,10 @@
+package api
+
+import "net/http"
+
+// metrics writes the metrics of the API's Metrics in the Prometheus text
+// exposition format
+func (a *API) metrics(res http.ResponseWriter, req *http.Request) {
+	res.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	a.Metrics.WriteText(res)
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/hybridgroup/gobot/rules"
+)
+
+// rulesIndex returns rules route handler.
+// Writes JSON with the status of all rules
+func (a *API) rulesIndex(res http.ResponseWriter, req *http.Request) {
+	a.writeJSON(map[string]interface{}{"rules": a.Rules.Rules()}, res)
+}
+
+// rule returns rule route handler.
+// Writes JSON with the status of the rule
+func (a *API) rule(res http.ResponseWriter, req *http.Request) {
+	if status := a.Rules.Rule(req.URL.Query().Get(":rule")); status != nil {
+		a.writeJSON(map[string]interface{}{"rule": status}, res)
+	} else {
+		a.writeJSONStatus(map[string]interface{}{"error": "No Rule found with the name " + req.URL.Query().Get(":rule")}, http.StatusNotFound, res)
+	}
+}
+
+// addRule returns rule route handler for POST and PUT.
+// Adds or replaces the rule in the request body and writes JSON with its status
+func (a *API) addRule(res http.ResponseWriter, req *http.Request) {
+	var r rules.Rule
+	if err := json.NewDecoder(req.Body).Decode(&r); err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusBadRequest, res)
+		return
+	}
+	if name := req.URL.Query().Get(":rule"); name != "" {
+		r.Name = name
+	}
+	if err := a.Rules.Add(r); err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusBadRequest, res)
+		return
+	}
+	a.writeJSON(map[string]interface{}{"rule": a.Rules.Rule(r.Name)}, res)
+}
+
+// removeRule returns rule route handler for DELETE.
+// Removes the rule
+func (a *API) removeRule(res http.ResponseWriter, req *http.Request) {
+	if err := a.Rules.Remove(req.URL.Query().Get(":rule")); err != nil {
+		a.writeJSONStatus(map[string]interface{}{"error": err.Error()}, http.StatusNotFound, res)
+	} else {
+		a.writeJSON(map[string]interface{}{"rule": req.URL.Query().Get(":rule")}, res)
+	}
+}
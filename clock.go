@@ -0,0 +1,78 @@
+package gobot
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time and waits for durations to pass. Schedules and the
+// polling loops of drivers use the Clock set by SetClock, so that tests can
+// replace the real clock with a fake one they advance themselves.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel receiving the current time once d has passed.
+	After(d time.Duration) <-chan time.Time
+	// NewTimer returns a Timer firing once d has passed.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single event created by a Clock, which can be stopped before it
+// fires.
+type Timer interface {
+	// C returns the channel receiving the time the Timer fired at.
+	C() <-chan time.Time
+	// Stop prevents the Timer from firing. Returns false if it already fired
+	// or was stopped.
+	Stop() bool
+}
+
+var (
+	clockMutex sync.RWMutex
+	clock      Clock = realClock{}
+)
+
+// SetClock replaces the Clock used by gobot and returns the previous one. A nil
+// Clock restores the real clock.
+func SetClock(c Clock) Clock {
+	if c == nil {
+		c = realClock{}
+	}
+	clockMutex.Lock()
+	defer clockMutex.Unlock()
+	previous := clock
+	clock = c
+	return previous
+}
+
+// currentClock returns the Clock set by SetClock.
+func currentClock() Clock {
+	clockMutex.RLock()
+	defer clockMutex.RUnlock()
+	return clock
+}
+
+// Now returns the current time of the Clock set by SetClock.
+func Now() time.Time {
+	return currentClock().Now()
+}
+
+// Wait returns a channel receiving the current time once d has passed on the
+// Clock set by SetClock. Drivers use it in place of time.After to wait between
+// polls.
+func Wait(d time.Duration) <-chan time.Time {
+	return currentClock().After(d)
+}
+
+// realClock is the Clock of the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time { return t.Timer.C }
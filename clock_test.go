@@ -0,0 +1,36 @@
+package gobot
+
+import (
+	"testing"
+	"time"
+)
+
+type testClock struct {
+	realClock
+	now time.Time
+}
+
+func (c testClock) Now() time.Time { return c.now }
+
+func TestSetClock(t *testing.T) {
+	now := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	previous := SetClock(testClock{now: now})
+	Assert(t, previous, Clock(realClock{}))
+	Assert(t, Now(), now)
+
+	Assert(t, SetClock(nil), Clock(testClock{now: now}))
+	Refute(t, Now(), now)
+	Assert(t, SetClock(previous), Clock(realClock{}))
+}
+
+func TestWait(t *testing.T) {
+	start := time.Now()
+	<-Wait(5 * time.Millisecond)
+	Assert(t, time.Since(start) >= 5*time.Millisecond, true)
+
+	timer := currentClock().NewTimer(time.Hour)
+	Assert(t, timer.Stop(), true)
+	timer = currentClock().NewTimer(time.Millisecond)
+	<-timer.C()
+	Assert(t, timer.Stop(), false)
+}
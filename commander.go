@@ -1,7 +1,14 @@
 package gobot
 
+import (
+	"context"
+	"reflect"
+)
+
 type commander struct {
 	commands map[string]func(map[string]interface{}) interface{}
+	schemas  map[string]*CommandSchema
+	asyncs   map[string]AsyncCommand
 }
 
 // Commander is the interface which describes the behaviour for a Driver or Adaptor
@@ -15,10 +22,41 @@ type Commander interface {
 	AddCommand(name string, command func(map[string]interface{}) interface{})
 }
 
-// NewCommander returns a new Commander.
+// SchemaCommander is the interface which describes a Commander whose commands
+// may have a schema their params are validated against.
+type SchemaCommander interface {
+	Commander
+	// AddCommandSchema adds a command given a name and the schema its params
+	// are validated against before it is called. When they do not match, the
+	// command returns a *ParamError instead of being called.
+	AddCommandSchema(name string, schema *CommandSchema, command func(map[string]interface{}) interface{})
+	// CommandSchema returns the schema of a command given a name. Returns nil if
+	// the command has no schema.
+	CommandSchema(name string) (schema *CommandSchema)
+	// CommandSchemas returns a map of the schemas of commands which have one.
+	CommandSchemas() (schemas map[string]*CommandSchema)
+}
+
+// AsyncCommander is the interface which describes a SchemaCommander which
+// also exposes long-running commands, run as jobs by the API.
+type AsyncCommander interface {
+	SchemaCommander
+	// AddAsyncCommand adds a long-running command given a name and an optional
+	// schema. It is also added as a regular command which blocks until the
+	// AsyncCommand returns.
+	AddAsyncCommand(name string, schema *CommandSchema, command AsyncCommand)
+	// AsyncCommand returns the long-running command given a name. Returns nil if
+	// the command is not found or was not added with AddAsyncCommand.
+	AsyncCommand(name string) (command AsyncCommand)
+}
+
+// NewCommander returns a new Commander, which also implements SchemaCommander
+// and AsyncCommander.
 func NewCommander() Commander {
 	return &commander{
 		commands: make(map[string]func(map[string]interface{}) interface{}),
+		schemas:  make(map[string]*CommandSchema),
+		asyncs:   make(map[string]AsyncCommand),
 	}
 }
 
@@ -32,5 +70,119 @@ func (c *commander) Commands() map[string]func(map[string]interface{}) interface
 }
 
 func (c *commander) AddCommand(name string, command func(map[string]interface{}) interface{}) {
-	c.commands[name] = command
+	c.commands[name] = measured(name, command)
+	delete(c.schemas, name)
+	delete(c.asyncs, name)
+}
+
+func (c *commander) AddCommandSchema(name string, schema *CommandSchema, command func(map[string]interface{}) interface{}) {
+	c.commands[name] = measured(name, func(params map[string]interface{}) interface{} {
+		valid, err := schema.Validate(params)
+		if err != nil {
+			return err
+		}
+		return command(valid)
+	})
+	c.schemas[name] = schema
+	delete(c.asyncs, name)
+}
+
+func (c *commander) CommandSchema(name string) (schema *CommandSchema) {
+	schema, _ = c.schemas[name]
+	return
+}
+
+func (c *commander) CommandSchemas() map[string]*CommandSchema {
+	return c.schemas
+}
+
+func (c *commander) AddAsyncCommand(name string, schema *CommandSchema, command AsyncCommand) {
+	f := func(params map[string]interface{}) interface{} {
+		return command(context.Background(), params, func(float64) {})
+	}
+	if schema != nil {
+		c.AddCommandSchema(name, schema, f)
+	} else {
+		c.AddCommand(name, f)
+	}
+	c.asyncs[name] = command
+}
+
+func (c *commander) AsyncCommand(name string) (command AsyncCommand) {
+	command, _ = c.asyncs[name]
+	return
+}
+
+// CommanderOf returns the Commander embedded in v, as Robots and Drivers embed
+// the one returned by NewCommander, or v itself if it implements
+// SchemaCommander or embeds no Commander. Returns nil if v is no Commander. The
+// result is type asserted to SchemaCommander or AsyncCommander to reach the
+// schemas and long-running commands of v.
+func CommanderOf(v interface{}) Commander {
+	if c, ok := v.(SchemaCommander); ok {
+		return c
+	}
+	if c := embeddedCommander(reflect.ValueOf(v)); c != nil {
+		return c
+	}
+	c, _ := v.(Commander)
+	return c
+}
+
+// embeddedCommander returns the value of the embedded Commander field of the
+// struct v points to, or nil if there is none or it cannot be reached.
+func embeddedCommander(v reflect.Value) Commander {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil
+	}
+	field, ok := v.Type().FieldByName("Commander")
+	if !ok || !field.Anonymous {
+		return nil
+	}
+	for _, i := range field.Index {
+		for v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+		v = v.Field(i)
+	}
+	if !v.CanInterface() {
+		return nil
+	}
+	c, _ := v.Interface().(Commander)
+	return c
+}
+
+// commandSchemas returns the schemas of the commands of c, or nil if it does
+// not implement SchemaCommander.
+func commandSchemas(c Commander) map[string]*CommandSchema {
+	if sc, ok := c.(SchemaCommander); ok {
+		return sc.CommandSchemas()
+	}
+	return nil
+}
+
+// measured returns command, counting its invocations and the invocations which
+// return an error or panic.
+func measured(name string, command func(map[string]interface{}) interface{}) func(map[string]interface{}) interface{} {
+	return func(params map[string]interface{}) (result interface{}) {
+		commandInvocations.With(name).Inc()
+		failed := true
+		defer func() {
+			if failed {
+				commandErrors.With(name).Inc()
+			}
+		}()
+		result = command(params)
+		_, failed = result.(error)
+		return
+	}
 }
@@ -1,6 +1,9 @@
 package gobot
 
-import "testing"
+import (
+	"context"
+	"testing"
+)
 
 func TestCommaner(t *testing.T) {
 	c := NewCommander()
@@ -18,3 +21,91 @@ func TestCommaner(t *testing.T) {
 	command = c.Command("booyeah")
 	Assert(t, command, (func(map[string]interface{}) interface{})(nil))
 }
+
+// testPlainCommander implements Commander only, like Commanders written
+// before schemas and long-running commands.
+type testPlainCommander struct {
+	testDriver
+	commands map[string]func(map[string]interface{}) interface{}
+}
+
+func (t *testPlainCommander) Command(name string) func(map[string]interface{}) interface{} {
+	return t.commands[name]
+}
+
+func (t *testPlainCommander) Commands() map[string]func(map[string]interface{}) interface{} {
+	return t.commands
+}
+
+func (t *testPlainCommander) AddCommand(name string, command func(map[string]interface{}) interface{}) {
+	t.commands[name] = command
+}
+
+func TestPlainCommander(t *testing.T) {
+	var _ Commander = (*testPlainCommander)(nil)
+	d := &testPlainCommander{testDriver: testDriver{name: "Device1"}, commands: map[string]func(map[string]interface{}) interface{}{}}
+	d.AddCommand("test", func(map[string]interface{}) interface{} { return "hi" })
+
+	json := NewJSONDevice(d)
+	Assert(t, json.Commands, []string{"test"})
+	Assert(t, len(json.CommandSchemas), 0)
+}
+
+func TestCommanderSchema(t *testing.T) {
+	c := NewCommander().(SchemaCommander)
+	s := &CommandSchema{
+		Params: []Param{Param{Name: "level", Type: IntegerParam, Required: true}.Range(0, 10)},
+	}
+	c.AddCommandSchema("test", s, func(params map[string]interface{}) interface{} {
+		return params["level"]
+	})
+
+	Assert(t, c.CommandSchema("test"), s)
+	Assert(t, len(c.CommandSchemas()), 1)
+	Assert(t, c.Command("test")(map[string]interface{}{"level": 5}), 5.0)
+
+	err, ok := c.Command("test")(map[string]interface{}{"level": 11.0}).(*ParamError)
+	Assert(t, ok, true)
+	Assert(t, err.Param, "level")
+
+	c.AddCommand("test", func(map[string]interface{}) interface{} {
+		return "hi"
+	})
+	Assert(t, c.CommandSchema("test"), (*CommandSchema)(nil))
+}
+
+func TestCommanderAsync(t *testing.T) {
+	c := NewCommander().(AsyncCommander)
+	c.AddAsyncCommand("test", nil, func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		progress(1)
+		return params["level"]
+	})
+
+	Refute(t, c.AsyncCommand("test"), (AsyncCommand)(nil))
+	Assert(t, c.Command("test")(map[string]interface{}{"level": 5}), 5)
+
+	c.AddCommand("test", func(map[string]interface{}) interface{} {
+		return "hi"
+	})
+	Assert(t, c.AsyncCommand("test"), (AsyncCommand)(nil))
+}
+
+func TestCommanderOf(t *testing.T) {
+	d := &testDriver{name: "Device1", Commander: NewCommander()}
+	s := &CommandSchema{Params: []Param{{Name: "level", Type: IntegerParam}}}
+	d.Commander.(SchemaCommander).AddCommandSchema("test", s, func(params map[string]interface{}) interface{} {
+		return params["level"]
+	})
+
+	_, ok := CommanderOf(d).(AsyncCommander)
+	Assert(t, ok, true)
+	Assert(t, NewJSONDevice(d).CommandSchemas, map[string]*CommandSchema{"test": s})
+
+	r := NewRobot("Robot1")
+	_, ok = CommanderOf(r).(AsyncCommander)
+	Assert(t, ok, true)
+
+	plain := &testPlainCommander{commands: map[string]func(map[string]interface{}) interface{}{}}
+	Assert(t, CommanderOf(plain), Commander(plain))
+	Assert(t, CommanderOf("Device1"), nil)
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"gopkg.in/yaml.v2"
+)
+
+// Config is the definition of a set of robots.
+type Config struct {
+	Robots []RobotConfig `json:"robots" yaml:"robots"`
+}
+
+// RobotConfig is the definition of a robot and the connections and devices it uses.
+type RobotConfig struct {
+	Name        string             `json:"name" yaml:"name"`
+	Tags        []string           `json:"tags" yaml:"tags"`
+	Connections []ConnectionConfig `json:"connections" yaml:"connections"`
+	Devices     []DeviceConfig     `json:"devices" yaml:"devices"`
+}
+
+// ConnectionConfig is the definition of a connection built by a registered adaptor.
+type ConnectionConfig struct {
+	Name    string                 `json:"name" yaml:"name"`
+	Adaptor string                 `json:"adaptor" yaml:"adaptor"`
+	Port    string                 `json:"port" yaml:"port"`
+	Params  map[string]interface{} `json:"params" yaml:"params"`
+}
+
+// DeviceConfig is the definition of a device built by a registered driver.
+type DeviceConfig struct {
+	Name       string `json:"name" yaml:"name"`
+	Driver     string `json:"driver" yaml:"driver"`
+	Connection string `json:"connection" yaml:"connection"`
+	Pin        string `json:"pin" yaml:"pin"`
+	// Interval is a duration such as "50ms", as accepted by time.ParseDuration.
+	Interval string                 `json:"interval" yaml:"interval"`
+	Params   map[string]interface{} `json:"params" yaml:"params"`
+}
+
+// Load reads the definition in the file at path. Files ending in .json are
+// parsed as JSON, all others as YAML.
+func Load(path string) (*Config, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		return ParseJSON(data)
+	}
+	return ParseYAML(data)
+}
+
+// ParseJSON parses a JSON definition.
+func ParseJSON(data []byte) (*Config, error) {
+	c := &Config{}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// ParseYAML parses a YAML definition.
+func ParseYAML(data []byte) (*Config, error) {
+	c := &Config{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	for i := range c.Robots {
+		r := &c.Robots[i]
+		for j := range r.Connections {
+			r.Connections[j].Params = stringKeys(r.Connections[j].Params).(map[string]interface{})
+		}
+		for j := range r.Devices {
+			r.Devices[j].Params = stringKeys(r.Devices[j].Params).(map[string]interface{})
+		}
+	}
+	return c, nil
+}
+
+// Build returns a new Gobot with all robots of the definition.
+func (c *Config) Build() (*gobot.Gobot, error) {
+	gbot := gobot.NewGobot()
+	for _, rc := range c.Robots {
+		if gbot.Robot(rc.Name) != nil {
+			return nil, fmt.Errorf("Robot %q: defined more than once", rc.Name)
+		}
+		r, err := rc.Build()
+		if err != nil {
+			return nil, err
+		}
+		gbot.AddRobot(r)
+	}
+	return gbot, nil
+}
+
+// Build returns a new Robot with the connections and devices of the definition.
+func (rc RobotConfig) Build() (*gobot.Robot, error) {
+	connections := []gobot.Connection{}
+	byName := map[string]gobot.Connection{}
+	for _, cc := range rc.Connections {
+		if _, ok := byName[cc.Name]; ok {
+			return nil, fmt.Errorf("Robot %q: Connection %q: defined more than once", rc.Name, cc.Name)
+		}
+		conn, err := gobot.NewAdaptor(cc.Adaptor, gobot.AdaptorSpec{
+			Name:   cc.Name,
+			Port:   cc.Port,
+			Params: gobot.Params(cc.Params),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("Robot %q: Connection %q: %v", rc.Name, cc.Name, err)
+		}
+		connections = append(connections, conn)
+		byName[cc.Name] = conn
+	}
+
+	devices := []gobot.Device{}
+	seen := map[string]bool{}
+	for _, dc := range rc.Devices {
+		if seen[dc.Name] {
+			return nil, fmt.Errorf("Robot %q: Device %q: defined more than once", rc.Name, dc.Name)
+		}
+		seen[dc.Name] = true
+		device, err := dc.build(connections, byName)
+		if err != nil {
+			return nil, fmt.Errorf("Robot %q: Device %q: %v", rc.Name, dc.Name, err)
+		}
+		devices = append(devices, device)
+	}
+
+	r, err := gobot.NewCheckedRobot(rc.Name, connections, devices)
+	if err != nil {
+		return nil, err
+	}
+	r.AddTag(rc.Tags...)
+	return r, nil
+}
+
+func (dc DeviceConfig) build(connections []gobot.Connection, byName map[string]gobot.Connection) (gobot.Driver, error) {
+	var conn gobot.Connection
+	switch {
+	case dc.Connection != "":
+		c, ok := byName[dc.Connection]
+		if !ok {
+			return nil, fmt.Errorf("unknown connection %q", dc.Connection)
+		}
+		conn = c
+	case len(connections) == 1:
+		conn = connections[0]
+	default:
+		return nil, fmt.Errorf("connection is required when a robot has %d connections", len(connections))
+	}
+
+	spec := gobot.DriverSpec{
+		Name:   dc.Name,
+		Pin:    dc.Pin,
+		Params: gobot.Params(dc.Params),
+	}
+	if dc.Interval != "" {
+		interval, err := time.ParseDuration(dc.Interval)
+		if err != nil {
+			return nil, err
+		}
+		spec.Interval = interval
+	}
+	return gobot.NewDriver(dc.Driver, conn, spec)
+}
+
+// stringKeys converts the maps decoded from YAML into the string keyed maps
+// decoded from JSON, so that both formats produce the same params.
+func stringKeys(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(t))
+		for key, value := range t {
+			m[fmt.Sprint(key)] = stringKeys(value)
+		}
+		return m
+	case map[string]interface{}:
+		for key, value := range t {
+			t[key] = stringKeys(value)
+		}
+		return t
+	case []interface{}:
+		for i := range t {
+			t[i] = stringKeys(t[i])
+		}
+		return t
+	}
+	return v
+}
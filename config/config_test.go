@@ -0,0 +1,158 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/firmata"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+)
+
+const testYAML = `
+robots:
+  - name: bot
+    tags: [swarm]
+    connections:
+      - name: arduino
+        adaptor: firmata
+        port: /dev/ttyACM0
+    devices:
+      - name: led
+        driver: gpio.led
+        pin: "13"
+      - name: button
+        driver: gpio.button
+        pin: "2"
+        interval: 50ms
+      - name: expander
+        driver: i2c.mcp23017
+        params:
+          address: 0x21
+          bank: 1
+`
+
+const testJSON = `{
+  "robots": [{
+    "name": "bot",
+    "connections": [
+      {"name": "a", "adaptor": "firmata", "port": "/dev/ttyACM0"},
+      {"name": "b", "adaptor": "firmata", "port": "/dev/ttyACM1"}
+    ],
+    "devices": [
+      {"name": "led", "driver": "gpio.led", "connection": "b", "pin": "13"}
+    ]
+  }]
+}`
+
+func writeTestFile(t *testing.T, name, data string) string {
+	dir, err := ioutil.TempDir("", "config")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, name)
+	if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadYAML(t *testing.T) {
+	path := writeTestFile(t, "robots.yaml", testYAML)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	c, err := Load(path)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, c.Robots[0].Devices[2].Params["address"], 0x21)
+
+	gbot, err := c.Build()
+	gobot.Assert(t, err, nil)
+
+	r := gbot.Robot("bot")
+	gobot.Refute(t, r, (*gobot.Robot)(nil))
+	gobot.Assert(t, r.Tags(), []string{"swarm"})
+	gobot.Assert(t, r.Connections().Len(), 1)
+	gobot.Assert(t, r.Devices().Len(), 3)
+
+	arduino := r.Connection("arduino").(*firmata.FirmataAdaptor)
+	gobot.Assert(t, arduino.Port(), "/dev/ttyACM0")
+
+	led := r.Device("led").(*gpio.LedDriver)
+	gobot.Assert(t, led.Pin(), "13")
+	gobot.Assert(t, led.Connection(), gobot.Connection(arduino))
+
+	button := r.Device("button").(*gpio.ButtonDriver)
+	gobot.Assert(t, button.Pin(), "2")
+
+	_, ok := r.Device("expander").(*i2c.MCP23017Driver)
+	gobot.Assert(t, ok, true)
+}
+
+func TestLoadJSON(t *testing.T) {
+	path := writeTestFile(t, "robots.json", testJSON)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	c, err := Load(path)
+	gobot.Assert(t, err, nil)
+
+	gbot, err := c.Build()
+	gobot.Assert(t, err, nil)
+
+	r := gbot.Robot("bot")
+	gobot.Assert(t, r.Connections().Len(), 2)
+	gobot.Assert(t, r.Device("led").Connection(), r.Connection("b"))
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		config Config
+		err    string
+	}{
+		{
+			Config{Robots: []RobotConfig{{Name: "bot", Connections: []ConnectionConfig{
+				{Name: "a", Adaptor: "unknown"},
+			}}}},
+			`Robot "bot": Connection "a": Unknown adaptor "unknown"`,
+		},
+		{
+			Config{Robots: []RobotConfig{{
+				Name:        "bot",
+				Connections: []ConnectionConfig{{Name: "a", Adaptor: "firmata"}},
+				Devices:     []DeviceConfig{{Name: "led", Driver: "gpio.led", Connection: "b"}},
+			}}},
+			`Robot "bot": Device "led": unknown connection "b"`,
+		},
+		{
+			Config{Robots: []RobotConfig{{
+				Name: "bot",
+				Connections: []ConnectionConfig{
+					{Name: "a", Adaptor: "firmata"},
+					{Name: "b", Adaptor: "firmata"},
+				},
+				Devices: []DeviceConfig{{Name: "led", Driver: "gpio.led"}},
+			}}},
+			`Robot "bot": Device "led": connection is required when a robot has 2 connections`,
+		},
+		{
+			Config{Robots: []RobotConfig{{
+				Name:        "bot",
+				Connections: []ConnectionConfig{{Name: "a", Adaptor: "firmata"}},
+				Devices:     []DeviceConfig{{Name: "button", Driver: "gpio.button", Interval: "soon"}},
+			}}},
+			`Robot "bot": Device "button": time: invalid duration "soon"`,
+		},
+		{
+			Config{Robots: []RobotConfig{{Name: "bot"}, {Name: "bot"}}},
+			`Robot "bot": defined more than once`,
+		},
+	}
+
+	for _, test := range tests {
+		_, err := test.config.Build()
+		gobot.Refute(t, err, nil)
+		gobot.Assert(t, err.Error(), test.err)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Package config builds robots from declarative JSON or YAML definitions.
+
+Adaptors and drivers are looked up by the kinds their packages register with
+gobot.RegisterAdaptor and gobot.RegisterDriver, so the packages providing them
+must be imported, usually for their side effects only:
+
+	import (
+		"github.com/hybridgroup/gobot/config"
+		_ "github.com/hybridgroup/gobot/platforms/firmata"
+		_ "github.com/hybridgroup/gobot/platforms/gpio"
+	)
+
+A definition lists robots together with their tags, connections and devices:
+
+	robots:
+	  - name: bot
+	    tags: [swarm]
+	    connections:
+	      - name: arduino
+	        adaptor: firmata
+	        port: /dev/ttyACM0
+	    devices:
+	      - name: led
+	        driver: gpio.led
+	        pin: "13"
+	      - name: button
+	        driver: gpio.button
+	        pin: "2"
+	        interval: 50ms
+
+A device may omit its connection when its robot has exactly one. Built robots
+have no work, which can be assigned before starting:
+
+	cfg, err := config.Load("robots.yaml")
+	if err != nil {
+		log.Fatal(err)
+	}
+	gbot, err := cfg.Build()
+	if err != nil {
+		log.Fatal(err)
+	}
+	gbot.Robot("bot").Work = func() {
+		// ...
+	}
+	gbot.Start()
+*/
+package config
@@ -1,9 +1,10 @@
 package gobot
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"reflect"
+	"time"
 )
 
 // JSONConnection is a JSON representation of a Connection.
@@ -38,32 +39,85 @@ func (c *Connections) Each(f func(Connection)) {
 	}
 }
 
+// find returns the Connection with the given name and its index, or nil if there is none.
+func (c *Connections) find(name string) (Connection, int) {
+	for i, connection := range *c {
+		if connection.Name() == name {
+			return connection, i
+		}
+	}
+	return nil, -1
+}
+
 // Start calls Connect on each Connection in c
 func (c *Connections) Start() (errs []error) {
-	log.Println("Starting connections...")
-	for _, connection := range *c {
+	return c.StartContext(context.Background())
+}
+
+// StartContext calls Connect on each Connection in c. A Connect which is still
+// running when ctx is done is abandoned and the error of ctx is returned. If a
+// Connection fails to connect, the Connections which were already connected are
+// finalized in reverse order.
+func (c *Connections) StartContext(ctx context.Context) (errs []error) {
+	return c.start(ctx, DefaultLogger(), 0)
+}
+
+// start connects each Connection in c, logging to l. A Connection which fails
+// to connect rolls back the Connections connected before it, each given
+// timeout to finalize.
+func (c *Connections) start(ctx context.Context, l Logger, timeout time.Duration) (errs []error) {
+	l.Log(InfoLevel, "Starting connections...", nil)
+	for n, connection := range *c {
 		info := "Starting connection " + connection.Name()
 
 		if porter, ok := connection.(Porter); ok {
 			info = info + " on port " + porter.Port()
 		}
 
-		log.Println(info + "...")
+		l.Log(InfoLevel, info+"...", connectionFields(connection))
 
-		if errs = connection.Connect(); len(errs) > 0 {
+		if errs = connectContext(ctx, connection); len(errs) > 0 {
 			for i, err := range errs {
 				errs[i] = fmt.Errorf("Connection %q: %v", connection.Name(), err)
 			}
+			connected := (*c)[:n]
+			errs = append(errs, connected.rollback(l, timeout)...)
 			return
 		}
 	}
 	return
 }
 
+// rollback finalizes each Connection in c in reverse order, undoing a failed
+// start. A timeout of zero waits for as long as a Connection takes.
+func (c *Connections) rollback(l Logger, timeout time.Duration) (errs []error) {
+	l.Log(InfoLevel, "Rolling back connections...", nil)
+	for i := len(*c) - 1; i >= 0; i-- {
+		connection := (*c)[i]
+		cerrs, timedOut := within(context.Background(), timeout, func(ctx context.Context) []error {
+			return finalizeContext(ctx, connection)
+		})
+		if timedOut {
+			l.Log(ErrorLevel, "Connection "+connection.Name()+" did not finalize within "+timeout.String(), connectionFields(connection))
+			cerrs = []error{fmt.Errorf("finalize timed out after %v", timeout)}
+		}
+		for _, err := range cerrs {
+			errs = append(errs, fmt.Errorf("Connection %q: rollback: %v", connection.Name(), err))
+		}
+	}
+	return
+}
+
 // Finalize calls Finalize on each Connection in c
 func (c *Connections) Finalize() (errs []error) {
+	return c.FinalizeContext(context.Background())
+}
+
+// FinalizeContext calls Finalize on each Connection in c, giving up on any
+// Connection which has not finalized by the time ctx is done.
+func (c *Connections) FinalizeContext(ctx context.Context) (errs []error) {
 	for _, connection := range *c {
-		if cerrs := connection.Finalize(); cerrs != nil {
+		if cerrs := finalizeContext(ctx, connection); cerrs != nil {
 			for i, err := range cerrs {
 				cerrs[i] = fmt.Errorf("Connection %q: %v", connection.Name(), err)
 			}
@@ -72,3 +126,30 @@ func (c *Connections) Finalize() (errs []error) {
 	}
 	return errs
 }
+
+// connectContext connects c, handing ctx to c if it is a ContextConnector.
+// Otherwise a Connect abandoned because ctx is done is finalized should it
+// succeed.
+func connectContext(ctx context.Context, c Connection) []error {
+	if connector, ok := c.(ContextConnector); ok {
+		return connector.ConnectContext(ctx)
+	}
+	return withContext(ctx, c.Connect, c.Finalize)
+}
+
+// finalizeContext finalizes c, handing ctx to c if it is a ContextFinalizer.
+func finalizeContext(ctx context.Context, c Connection) []error {
+	if finalizer, ok := c.(ContextFinalizer); ok {
+		return finalizer.FinalizeContext(ctx)
+	}
+	return withContext(ctx, c.Finalize, nil)
+}
+
+// connectionFields returns the log fields naming a Connection and its port.
+func connectionFields(c Connection) Fields {
+	fields := Fields{"connection": c.Name()}
+	if porter, ok := c.(Porter); ok {
+		fields["port"] = porter.Port()
+	}
+	return fields
+}
@@ -0,0 +1,248 @@
+package gobot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Dependent is the interface that describes a Device or Connection which must
+// start after, and halt before, other Devices or Connections of its Robot,
+// such as an led on the pins of an expander, or a sensor behind an I2C mux.
+// Dependencies are named. A Device depends on other Devices or on Connections,
+// which all start before any Device, a Connection on other Connections.
+type Dependent interface {
+	Dependencies() []string
+}
+
+// AddDependency makes the Device or Connection name of the Robot depend on the
+// Devices or Connections named by dependencies, in addition to the
+// Dependencies it declares as a Dependent. Returns an error if name or one of
+// dependencies is not part of the Robot, or if the dependency would make a
+// cycle, in which case it is not added.
+func (r *Robot) AddDependency(name string, dependencies ...string) (err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if d, _ := r.devices.find(name); d == nil {
+		if c, _ := r.connections.find(name); c == nil {
+			return fmt.Errorf("%q: neither a Device nor a Connection", name)
+		}
+	}
+	previous := r.dependencies[name]
+	r.dependencies[name] = append(append([]string{}, previous...), dependencies...)
+	if errs := r.checkDependencies(); len(errs) > 0 {
+		r.dependencies[name] = previous
+		return errs[0]
+	}
+	return
+}
+
+// CheckDependencies returns an error for each dependency of a Device or
+// Connection of the Robot which names none of them, and for each dependency
+// cycle. A Robot whose dependencies are not sound does not start.
+func (r *Robot) CheckDependencies() (errs []error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.checkDependencies()
+}
+
+func (r *Robot) checkDependencies() (errs []error) {
+	_, errs = r.connectionOrder()
+	_, derrs := r.deviceOrder()
+	return append(errs, derrs...)
+}
+
+// startOrder returns the Connections and Devices of the Robot in the order
+// they start in, each after its dependencies, along with the errors of
+// CheckDependencies.
+func (r *Robot) startOrder() (connections *Connections, devices *Devices, errs []error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	connections, errs = r.connectionOrder()
+	devices, derrs := r.deviceOrder()
+	return connections, devices, append(errs, derrs...)
+}
+
+// stopOrder returns the Connections and Devices of the Robot in the order they
+// stop in, the reverse of the order they start in. Devices and Connections
+// whose dependencies are not sound stop in the reverse of the order they were
+// added in.
+func (r *Robot) stopOrder() (connections *Connections, devices *Devices) {
+	connections, devices, _ = r.startOrder()
+	for i, j := 0, len(*connections)-1; i < j; i, j = i+1, j-1 {
+		(*connections)[i], (*connections)[j] = (*connections)[j], (*connections)[i]
+	}
+	for i, j := 0, len(*devices)-1; i < j; i, j = i+1, j-1 {
+		(*devices)[i], (*devices)[j] = (*devices)[j], (*devices)[i]
+	}
+	return
+}
+
+// connectionOrder returns the Connections of the Robot, each after the
+// Connections it depends on. On error they are returned in the order they
+// were added in.
+func (r *Robot) connectionOrder() (*Connections, []error) {
+	names := []string{}
+	for _, c := range *r.connections {
+		names = append(names, c.Name())
+	}
+	deps := [][]int{}
+	errs := []error{}
+	for _, c := range *r.connections {
+		indexes := []int{}
+		for _, dep := range r.dependenciesOf(c.Name(), c) {
+			if _, i := r.connections.find(dep); i >= 0 {
+				indexes = append(indexes, i)
+			} else {
+				errs = append(errs, fmt.Errorf("Connection %q: depends on unknown Connection %q", c.Name(), dep))
+			}
+		}
+		deps = append(deps, indexes)
+	}
+
+	order, cycle := sortDependencies(deps)
+	if len(cycle) > 0 {
+		errs = append(errs, cycleError(names, cycle))
+	}
+	if len(errs) > 0 {
+		connections := append(Connections{}, *r.connections...)
+		return &connections, errs
+	}
+	connections := make(Connections, 0, len(order))
+	for _, i := range order {
+		connections = append(connections, (*r.connections)[i])
+	}
+	return &connections, nil
+}
+
+// deviceOrder returns the Devices of the Robot, each after the Devices it
+// depends on. On error they are returned in the order they were added in.
+func (r *Robot) deviceOrder() (*Devices, []error) {
+	names := []string{}
+	for _, d := range *r.devices {
+		names = append(names, d.Name())
+	}
+	deps := [][]int{}
+	errs := []error{}
+	for _, d := range *r.devices {
+		indexes := []int{}
+		for _, dep := range r.dependenciesOf(d.Name(), d) {
+			if _, i := r.devices.find(dep); i >= 0 {
+				indexes = append(indexes, i)
+			} else if c, _ := r.connections.find(dep); c == nil {
+				errs = append(errs, fmt.Errorf("Device %q: depends on unknown Device or Connection %q", d.Name(), dep))
+			}
+		}
+		deps = append(deps, indexes)
+	}
+
+	order, cycle := sortDependencies(deps)
+	if len(cycle) > 0 {
+		errs = append(errs, cycleError(names, cycle))
+	}
+	if len(errs) > 0 {
+		devices := append(Devices{}, *r.devices...)
+		return &devices, errs
+	}
+	devices := make(Devices, 0, len(order))
+	for _, i := range order {
+		devices = append(devices, (*r.devices)[i])
+	}
+	return &devices, nil
+}
+
+// dependenciesOf returns the names of the dependencies of the Device or
+// Connection v, both declared and added to the Robot.
+func (r *Robot) dependenciesOf(name string, v interface{}) []string {
+	deps := []string{}
+	if dependent, ok := v.(Dependent); ok {
+		deps = append(deps, dependent.Dependencies()...)
+	}
+	return append(deps, r.dependencies[name]...)
+}
+
+// dependent returns the name of a Device or Connection of the Robot which
+// depends on name, or "" if there is none.
+func (r *Robot) dependent(name string) string {
+	for _, d := range *r.devices {
+		for _, dep := range r.dependenciesOf(d.Name(), d) {
+			if dep == name && d.Name() != name {
+				return d.Name()
+			}
+		}
+	}
+	for _, c := range *r.connections {
+		for _, dep := range r.dependenciesOf(c.Name(), c) {
+			if dep == name && c.Name() != name {
+				return c.Name()
+			}
+		}
+	}
+	return ""
+}
+
+// sortDependencies orders n nodes, given the indexes of the nodes each depends
+// on, so that every node comes after its dependencies and otherwise keeps its
+// place. Returns the indexes of the nodes of a cycle if there is one.
+func sortDependencies(deps [][]int) (order []int, cycle []int) {
+	placed := make([]bool, len(deps))
+	for len(order) < len(deps) {
+		next := -1
+		for i := range deps {
+			if !placed[i] && allPlaced(deps[i], placed) {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			return nil, findCycle(deps, placed)
+		}
+		placed[next] = true
+		order = append(order, next)
+	}
+	return order, nil
+}
+
+func allPlaced(indexes []int, placed []bool) bool {
+	for _, i := range indexes {
+		if !placed[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// findCycle follows the dependencies of the first node not placed, each of
+// which depends on another node not placed, until it comes back to a node
+// it has seen.
+func findCycle(deps [][]int, placed []bool) []int {
+	seen := map[int]int{}
+	path := []int{}
+	for i := range deps {
+		if !placed[i] {
+			for {
+				if at, ok := seen[i]; ok {
+					return append(path[at:], i)
+				}
+				seen[i] = len(path)
+				path = append(path, i)
+				for _, dep := range deps[i] {
+					if !placed[dep] {
+						i = dep
+						break
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func cycleError(names []string, cycle []int) error {
+	path := []string{}
+	for _, i := range cycle {
+		path = append(path, names[i])
+	}
+	return fmt.Errorf("dependency cycle: %v", strings.Join(path, " -> "))
+}
@@ -0,0 +1,151 @@
+package gobot
+
+import (
+	"log"
+	"testing"
+)
+
+type testDependentDriver struct {
+	testRecordingDriver
+	dependencies []string
+}
+
+func (t *testDependentDriver) Dependencies() []string { return t.dependencies }
+
+type testDependentAdaptor struct {
+	testRecordingAdaptor
+	dependencies []string
+}
+
+func (t *testDependentAdaptor) Dependencies() []string { return t.dependencies }
+
+func newTestDependentDriver(name string, calls *[]string, dependencies ...string) *testDependentDriver {
+	return &testDependentDriver{
+		testRecordingDriver: testRecordingDriver{
+			testDriver: testDriver{name: name, Commander: NewCommander()},
+			calls:      calls,
+		},
+		dependencies: dependencies,
+	}
+}
+
+func newTestDependentAdaptor(name string, calls *[]string, dependencies ...string) *testDependentAdaptor {
+	return &testDependentAdaptor{
+		testRecordingAdaptor: testRecordingAdaptor{testAdaptor: testAdaptor{name: name}, calls: calls},
+		dependencies:         dependencies,
+	}
+}
+
+func TestRobotDependencyOrder(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := NewRobot("Robot1",
+		[]Connection{
+			newTestDependentAdaptor("mux", &calls, "bus"),
+			newTestDependentAdaptor("bus", &calls),
+		},
+		[]Device{
+			newTestDependentDriver("led1", &calls, "expander"),
+			newTestDependentDriver("sensor", &calls, "mux"),
+			newTestDependentDriver("led2", &calls, "expander"),
+			newTestDependentDriver("expander", &calls),
+		},
+	)
+	Assert(t, len(r.CheckDependencies()), 0)
+
+	Assert(t, len(r.Start()), 0)
+	Assert(t, calls, []string{
+		"connect bus", "connect mux",
+		"start sensor", "start expander", "start led1", "start led2",
+	})
+
+	calls = calls[:0]
+	Assert(t, len(r.Stop()), 0)
+	Assert(t, calls, []string{
+		"halt led2", "halt led1", "halt expander", "halt sensor",
+		"finalize mux", "finalize bus",
+	})
+}
+
+func TestRobotDependencyCycle(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := NewRobot("Robot1", []Device{
+		newTestDependentDriver("a", &calls, "b"),
+		newTestDependentDriver("b", &calls, "c"),
+		newTestDependentDriver("c", &calls, "a"),
+		newTestDependentDriver("d", &calls, "e"),
+	})
+
+	errs := r.CheckDependencies()
+	Assert(t, len(errs), 2)
+	Assert(t, errs[0].Error(), `Device "d": depends on unknown Device or Connection "e"`)
+	Assert(t, errs[1].Error(), "dependency cycle: a -> b -> c -> a")
+
+	Assert(t, r.Start(), errs)
+	Assert(t, r.State(), StateFailed)
+	Assert(t, len(calls), 0)
+}
+
+func TestNewCheckedRobot(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r, err := NewCheckedRobot("Robot1", []Device{
+		newTestDependentDriver("a", &calls, "b"),
+		newTestDependentDriver("b", &calls, "a"),
+		newTestDependentDriver("c", &calls, "d"),
+	})
+	Assert(t, r, (*Robot)(nil))
+	Assert(t, err.Error(), `Robot "Robot1": Device "c": depends on unknown Device or Connection "d"; dependency cycle: a -> b -> a`)
+
+	r, err = NewCheckedRobot("Robot1", []Device{
+		newTestDependentDriver("a", &calls, "b"),
+		newTestDependentDriver("b", &calls),
+	})
+	Assert(t, err, nil)
+	Assert(t, r.Name, "Robot1")
+}
+
+func TestRobotAddDependency(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := NewRobot("Robot1", []Device{
+		newTestDependentDriver("led", &calls),
+		newTestDependentDriver("expander", &calls),
+	})
+
+	Assert(t, r.AddDependency("led", "expander"), nil)
+	Assert(t, r.AddDependency("expander", "led").Error(), "dependency cycle: led -> expander -> led")
+	Assert(t, r.AddDependency("led", "missing").Error(),
+		`Device "led": depends on unknown Device or Connection "missing"`)
+	Assert(t, r.AddDependency("missing", "led").Error(), `"missing": neither a Device nor a Connection`)
+
+	Assert(t, len(r.Start()), 0)
+	Assert(t, calls, []string{"start expander", "start led"})
+	r.Stop()
+}
+
+func TestRobotDependencyAttachDetach(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := NewRobot("Robot1", []Connection{newTestDependentAdaptor("bus", &calls)})
+	Assert(t, len(r.Start()), 0)
+
+	errs := r.AttachDevice(newTestDependentDriver("led", &calls, "expander"))
+	Assert(t, errs[0].Error(), `Device "led": dependency "expander" is not attached`)
+	errs = r.AttachConnection(newTestDependentAdaptor("mux", &calls, "i2c"))
+	Assert(t, errs[0].Error(), `Connection "mux": dependency "i2c" is not attached`)
+
+	Assert(t, len(r.AttachDevice(newTestDependentDriver("expander", &calls, "bus"))), 0)
+	Assert(t, len(r.AttachDevice(newTestDependentDriver("led", &calls, "expander"))), 0)
+
+	errs = r.DetachDevice("expander")
+	Assert(t, errs[0].Error(), `Device "expander": needed by Device "led"`)
+	errs = r.DetachConnection("bus")
+	Assert(t, errs[0].Error(), `Connection "bus": needed by "expander"`)
+
+	Assert(t, len(r.DetachDevice("led")), 0)
+	Assert(t, len(r.DetachDevice("expander")), 0)
+	Assert(t, len(r.DetachConnection("bus")), 0)
+	r.Stop()
+}
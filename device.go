@@ -1,35 +1,58 @@
 package gobot
 
 import (
+	"context"
 	"fmt"
-	"log"
 	"reflect"
+	"sort"
+	"time"
 )
 
+// DefaultHaltTimeout is how long a new Robot gives each Device to halt.
+var DefaultHaltTimeout = 5 * time.Second
+
 // JSONDevice is a JSON representation of a Device.
 type JSONDevice struct {
-	Name       string   `json:"name"`
-	Driver     string   `json:"driver"`
-	Connection string   `json:"connection"`
-	Commands   []string `json:"commands"`
+	Name           string                    `json:"name"`
+	Driver         string                    `json:"driver"`
+	Connection     string                    `json:"connection"`
+	Pin            string                    `json:"pin"`
+	State          map[string]interface{}    `json:"state"`
+	Events         []string                  `json:"events"`
+	Commands       []string                  `json:"commands"`
+	CommandSchemas map[string]*CommandSchema `json:"command_schemas"`
 }
 
 // NewJSONDevice returns a JSONDevice given a Device.
 func NewJSONDevice(device Device) *JSONDevice {
 	jsonDevice := &JSONDevice{
-		Name:       device.Name(),
-		Driver:     reflect.TypeOf(device).String(),
-		Commands:   []string{},
-		Connection: "",
+		Name:           device.Name(),
+		Driver:         reflect.TypeOf(device).String(),
+		State:          map[string]interface{}{},
+		Events:         eventNames(device),
+		Commands:       []string{},
+		CommandSchemas: map[string]*CommandSchema{},
+		Connection:     "",
 	}
 	if device.Connection() != nil {
 		jsonDevice.Connection = device.Connection().Name()
 	}
+	if pinner, ok := device.(Pinner); ok {
+		jsonDevice.Pin = pinner.Pin()
+	}
+	if reporter, ok := device.(StateReporter); ok {
+		if state := reporter.ReportState(); state != nil {
+			jsonDevice.State = state
+		}
+	}
 	if commander, ok := device.(Commander); ok {
 		for command := range commander.Commands() {
 			jsonDevice.Commands = append(jsonDevice.Commands, command)
 		}
 	}
+	if commander, ok := CommanderOf(device).(SchemaCommander); ok {
+		jsonDevice.CommandSchemas = commander.CommandSchemas()
+	}
 	return jsonDevice
 }
 
@@ -51,36 +74,165 @@ func (d *Devices) Each(f func(Device)) {
 	}
 }
 
+// find returns the Device with the given name and its index, or nil if there is none.
+func (d *Devices) find(name string) (Device, int) {
+	for i, device := range *d {
+		if device.Name() == name {
+			return device, i
+		}
+	}
+	return nil, -1
+}
+
 // Start calls Start on each Device in d
 func (d *Devices) Start() (errs []error) {
-	log.Println("Starting devices...")
-	for _, device := range *d {
+	return d.StartContext(context.Background())
+}
+
+// StartContext calls Start on each Device in d. A Start which is still running
+// when ctx is done is abandoned and the error of ctx is returned. If a Device
+// fails to start, the Devices which were already started are halted in reverse
+// order.
+func (d *Devices) StartContext(ctx context.Context) (errs []error) {
+	return d.start(ctx, DefaultLogger(), nil)
+}
+
+// start starts each Device in d, logging to l. A Device which fails to start
+// rolls back the Devices started before it, each given its timeout to halt.
+func (d *Devices) start(ctx context.Context, l Logger, timeout func(Device) time.Duration) (errs []error) {
+	l.Log(InfoLevel, "Starting devices...", nil)
+	for n, device := range *d {
 		info := "Starting device " + device.Name()
 
 		if pinner, ok := device.(Pinner); ok {
 			info = info + " on pin " + pinner.Pin()
 		}
 
-		log.Println(info + "...")
-		if errs = device.Start(); len(errs) > 0 {
+		l.Log(InfoLevel, info+"...", deviceFields(device))
+		if errs = startContext(ctx, device); len(errs) > 0 {
 			for i, err := range errs {
 				errs[i] = fmt.Errorf("Device %q: %v", device.Name(), err)
 			}
+			started := (*d)[:n]
+			errs = append(errs, started.rollback(l, timeout)...)
 			return
 		}
 	}
 	return
 }
 
+// rollback halts each Device in d in reverse order, undoing a failed start. A
+// nil timeout, or a timeout of zero, waits for as long as a Device takes.
+func (d *Devices) rollback(l Logger, timeout func(Device) time.Duration) (errs []error) {
+	l.Log(InfoLevel, "Rolling back devices...", nil)
+	for i := len(*d) - 1; i >= 0; i-- {
+		device := (*d)[i]
+		var t time.Duration
+		if timeout != nil {
+			t = timeout(device)
+		}
+		derrs, timedOut := haltWithin(context.Background(), device, t)
+		if timedOut {
+			l.Log(ErrorLevel, "Device "+device.Name()+" did not halt within "+t.String(), deviceFields(device))
+			derrs = []error{fmt.Errorf("halt timed out after %v", t)}
+		}
+		for _, err := range derrs {
+			errs = append(errs, fmt.Errorf("Device %q: rollback: %v", device.Name(), err))
+		}
+	}
+	return
+}
+
 // Halt calls Halt on each Device in d
 func (d *Devices) Halt() (errs []error) {
+	return d.HaltContext(context.Background())
+}
+
+// HaltContext calls Halt on each Device in d, giving up on any Device which has
+// not halted by the time ctx is done.
+func (d *Devices) HaltContext(ctx context.Context) (errs []error) {
+	return d.halt(ctx, DefaultLogger(), nil)
+}
+
+// halt halts each Device in d, giving up on any Device which has not halted by
+// the time ctx is done or its timeout has passed. Devices timing out are
+// logged to l. A nil timeout, or a timeout of zero, gives Devices until ctx is
+// done.
+func (d *Devices) halt(ctx context.Context, l Logger, timeout func(Device) time.Duration) (errs []error) {
 	for _, device := range *d {
-		if derrs := device.Halt(); len(derrs) > 0 {
-			for i, err := range derrs {
-				derrs[i] = fmt.Errorf("Device %q: %v", device.Name(), err)
-			}
-			errs = append(errs, derrs...)
+		var t time.Duration
+		if timeout != nil {
+			t = timeout(device)
+		}
+		derrs, timedOut := haltWithin(ctx, device, t)
+		if timedOut {
+			l.Log(ErrorLevel, "Device "+device.Name()+" did not halt within "+t.String(), deviceFields(device))
+			derrs = []error{fmt.Errorf("halt timed out after %v", t)}
 		}
+		for i, err := range derrs {
+			derrs[i] = fmt.Errorf("Device %q: %v", device.Name(), err)
+		}
+		errs = append(errs, derrs...)
 	}
 	return
 }
+
+// startContext starts d, handing ctx to d if it is a ContextStarter. Otherwise
+// a Start abandoned because ctx is done is halted should it succeed.
+func startContext(ctx context.Context, d Device) []error {
+	if starter, ok := d.(ContextStarter); ok {
+		return starter.StartContext(ctx)
+	}
+	return withContext(ctx, d.Start, d.Halt)
+}
+
+// haltContext halts d, handing ctx to d if it is a ContextHalter.
+func haltContext(ctx context.Context, d Device) []error {
+	if halter, ok := d.(ContextHalter); ok {
+		return halter.HaltContext(ctx)
+	}
+	return withContext(ctx, d.Halt, nil)
+}
+
+// haltWithin halts d, giving up once ctx is done or timeout has passed, if it
+// is not zero. Reports whether d was given up on because of timeout.
+func haltWithin(ctx context.Context, d Device, timeout time.Duration) (errs []error, timedOut bool) {
+	return within(ctx, timeout, func(ctx context.Context) []error { return haltContext(ctx, d) })
+}
+
+// within calls f with ctx, limited to timeout if it is not zero. Reports
+// whether f returned errors because of timeout rather than ctx.
+func within(ctx context.Context, timeout time.Duration, f func(context.Context) []error) (errs []error, timedOut bool) {
+	if timeout <= 0 {
+		return f(ctx), false
+	}
+	tctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	errs = f(tctx)
+	return errs, len(errs) > 0 && ctx.Err() == nil && tctx.Err() == context.DeadlineExceeded
+}
+
+// eventNames returns the sorted names of the events of e, if it is an Eventer.
+func eventNames(e interface{}) []string {
+	names := []string{}
+	if eventer, ok := e.(Eventer); ok {
+		for name := range eventer.Events() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// deviceFields returns the log fields naming a Device, its pin and its
+// Connection.
+func deviceFields(d Device) Fields {
+	fields := Fields{"device": d.Name()}
+	if pinner, ok := d.(Pinner); ok {
+		fields["pin"] = pinner.Pin()
+	}
+	if c := d.Connection(); c != nil {
+		fields["connection"] = c.Name()
+	}
+	return fields
+}
@@ -19,11 +19,12 @@ Basic Setup
     func main() {
       gbot  := gobot.NewGobot()
 
-      robot := gobot.NewRobot("Eve", func() {
-        gobot.Every(500*time.Millisecond, func() {
+      robot := gobot.NewRobot("Eve")
+      robot.Work = func() {
+        robot.Every(500*time.Millisecond, func() {
           fmt.Println("Greeting Human")
         })
-      })
+      }
 
       gbot.AddRobot(robot)
 
@@ -48,23 +49,28 @@ Blinking an LED (Hello Eve!)
     	firmataAdaptor := firmata.NewFirmataAdaptor("arduino", "/dev/ttyACM0")
     	led := gpio.NewLedDriver(firmataAdaptor, "led", "13")
 
-    	work := func() {
-    		gobot.Every(1*time.Second, func() {
-    			led.Toggle()
-    		})
-    	}
-
     	robot := gobot.NewRobot("Eve",
     		[]gobot.Connection{firmataAdaptor},
     		[]gobot.Device{led},
-    		work,
     	)
 
+    	robot.Work = func() {
+    		robot.Every(1*time.Second, func() {
+    			led.Toggle()
+    		})
+    	}
+
     	gbot.AddRobot(robot)
 
     	gbot.Start()
     }
 
+The Every, After and Cron methods of a Robot stop their schedules when the
+Robot stops, and report the panics of the scheduled functions to the Robot.
+The package level Every, After and Cron are not tied to any Robot: their
+schedules keep firing until they are stopped, and their panics are only
+logged.
+
 Web Enabled? You bet! Gobot can be configured to expose a restful HTTP interface
 using the api package. You can define custom commands on your robots, in addition
 to the built-in device driver commands, and interact with your application as a
@@ -101,5 +107,66 @@ web service.
     	gbot.Start()
     }
 
+Logging
+
+Gobot writes log records with a level and structured fields naming the robot,
+connection, device and pin they concern. By default they are written as text
+lines through the standard log package. Set a Logger on the Gobot to change
+that for all its robots, or replace the DefaultLogger to change it everywhere:
+
+    // one JSON object per line, without the debug records
+    gobot.SetDefaultLogger(gobot.MinLevel(gobot.NewJSONLogger(os.Stderr), gobot.InfoLevel))
+
+    // no logs at all
+    gbot.SetLogger(gobot.NewNullLogger())
+
+Adaptors and drivers which log implement LoggerSetter and are given the
+Logger of their robot when they are added to it.
+
+Safe state
+
+Drivers of actuators, such as motors, relays, servos and drones, implement
+SafeStater to declare a state it is safe to leave them in. Robots drive them
+to it when they stop, when their work, a callback or a command panics, and
+when they lose a connection. A robot with a WatchdogTimeout also does so when
+its work stops calling Heartbeat:
+
+    robot.WatchdogTimeout = 500 * time.Millisecond
+    robot.Work = func() {
+        robot.Every(100*time.Millisecond, func() {
+            robot.Heartbeat()
+            motor.Speed(nextSpeed())
+        })
+    }
+
+Dependencies
+
+Connections start before devices, and otherwise in the order they were added
+in, unless a driver or adaptor implementing Dependent names others it must
+start after. Devices and connections halt in reverse order. AddDependency
+declares dependencies for drivers which do not, such as leds on the pins of
+an expander:
+
+    robot := gobot.NewRobot("lights",
+        []gobot.Connection{adaptor},
+        []gobot.Device{led, expander},
+    )
+    robot.AddDependency("led", "expander")
+
+A robot whose dependencies name unknown devices or connections, or form a
+cycle, logs the errors when it is created and does not start. NewCheckedRobot
+returns them instead:
+
+    robot, err := gobot.NewCheckedRobot("lights",
+        []gobot.Connection{adaptor},
+        []gobot.Device{led, expander},
+    )
+    if err != nil {
+        log.Fatal(err)
+    }
+
+An LedDriver whose DigitalWriter is another driver, such as an expander,
+depends on it without calling AddDependency.
+
 */
 package gobot
@@ -1,5 +1,7 @@
 package gobot
 
+import "context"
+
 // Driver is the interface that describes a driver in gobot
 type Driver interface {
 	// Name returns the label for the Driver
@@ -16,3 +18,23 @@ type Driver interface {
 type Pinner interface {
 	Pin() string
 }
+
+// StateReporter is the interface that describes a driver which reports its
+// current state, such as whether an led is on, the angle of a servo or the
+// last reading of a sensor, as JSON-friendly values by name
+type StateReporter interface {
+	ReportState() map[string]interface{}
+}
+
+// ContextStarter is the interface that describes a driver which can abort
+// its Start when ctx is cancelled. The Start of other drivers is abandoned
+// when ctx is cancelled, and halted should it succeed afterwards.
+type ContextStarter interface {
+	StartContext(ctx context.Context) []error
+}
+
+// ContextHalter is the interface that describes a driver which can bound
+// its Halt by ctx
+type ContextHalter interface {
+	HaltContext(ctx context.Context) []error
+}
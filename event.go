@@ -1,16 +1,54 @@
 package gobot
 
-import "sync"
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// OverflowPolicy decides what happens to data written to a queued subscription
+// whose queue is full.
+type OverflowPolicy int
+
+const (
+	// DropOldest discards the oldest queued data to make room for the new data.
+	DropOldest OverflowPolicy = iota
+	// DropNewest discards the new data.
+	DropNewest
+	// Block makes the writer wait until there is room in the queue.
+	Block
+)
 
 type callback struct {
-	f    func(interface{})
-	once bool
+	id      uint64
+	f       func(interface{})
+	once    bool
+	queue   *eventQueue
+	stamped bool
 }
 
 // Event executes the list of Callbacks when Chan is written to.
 type Event struct {
+	// dropped is first so that it is 64-bit aligned for atomic operations on
+	// 32-bit platforms
+	dropped uint64
 	sync.Mutex
 	Callbacks []callback
+	name      string
+	lastID    uint64
+	queueSize int
+	overflow  OverflowPolicy
+	onPanic   func(*PanicError)
+	history   *eventHistory
+}
+
+// Subscription is a handle to a callback registered on an Event with On or Once.
+type Subscription struct {
+	event *Event
+	id    uint64
+	queue *eventQueue
 }
 
 // NewEvent returns a new Event which is now listening for data.
@@ -19,17 +57,239 @@ func NewEvent() *Event {
 }
 
 // Write writes data to the Event, it will not block and will not buffer if there
-// are no active subscribers to the Event.
+// are no active subscribers to the Event. Queued subscribers receive data in
+// order through their queue, which blocks Write only under the Block policy.
+// Events set to keep a history add data to it.
 func (e *Event) Write(data interface{}) {
 	e.Lock()
-	defer e.Unlock()
-
+	now := Now()
+	if e.name != "" {
+		eventsPublished.With(e.name).Inc()
+	}
+	if e.history != nil {
+		e.history.add(Sample{Time: now, Data: data})
+	}
+	callbacks := e.Callbacks
 	tmp := []callback{}
-	for _, cb := range e.Callbacks {
-		go cb.f(data)
+	for _, cb := range callbacks {
 		if !cb.once {
 			tmp = append(tmp, cb)
 		}
 	}
 	e.Callbacks = tmp
+	e.Unlock()
+
+	for _, cb := range callbacks {
+		value := data
+		if cb.stamped {
+			value = Sample{Time: now, Data: data}
+		}
+		if cb.queue != nil {
+			cb.queue.push(value)
+		} else {
+			go cb.f(value)
+		}
+	}
+}
+
+// SetQueue makes callbacks subsequently registered with On deliver data in order
+// from their own goroutine, through a queue holding at most size values. When
+// the queue is full, policy decides what happens to new data. A size of 0
+// restores the default of running each callback in a new goroutine.
+func (e *Event) SetQueue(size int, policy OverflowPolicy) {
+	e.Lock()
+	defer e.Unlock()
+	e.queueSize = size
+	e.overflow = policy
+}
+
+// OnPanic sets the function receiving the *PanicError of a callback of the
+// Event which panics. Without one, the panic is logged. Either way it does not
+// crash the process.
+func (e *Event) OnPanic(f func(err *PanicError)) {
+	e.Lock()
+	defer e.Unlock()
+	e.onPanic = f
+}
+
+// guard returns f, recovering from its panics and passing them to the panic
+// handler of the Event. The duration of f is measured under name, unless it is
+// empty.
+func (e *Event) guard(name string, f func(interface{})) func(interface{}) {
+	var duration *Histogram
+	if name != "" {
+		duration = callbackDuration.With(name)
+	}
+	return func(data interface{}) {
+		start := time.Now()
+		defer func() {
+			if duration != nil {
+				duration.Observe(time.Since(start).Seconds())
+			}
+		}()
+		defer func() {
+			if v := recover(); v != nil {
+				err := &PanicError{Value: v, Stack: debug.Stack()}
+				e.Lock()
+				onPanic := e.onPanic
+				e.Unlock()
+				if onPanic != nil {
+					onPanic(err)
+				} else {
+					DefaultLogger().Log(ErrorLevel, fmt.Sprintf("Recovered from %v in event callback", err), Fields{"stack": string(err.Stack)})
+				}
+			}
+		}()
+		f(data)
+	}
+}
+
+// SubscriberCount returns the number of callbacks currently subscribed to the Event.
+func (e *Event) SubscriberCount() int {
+	e.Lock()
+	defer e.Unlock()
+	return len(e.Callbacks)
+}
+
+// Dropped returns the number of values discarded by the queues of all
+// subscribers of the Event.
+func (e *Event) Dropped() uint64 {
+	return atomic.LoadUint64(&e.dropped)
+}
+
+// subscribe adds f to the Event's callbacks and returns its Subscription. A size
+// greater than 0 delivers data to f through a queue, a negative size uses the
+// queue settings of the Event. A stamped f receives Samples rather than data.
+func (e *Event) subscribe(f func(interface{}), once bool, size int, policy OverflowPolicy, stamped bool) *Subscription {
+	e.Lock()
+	defer e.Unlock()
+
+	if size < 0 {
+		size, policy = e.queueSize, e.overflow
+	}
+
+	e.lastID++
+	f = e.guard(e.name, f)
+	cb := callback{id: e.lastID, f: f, once: once, stamped: stamped}
+	if size > 0 && !once {
+		cb.queue = newEventQueue(size, policy, &e.dropped)
+		go cb.queue.run(f)
+	}
+	e.Callbacks = append(e.Callbacks, cb)
+	return &Subscription{event: e, id: cb.id, queue: cb.queue}
+}
+
+// unsubscribe removes the callback with the given id from the Event's callbacks.
+func (e *Event) unsubscribe(id uint64) {
+	e.Lock()
+	defer e.Unlock()
+
+	for i, cb := range e.Callbacks {
+		if cb.id == id {
+			e.Callbacks = append(e.Callbacks[:i:i], e.Callbacks[i+1:]...)
+			if cb.queue != nil {
+				cb.queue.close()
+			}
+			return
+		}
+	}
+}
+
+// Unsubscribe removes the Subscription's callback from its Event. Calling
+// Unsubscribe more than once, or after a Once callback has fired, has no effect.
+// Data still waiting in the queue of a queued subscription is discarded.
+func (s *Subscription) Unsubscribe() {
+	s.event.unsubscribe(s.id)
+}
+
+// Dropped returns the number of values discarded by the Subscription's queue.
+// It is always 0 for subscriptions which are not queued.
+func (s *Subscription) Dropped() uint64 {
+	if s.queue == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&s.queue.dropped)
+}
+
+// eventQueue is a bounded FIFO of data waiting to be delivered to a single
+// callback.
+type eventQueue struct {
+	// dropped is first so that it is 64-bit aligned for atomic operations on
+	// 32-bit platforms
+	dropped uint64
+	sync.Mutex
+	cond         *sync.Cond
+	items        []interface{}
+	size         int
+	policy       OverflowPolicy
+	closed       bool
+	eventDropped *uint64
+}
+
+func newEventQueue(size int, policy OverflowPolicy, eventDropped *uint64) *eventQueue {
+	q := &eventQueue{
+		size:         size,
+		policy:       policy,
+		eventDropped: eventDropped,
+	}
+	q.cond = sync.NewCond(q)
+	return q
+}
+
+// push adds data to the queue, applying the queue's policy when it is full.
+func (q *eventQueue) push(data interface{}) {
+	q.Lock()
+	defer q.Unlock()
+
+	for !q.closed && len(q.items) >= q.size {
+		switch q.policy {
+		case DropNewest:
+			q.drop()
+			return
+		case DropOldest:
+			q.items = q.items[1:]
+			q.drop()
+		default:
+			q.cond.Wait()
+		}
+	}
+	if q.closed {
+		return
+	}
+	q.items = append(q.items, data)
+	q.cond.Broadcast()
+}
+
+// run calls f with each value in the queue, in order, until the queue is closed.
+func (q *eventQueue) run(f func(interface{})) {
+	for {
+		q.Lock()
+		for !q.closed && len(q.items) == 0 {
+			q.cond.Wait()
+		}
+		if q.closed {
+			q.Unlock()
+			return
+		}
+		data := q.items[0]
+		q.items = q.items[1:]
+		q.cond.Broadcast()
+		q.Unlock()
+
+		f(data)
+	}
+}
+
+// close stops delivery and releases any writer blocked on the queue.
+func (q *eventQueue) close() {
+	q.Lock()
+	defer q.Unlock()
+	q.closed = true
+	q.items = nil
+	q.cond.Broadcast()
+}
+
+func (q *eventQueue) drop() {
+	atomic.AddUint64(&q.dropped, 1)
+	atomic.AddUint64(q.eventDropped, 1)
 }
@@ -0,0 +1,130 @@
+package gobot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnQueuedOrder(t *testing.T) {
+	c := make(chan interface{})
+	e := NewEvent()
+	OnQueued(e, 1, Block, func(data interface{}) {
+		c <- data
+	})
+
+	go func() {
+		for i := 0; i < 100; i++ {
+			Publish(e, i)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		Assert(t, <-c, i)
+	}
+	Assert(t, e.Dropped(), uint64(0))
+}
+
+func TestOnQueuedSample(t *testing.T) {
+	clock := &testClock{now: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)}
+	defer SetClock(SetClock(clock))
+	published := clock.now
+
+	c := make(chan Sample, 1)
+	e := NewEvent()
+	OnQueuedSample(e, 1, Block, func(s Sample) {
+		c <- s
+	})
+	Publish(e, 1)
+	Assert(t, <-c, Sample{Time: published, Data: 1})
+}
+
+func TestOnQueuedDropNewest(t *testing.T) {
+	c := make(chan interface{})
+	e := NewEvent()
+	sub, _ := OnQueued(e, 2, DropNewest, func(data interface{}) {
+		c <- data
+	})
+
+	Publish(e, 1)
+	// wait for 1 to be taken off the queue by the delivering goroutine
+	<-time.After(10 * time.Millisecond)
+	Publish(e, 2)
+	Publish(e, 3)
+	Publish(e, 4)
+
+	Assert(t, <-c, 1)
+	Assert(t, <-c, 2)
+	Assert(t, <-c, 3)
+	Assert(t, sub.Dropped(), uint64(1))
+	Assert(t, e.Dropped(), uint64(1))
+}
+
+func TestOnQueuedDropOldest(t *testing.T) {
+	c := make(chan interface{})
+	e := NewEvent()
+	sub, _ := OnQueued(e, 2, DropOldest, func(data interface{}) {
+		c <- data
+	})
+
+	Publish(e, 1)
+	<-time.After(10 * time.Millisecond)
+	Publish(e, 2)
+	Publish(e, 3)
+	Publish(e, 4)
+
+	Assert(t, <-c, 1)
+	Assert(t, <-c, 3)
+	Assert(t, <-c, 4)
+	Assert(t, sub.Dropped(), uint64(1))
+}
+
+func TestEventSetQueue(t *testing.T) {
+	c := make(chan interface{})
+	e := NewEvent()
+	e.SetQueue(1, Block)
+	sub, _ := On(e, func(data interface{}) {
+		c <- data
+	})
+	Refute(t, sub.queue, (*eventQueue)(nil))
+
+	go func() {
+		for i := 0; i < 10; i++ {
+			Publish(e, i)
+		}
+	}()
+	for i := 0; i < 10; i++ {
+		Assert(t, <-c, i)
+	}
+
+	// Once callbacks are never queued
+	sub, _ = Once(e, func(data interface{}) {})
+	Assert(t, sub.queue, (*eventQueue)(nil))
+	Assert(t, sub.Dropped(), uint64(0))
+}
+
+func TestOnQueuedUnsubscribeReleasesWriter(t *testing.T) {
+	block := make(chan bool)
+	e := NewEvent()
+	sub, _ := OnQueued(e, 1, Block, func(data interface{}) {
+		<-block
+	})
+
+	done := make(chan bool)
+	go func() {
+		Publish(e, 1)
+		Publish(e, 2)
+		Publish(e, 3)
+		done <- true
+	}()
+
+	<-time.After(10 * time.Millisecond)
+	sub.Unsubscribe()
+
+	select {
+	case <-done:
+	case <-time.After(1 * time.Second):
+		t.Errorf("Publish still blocked after Unsubscribe")
+	}
+	close(block)
+	Assert(t, e.SubscriberCount(), 0)
+}
@@ -1,6 +1,9 @@
 package gobot
 
+import "sync"
+
 type eventer struct {
+	mutex  sync.RWMutex
 	events map[string]*Event
 }
 
@@ -13,6 +16,9 @@ type Eventer interface {
 	Event(name string) (event *Event)
 	// AddEvent adds a new Event given a name.
 	AddEvent(name string)
+	// RegisterEvent adds an existing Event given a name, replacing any Event
+	// already registered with that name.
+	RegisterEvent(name string, event *Event)
 }
 
 // NewEventer returns a new Eventer.
@@ -23,14 +29,36 @@ func NewEventer() Eventer {
 }
 
 func (e *eventer) Events() map[string]*Event {
-	return e.events
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	events := make(map[string]*Event, len(e.events))
+	for name, event := range e.events {
+		events[name] = event
+	}
+	return events
 }
 
 func (e *eventer) Event(name string) (event *Event) {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
 	event, _ = e.events[name]
 	return
 }
 
 func (e *eventer) AddEvent(name string) {
-	e.events[name] = NewEvent()
+	e.RegisterEvent(name, NewEvent())
+}
+
+func (e *eventer) RegisterEvent(name string, event *Event) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	event.Lock()
+	if event.name == "" {
+		// an Event registered under several names is measured by the first,
+		// starting with the callbacks subscribed afterwards
+		event.name = name
+	}
+	event.Unlock()
+	e.events[name] = event
 }
@@ -16,3 +16,11 @@ func TestEventer(t *testing.T) {
 	event = e.Event("booyeah")
 	Assert(t, event, (*Event)(nil))
 }
+
+func TestEventerRegisterEvent(t *testing.T) {
+	e := NewEventer()
+	event := NewEvent()
+	e.RegisterEvent("derived", event)
+	Assert(t, e.Event("derived"), event)
+	Assert(t, len(e.Events()), 1)
+}
@@ -13,20 +13,20 @@ func main() {
 	ardroneAdaptor := ardrone.NewArdroneAdaptor("Drone")
 	drone := ardrone.NewArdroneDriver(ardroneAdaptor, "Drone")
 
-	work := func() {
+	robot := gobot.NewRobot("drone",
+		[]gobot.Connection{ardroneAdaptor},
+		[]gobot.Device{drone},
+	)
+
+	robot.Work = func() {
 		gobot.On(drone.Event("flying"), func(data interface{}) {
-			gobot.After(3*time.Second, func() {
+			robot.After(3*time.Second, func() {
 				drone.Land()
 			})
 		})
 		drone.TakeOff()
 	}
 
-	robot := gobot.NewRobot("drone",
-		[]gobot.Connection{ardroneAdaptor},
-		[]gobot.Device{drone},
-		work,
-	)
 	gbot.AddRobot(robot)
 
 	gbot.Start()
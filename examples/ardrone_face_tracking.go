@@ -25,7 +25,12 @@ func main() {
 	ardroneAdaptor := ardrone.NewArdroneAdaptor("Drone")
 	drone := ardrone.NewArdroneDriver(ardroneAdaptor, "drone")
 
-	work := func() {
+	robot := gobot.NewRobot("face",
+		[]gobot.Connection{ardroneAdaptor},
+		[]gobot.Device{window, camera, drone},
+	)
+
+	robot.Work = func() {
 		detect := false
 		drone.TakeOff()
 		var image *cv.IplImage
@@ -36,11 +41,11 @@ func main() {
 			}
 		})
 		gobot.On(drone.Event("flying"), func(data interface{}) {
-			gobot.After(1*time.Second, func() { drone.Up(0.2) })
-			gobot.After(2*time.Second, func() { drone.Hover() })
-			gobot.After(5*time.Second, func() {
+			robot.After(1*time.Second, func() { drone.Up(0.2) })
+			robot.After(2*time.Second, func() { drone.Hover() })
+			robot.After(5*time.Second, func() {
 				detect = true
-				gobot.Every(300*time.Millisecond, func() {
+				robot.Every(300*time.Millisecond, func() {
 					drone.Hover()
 					i := image
 					faces := opencv.DetectFaces(cascade, i)
@@ -65,17 +70,11 @@ func main() {
 					}
 					window.ShowImage(i)
 				})
-				gobot.After(20*time.Second, func() { drone.Land() })
+				robot.After(20*time.Second, func() { drone.Land() })
 			})
 		})
 	}
 
-	robot := gobot.NewRobot("face",
-		[]gobot.Connection{ardroneAdaptor},
-		[]gobot.Device{window, camera, drone},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -26,7 +26,12 @@ func main() {
 	ardroneAdaptor := ardrone.NewArdroneAdaptor("Drone")
 	drone := ardrone.NewArdroneDriver(ardroneAdaptor, "Drone")
 
-	work := func() {
+	robot := gobot.NewRobot("ardrone",
+		[]gobot.Connection{joystickAdaptor, ardroneAdaptor},
+		[]gobot.Device{joystick, drone},
+	)
+
+	robot.Work = func() {
 		offset := 32767.0
 		rightStick := pair{x: 0, y: 0}
 		leftStick := pair{x: 0, y: 0}
@@ -65,7 +70,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		robot.Every(10*time.Millisecond, func() {
 			pair := leftStick
 			if pair.y < -10 {
 				drone.Forward(validatePitch(pair.y, offset))
@@ -84,7 +89,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		robot.Every(10*time.Millisecond, func() {
 			pair := rightStick
 			if pair.y < -10 {
 				drone.Up(validatePitch(pair.y, offset))
@@ -104,12 +109,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("ardrone",
-		[]gobot.Connection{joystickAdaptor, ardroneAdaptor},
-		[]gobot.Device{joystick, drone},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -123,5 +122,6 @@ func validatePitch(data float64, offset float64) float64 {
 		}
 		return 1.0
 	}
+
 	return 0.0
 }
@@ -20,17 +20,17 @@ func main() {
 	loopback := NewLoopbackAdaptor("loopback", "/dev/null")
 	ping := NewPingDriver(loopback, "ping", "1")
 
-	work := func() {
-		gobot.Every(5*time.Second, func() {
-			fmt.Println(ping.Ping())
-		})
-	}
 	r := gobot.NewRobot("TestBot",
 		[]gobot.Connection{loopback},
 		[]gobot.Device{ping},
-		work,
 	)
 
+	r.Work = func() {
+		r.Every(5*time.Second, func() {
+			fmt.Println(ping.Ping())
+		})
+	}
+
 	r.AddCommand("hello", func(params map[string]interface{}) interface{} {
 		return fmt.Sprintf("Hello, %v!", params["greeting"])
 	})
@@ -14,18 +14,17 @@ func main() {
 	beagleboneAdaptor := beaglebone.NewBeagleboneAdaptor("beaglebone")
 	led := gpio.NewLedDriver(beagleboneAdaptor, "led", "P9_12")
 
-	work := func() {
-		gobot.Every(1*time.Second, func() {
-			led.Toggle()
-		})
-	}
-
 	robot := gobot.NewRobot("blinkBot",
 		[]gobot.Connection{beagleboneAdaptor},
 		[]gobot.Device{led},
-		work,
 	)
 
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
+			led.Toggle()
+		})
+	}
+
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -14,8 +14,13 @@ func main() {
 	beagleboneAdaptor := beaglebone.NewBeagleboneAdaptor("beaglebone")
 	blinkm := i2c.NewBlinkMDriver(beagleboneAdaptor, "blinkm")
 
-	work := func() {
-		gobot.Every(3*time.Second, func() {
+	robot := gobot.NewRobot("blinkmBot",
+		[]gobot.Connection{beagleboneAdaptor},
+		[]gobot.Device{blinkm},
+	)
+
+	robot.Work = func() {
+		robot.Every(3*time.Second, func() {
 			r := byte(gobot.Rand(255))
 			g := byte(gobot.Rand(255))
 			b := byte(gobot.Rand(255))
@@ -25,12 +30,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("blinkmBot",
-		[]gobot.Connection{beagleboneAdaptor},
-		[]gobot.Device{blinkm},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
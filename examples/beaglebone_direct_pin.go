@@ -15,8 +15,13 @@ func main() {
 	led := gpio.NewDirectPinDriver(beagleboneAdaptor, "led", "P8_10")
 	button := gpio.NewDirectPinDriver(beagleboneAdaptor, "button", "P8_9")
 
-	work := func() {
-		gobot.Every(500*time.Millisecond, func() {
+	robot := gobot.NewRobot("pinBot",
+		[]gobot.Connection{beagleboneAdaptor},
+		[]gobot.Device{led},
+	)
+
+	robot.Work = func() {
+		robot.Every(500*time.Millisecond, func() {
 			val, _ := button.DigitalRead()
 			if val == 1 {
 				led.DigitalWrite(1)
@@ -26,12 +31,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("pinBot",
-		[]gobot.Connection{beagleboneAdaptor},
-		[]gobot.Device{led},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -13,11 +13,16 @@ func main() {
 	beagleboneAdaptor := beaglebone.NewBeagleboneAdaptor("beaglebone")
 	led := gpio.NewLedDriver(beagleboneAdaptor, "led", "P9_14")
 
-	work := func() {
+	robot := gobot.NewRobot("pwmBot",
+		[]gobot.Connection{beagleboneAdaptor},
+		[]gobot.Device{led},
+	)
+
+	robot.Work = func() {
 		brightness := uint8(0)
 		fadeAmount := uint8(5)
 
-		gobot.Every(100*time.Millisecond, func() {
+		robot.Every(100*time.Millisecond, func() {
 			led.Brightness(brightness)
 			brightness = brightness + fadeAmount
 			if brightness == 0 || brightness == 255 {
@@ -26,12 +31,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("pwmBot",
-		[]gobot.Connection{beagleboneAdaptor},
-		[]gobot.Device{led},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
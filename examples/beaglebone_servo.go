@@ -14,20 +14,19 @@ func main() {
 	beagleboneAdaptor := beaglebone.NewBeagleboneAdaptor("beaglebone")
 	servo := gpio.NewServoDriver(beagleboneAdaptor, "servo", "P9_14")
 
-	work := func() {
-		gobot.Every(1*time.Second, func() {
+	robot := gobot.NewRobot("servoBot",
+		[]gobot.Connection{beagleboneAdaptor},
+		[]gobot.Device{servo},
+	)
+
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
 			i := uint8(gobot.Rand(180))
 			fmt.Println("Turning", i)
 			servo.Move(i)
 		})
 	}
 
-	robot := gobot.NewRobot("servoBot",
-		[]gobot.Connection{beagleboneAdaptor},
-		[]gobot.Device{servo},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -13,9 +13,14 @@ func main() {
 	bebopAdaptor := bebop.NewBebopAdaptor("Drone")
 	drone := bebop.NewBebopDriver(bebopAdaptor, "Drone")
 
-	work := func() {
+	robot := gobot.NewRobot("drone",
+		[]gobot.Connection{bebopAdaptor},
+		[]gobot.Device{drone},
+	)
+
+	robot.Work = func() {
 		gobot.On(drone.Event("flying"), func(data interface{}) {
-			gobot.After(3*time.Second, func() {
+			robot.After(3*time.Second, func() {
 				drone.Land()
 			})
 		})
@@ -24,11 +29,6 @@ func main() {
 		drone.TakeOff()
 	}
 
-	robot := gobot.NewRobot("drone",
-		[]gobot.Connection{bebopAdaptor},
-		[]gobot.Device{drone},
-		work,
-	)
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -26,7 +26,12 @@ func main() {
 	bebopAdaptor := bebop.NewBebopAdaptor("Drone")
 	drone := bebop.NewBebopDriver(bebopAdaptor, "Drone")
 
-	work := func() {
+	robot := gobot.NewRobot("bebop",
+		[]gobot.Connection{joystickAdaptor, bebopAdaptor},
+		[]gobot.Device{joystick, drone},
+	)
+
+	robot.Work = func() {
 
 		offset := 32767.0
 		rightStick := pair{x: 0, y: 0}
@@ -78,7 +83,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		robot.Every(10*time.Millisecond, func() {
 			pair := leftStick
 			if pair.y < -10 {
 				drone.Forward(validatePitch(pair.y, offset))
@@ -97,7 +102,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		robot.Every(10*time.Millisecond, func() {
 			pair := rightStick
 			if pair.y < -10 {
 				drone.Up(validatePitch(pair.y, offset))
@@ -117,12 +122,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("bebop",
-		[]gobot.Connection{joystickAdaptor, bebopAdaptor},
-		[]gobot.Device{joystick, drone},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -136,5 +135,6 @@ func validatePitch(data float64, offset float64) int {
 		}
 		return 100
 	}
+
 	return 0
 }
@@ -64,7 +64,12 @@ func main() {
 	bebopAdaptor := bebop.NewBebopAdaptor("Drone")
 	drone := bebop.NewBebopDriver(bebopAdaptor, "Drone")
 
-	work := func() {
+	robot := gobot.NewRobot("bebop",
+		[]gobot.Connection{joystickAdaptor, bebopAdaptor},
+		[]gobot.Device{joystick, drone},
+	)
+
+	robot.Work = func() {
 		video, _, _ := ffmpeg()
 
 		go func() {
@@ -126,7 +131,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		robot.Every(10*time.Millisecond, func() {
 			pair := leftStick
 			if pair.y < -10 {
 				drone.Forward(validatePitch(pair.y, offset))
@@ -145,7 +150,7 @@ func main() {
 			}
 		})
 
-		gobot.Every(10*time.Millisecond, func() {
+		robot.Every(10*time.Millisecond, func() {
 			pair := rightStick
 			if pair.y < -10 {
 				drone.Up(validatePitch(pair.y, offset))
@@ -165,12 +170,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("bebop",
-		[]gobot.Connection{joystickAdaptor, bebopAdaptor},
-		[]gobot.Device{joystick, drone},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -184,5 +183,6 @@ func validatePitch(data float64, offset float64) int {
 		}
 		return 100
 	}
+
 	return 0
 }
@@ -14,18 +14,17 @@ func main() {
 	digisparkAdaptor := digispark.NewDigisparkAdaptor("Digispark")
 	led := gpio.NewLedDriver(digisparkAdaptor, "led", "0")
 
-	work := func() {
-		gobot.Every(1*time.Second, func() {
-			led.Toggle()
-		})
-	}
-
 	robot := gobot.NewRobot("blinkBot",
 		[]gobot.Connection{digisparkAdaptor},
 		[]gobot.Device{led},
-		work,
 	)
 
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
+			led.Toggle()
+		})
+	}
+
 	gbot.AddRobot(robot)
 
 	gbot.Start()
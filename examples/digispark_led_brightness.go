@@ -14,11 +14,16 @@ func main() {
 	digisparkAdaptor := digispark.NewDigisparkAdaptor("digispark")
 	led := gpio.NewLedDriver(digisparkAdaptor, "led", "0")
 
-	work := func() {
+	robot := gobot.NewRobot("pwmBot",
+		[]gobot.Connection{digisparkAdaptor},
+		[]gobot.Device{led},
+	)
+
+	robot.Work = func() {
 		brightness := uint8(0)
 		fadeAmount := uint8(15)
 
-		gobot.Every(100*time.Millisecond, func() {
+		robot.Every(100*time.Millisecond, func() {
 			led.Brightness(brightness)
 			brightness = brightness + fadeAmount
 			if brightness == 0 || brightness == 255 {
@@ -27,12 +32,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("pwmBot",
-		[]gobot.Connection{digisparkAdaptor},
-		[]gobot.Device{led},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -15,20 +15,19 @@ func main() {
 	digisparkAdaptor := digispark.NewDigisparkAdaptor("digispark")
 	servo := gpio.NewServoDriver(digisparkAdaptor, "servo", "0")
 
-	work := func() {
-		gobot.Every(1*time.Second, func() {
+	robot := gobot.NewRobot("servoBot",
+		[]gobot.Connection{digisparkAdaptor},
+		[]gobot.Device{servo},
+	)
+
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
 			i := uint8(gobot.Rand(180))
 			fmt.Println("Turning", i)
 			servo.Move(i)
 		})
 	}
 
-	robot := gobot.NewRobot("servoBot",
-		[]gobot.Connection{digisparkAdaptor},
-		[]gobot.Device{servo},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
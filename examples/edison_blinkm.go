@@ -15,8 +15,13 @@ func main() {
 	e := edison.NewEdisonAdaptor("edison")
 	blinkm := i2c.NewBlinkMDriver(e, "blinkm")
 
-	work := func() {
-		gobot.Every(3*time.Second, func() {
+	robot := gobot.NewRobot("blinkmBot",
+		[]gobot.Connection{e},
+		[]gobot.Device{blinkm},
+	)
+
+	robot.Work = func() {
+		robot.Every(3*time.Second, func() {
 			r := byte(gobot.Rand(255))
 			g := byte(gobot.Rand(255))
 			b := byte(gobot.Rand(255))
@@ -26,12 +31,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("blinkmBot",
-		[]gobot.Connection{e},
-		[]gobot.Device{blinkm},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 	gbot.Start()
 }
@@ -15,8 +15,13 @@ func main() {
 	board := edison.NewEdisonAdaptor("edison")
 	accel := i2c.NewGroveAccelerometerDriver(board, "accel")
 
-	work := func() {
-		gobot.Every(500*time.Millisecond, func() {
+	robot := gobot.NewRobot("accelBot",
+		[]gobot.Connection{board},
+		[]gobot.Device{accel},
+	)
+
+	robot.Work = func() {
+		robot.Every(500*time.Millisecond, func() {
 			if x, y, z, err := accel.XYZ(); err == nil {
 				fmt.Println(x, y, z)
 				fmt.Println(accel.Acceleration(x, y, z))
@@ -26,12 +31,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("accelBot",
-		[]gobot.Connection{board},
-		[]gobot.Device{accel},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
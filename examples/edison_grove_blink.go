@@ -14,18 +14,17 @@ func main() {
 	e := edison.NewEdisonAdaptor("edison")
 	led := gpio.NewLedDriver(e, "led", "13")
 
-	work := func() {
-		gobot.Every(1*time.Second, func() {
-			led.Toggle()
-		})
-	}
-
 	robot := gobot.NewRobot("blinkBot",
 		[]gobot.Connection{e},
 		[]gobot.Device{led},
-		work,
 	)
 
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
+			led.Toggle()
+		})
+	}
+
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -14,12 +14,17 @@ func main() {
 	board := edison.NewEdisonAdaptor("edison")
 	screen := i2c.NewGroveLcdDriver(board, "screen")
 
-	work := func() {
+	robot := gobot.NewRobot("screenBot",
+		[]gobot.Connection{board},
+		[]gobot.Device{screen},
+	)
+
+	robot.Work = func() {
 		screen.Write("hello")
 
 		screen.SetRGB(255, 0, 0)
 
-		gobot.After(5*time.Second, func() {
+		robot.After(5*time.Second, func() {
 			screen.Clear()
 			screen.Home()
 			screen.SetRGB(0, 255, 0)
@@ -27,7 +32,7 @@ func main() {
 			screen.SetCustomChar(0, i2c.CustomLCDChars["smiley"])
 			// add the custom character at the end of the string
 			screen.Write("goodbye\nhave a nice day " + string(byte(0)))
-			gobot.Every(500*time.Millisecond, func() {
+			robot.Every(500*time.Millisecond, func() {
 				screen.Scroll(false)
 			})
 		})
@@ -37,12 +42,6 @@ func main() {
 		screen.SetRGB(0, 0, 255)
 	}
 
-	robot := gobot.NewRobot("screenBot",
-		[]gobot.Connection{board},
-		[]gobot.Device{screen},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
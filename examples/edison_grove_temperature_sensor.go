@@ -15,18 +15,17 @@ func main() {
 	board := edison.NewEdisonAdaptor("board")
 	sensor := gpio.NewGroveTemperatureSensorDriver(board, "sensor", "0")
 
-	work := func() {
-		gobot.Every(500*time.Millisecond, func() {
-			fmt.Println("current temp (c): ", sensor.Temperature())
-		})
-	}
-
 	robot := gobot.NewRobot("sensorBot",
 		[]gobot.Connection{board},
 		[]gobot.Device{sensor},
-		work,
 	)
 
+	robot.Work = func() {
+		robot.Every(500*time.Millisecond, func() {
+			fmt.Println("current temp (c): ", sensor.Temperature())
+		})
+	}
+
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -15,8 +15,13 @@ func main() {
 	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
 	blinkm := i2c.NewBlinkMDriver(firmataAdaptor, "blinkm")
 
-	work := func() {
-		gobot.Every(3*time.Second, func() {
+	robot := gobot.NewRobot("blinkmBot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{blinkm},
+	)
+
+	robot.Work = func() {
+		robot.Every(3*time.Second, func() {
 			r := byte(gobot.Rand(255))
 			g := byte(gobot.Rand(255))
 			b := byte(gobot.Rand(255))
@@ -26,12 +31,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("blinkmBot",
-		[]gobot.Connection{firmataAdaptor},
-		[]gobot.Device{blinkm},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 	gbot.Start()
 }
@@ -20,7 +20,12 @@ func main() {
 	leapAdaptor := leap.NewLeapMotionAdaptor("leap", "127.0.0.1:6437")
 	leapDriver := leap.NewLeapMotionDriver(leapAdaptor, "leap")
 
-	work := func() {
+	robot := gobot.NewRobot("pwmBot",
+		[]gobot.Connection{firmataAdaptor, leapAdaptor},
+		[]gobot.Device{servo1, servo2, leapDriver},
+	)
+
+	robot.Work = func() {
 		x := 90.0
 		z := 90.0
 		gobot.On(leapDriver.Event("message"), func(data interface{}) {
@@ -30,19 +35,13 @@ func main() {
 				z = gobot.ToScale(gobot.FromScale(hand.Z(), -300, 300), 30, 150)
 			}
 		})
-		gobot.Every(10*time.Millisecond, func() {
+		robot.Every(10*time.Millisecond, func() {
 			servo1.Move(uint8(x))
 			servo2.Move(uint8(z))
-			fmt.Println("Current Angle: ", servo1.CurrentAngle, ",", servo2.CurrentAngle)
+			fmt.Println("Current Angle: ", servo1.Angle(), ",", servo2.Angle())
 		})
 	}
 
-	robot := gobot.NewRobot("pwmBot",
-		[]gobot.Connection{firmataAdaptor, leapAdaptor},
-		[]gobot.Device{servo1, servo2, leapDriver},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -14,10 +14,15 @@ func main() {
 	firmataAdaptor := firmata.NewFirmataAdaptor("myFirmata", "/dev/ttyACM0")
 	pin := gpio.NewDirectPinDriver(firmataAdaptor, "pin", "13")
 
-	work := func() {
+	robot := gobot.NewRobot("pinBot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{pin},
+	)
+
+	robot.Work = func() {
 		level := byte(1)
 
-		gobot.Every(1*time.Second, func() {
+		robot.Every(1*time.Second, func() {
 			pin.DigitalWrite(level)
 			if level == 1 {
 				level = 0
@@ -27,12 +32,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("pinBot",
-		[]gobot.Connection{firmataAdaptor},
-		[]gobot.Device{pin},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -15,19 +15,18 @@ func main() {
 	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
 	hmc6352 := i2c.NewHMC6352Driver(firmataAdaptor, "hmc6352")
 
-	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
-			heading, _ := hmc6352.Heading()
-			fmt.Println("Heading", heading)
-		})
-	}
-
 	robot := gobot.NewRobot("hmc6352Bot",
 		[]gobot.Connection{firmataAdaptor},
 		[]gobot.Device{hmc6352},
-		work,
 	)
 
+	robot.Work = func() {
+		robot.Every(100*time.Millisecond, func() {
+			heading, _ := hmc6352.Heading()
+			fmt.Println("Heading", heading)
+		})
+	}
+
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -18,11 +18,16 @@ func main() {
 	button := gpio.NewButtonDriver(firmataAdaptor, "button", "2")
 	sensor := gpio.NewAnalogSensorDriver(firmataAdaptor, "sensor", "0")
 
-	work := func() {
-		gobot.Every(1*time.Second, func() {
+	robot := gobot.NewRobot("bot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{led1, led2, button, sensor},
+	)
+
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
 			led1.Toggle()
 		})
-		gobot.Every(2*time.Second, func() {
+		robot.Every(2*time.Second, func() {
 			led2.Toggle()
 		})
 		gobot.On(button.Event("push"), func(data interface{}) {
@@ -36,12 +41,6 @@ func main() {
 		})		
 	}
 
-	robot := gobot.NewRobot("bot",
-		[]gobot.Connection{firmataAdaptor},
-		[]gobot.Device{led1, led2, button, sensor},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
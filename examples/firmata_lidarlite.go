@@ -15,19 +15,18 @@ func main() {
 	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
 	lidar := i2c.NewLIDARLiteDriver(firmataAdaptor, "lidar")
 
-	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
-			distance, _ := lidar.Distance()
-			fmt.Println("Distance", distance)
-		})
-	}
-
 	robot := gobot.NewRobot("lidarbot",
 		[]gobot.Connection{firmataAdaptor},
 		[]gobot.Device{lidar},
-		work,
 	)
 
+	robot.Work = func() {
+		robot.Every(100*time.Millisecond, func() {
+			distance, _ := lidar.Distance()
+			fmt.Println("Distance", distance)
+		})
+	}
+
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -15,8 +15,13 @@ func main() {
 	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
 	mma7660 := i2c.NewMMA7660Driver(firmataAdaptor, "mma7660")
 
-	work := func() {
-		gobot.Every(500*time.Millisecond, func() {
+	robot := gobot.NewRobot("mma76602Bot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{mma7660},
+	)
+
+	robot.Work = func() {
+		robot.Every(500*time.Millisecond, func() {
 			if x, y, z, err := mma7660.XYZ(); err == nil {
 				fmt.Println(x, y, z)
 				fmt.Println(mma7660.Acceleration(x, y, z))
@@ -26,12 +31,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("mma76602Bot",
-		[]gobot.Connection{firmataAdaptor},
-		[]gobot.Device{mma7660},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -14,11 +14,16 @@ func main() {
 	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
 	motor := gpio.NewMotorDriver(firmataAdaptor, "motor", "3")
 
-	work := func() {
+	robot := gobot.NewRobot("motorBot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{motor},
+	)
+
+	robot.Work = func() {
 		speed := byte(0)
 		fadeAmount := byte(15)
 
-		gobot.Every(100*time.Millisecond, func() {
+		robot.Every(100*time.Millisecond, func() {
 			motor.Speed(speed)
 			speed = speed + fadeAmount
 			if speed == 0 || speed == 255 {
@@ -27,12 +32,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("motorBot",
-		[]gobot.Connection{firmataAdaptor},
-		[]gobot.Device{motor},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -15,20 +15,19 @@ func main() {
 	firmataAdaptor := firmata.NewFirmataAdaptor("firmata", "/dev/ttyACM0")
 	mpu6050 := i2c.NewMPU6050Driver(firmataAdaptor, "mpu6050")
 
-	work := func() {
-		gobot.Every(100*time.Millisecond, func() {
+	robot := gobot.NewRobot("mpu6050Bot",
+		[]gobot.Connection{firmataAdaptor},
+		[]gobot.Device{mpu6050},
+	)
+
+	robot.Work = func() {
+		robot.Every(100*time.Millisecond, func() {
 			fmt.Println("Accelerometer", mpu6050.Accelerometer)
 			fmt.Println("Gyroscope", mpu6050.Gyroscope)
 			fmt.Println("Temperature", mpu6050.Temperature)
 		})
 	}
 
-	robot := gobot.NewRobot("mpu6050Bot",
-		[]gobot.Connection{firmataAdaptor},
-		[]gobot.Device{mpu6050},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -71,19 +71,18 @@ func main() {
 	green := gpio.NewLedDriver(firmataAdaptor, "green", "6")
 	blue := gpio.NewLedDriver(firmataAdaptor, "blue", "5")
 
-	work := func() {
-		checkTravis(gbot.Robot("travis"))
-		gobot.Every(10*time.Second, func() {
-			checkTravis(gbot.Robot("travis"))
-		})
-	}
-
 	robot := gobot.NewRobot("travis",
 		[]gobot.Connection{firmataAdaptor},
 		[]gobot.Device{red, green, blue},
-		work,
 	)
 
+	robot.Work = func() {
+		checkTravis(gbot.Robot("travis"))
+		robot.Every(10*time.Second, func() {
+			checkTravis(gbot.Robot("travis"))
+		})
+	}
+
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -10,12 +10,11 @@ import (
 func main() {
 	gbot := gobot.NewGobot()
 
-	robot := gobot.NewRobot(
-		"hello",
-		func() {
-			gobot.Every(500*time.Millisecond, func() { fmt.Println("Greetings human") })
-		},
-	)
+	robot := gobot.NewRobot("hello")
+
+	robot.Work = func() {
+		robot.Every(500*time.Millisecond, func() { fmt.Println("Greetings human") })
+	}
 
 	gbot.AddRobot(robot)
 
@@ -16,7 +16,12 @@ func main() {
 	firmataAdaptor := firmata.NewFirmataAdaptor("arduino", "/dev/ttyACM0")
 	led := gpio.NewLedDriver(firmataAdaptor, "led", "13")
 
-	work := func() {
+	robot := gobot.NewRobot("mqttBot",
+		[]gobot.Connection{mqttAdaptor, firmataAdaptor},
+		[]gobot.Device{led},
+	)
+
+	robot.Work = func() {
 		mqttAdaptor.On("lights/on", func(data []byte) {
 			led.On()
 		})
@@ -24,20 +29,14 @@ func main() {
 			led.Off()
 		})
 		data := []byte("")
-		gobot.Every(1*time.Second, func() {
+		robot.Every(1*time.Second, func() {
 			mqttAdaptor.Publish("lights/on", data)
 		})
-		gobot.Every(2*time.Second, func() {
+		robot.Every(2*time.Second, func() {
 			mqttAdaptor.Publish("lights/off", data)
 		})
 	}
 
-	robot := gobot.NewRobot("mqttBot",
-		[]gobot.Connection{mqttAdaptor, firmataAdaptor},
-		[]gobot.Device{led},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -13,7 +13,11 @@ func main() {
 
 	mqttAdaptor := mqtt.NewMqttAdaptor("server", "tcp://test.mosquitto.org:1883", "pinger")
 
-	work := func() {
+	robot := gobot.NewRobot("mqttBot",
+		[]gobot.Connection{mqttAdaptor},
+	)
+
+	robot.Work = func() {
 		mqttAdaptor.On("hello", func(data []byte) {
 			fmt.Println("hello")
 		})
@@ -21,19 +25,14 @@ func main() {
 			fmt.Println("hola")
 		})
 		data := []byte("o")
-		gobot.Every(1*time.Second, func() {
+		robot.Every(1*time.Second, func() {
 			mqttAdaptor.Publish("hello", data)
 		})
-		gobot.Every(5*time.Second, func() {
+		robot.Every(5*time.Second, func() {
 			mqttAdaptor.Publish("hola", data)
 		})
 	}
 
-	robot := gobot.NewRobot("mqttBot",
-		[]gobot.Connection{mqttAdaptor},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -19,14 +19,19 @@ func main() {
 	window := opencv.NewWindowDriver("window")
 	camera := opencv.NewCameraDriver("camera", 0)
 
-	work := func() {
+	robot := gobot.NewRobot("faceBot",
+		[]gobot.Connection{},
+		[]gobot.Device{window, camera},
+	)
+
+	robot.Work = func() {
 		var image *cv.IplImage
 
 		gobot.On(camera.Event("frame"), func(data interface{}) {
 			image = data.(*cv.IplImage)
 		})
 
-		gobot.Every(500*time.Millisecond, func() {
+		robot.Every(500*time.Millisecond, func() {
 			if image != nil {
 				i := image.Clone()
 				faces := opencv.DetectFaces(cascade, i)
@@ -37,12 +42,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("faceBot",
-		[]gobot.Connection{},
-		[]gobot.Device{window, camera},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -14,18 +14,17 @@ func main() {
 	sparkCore := spark.NewSparkCoreAdaptor("spark", "device_id", "access_token")
 	led := gpio.NewLedDriver(sparkCore, "led", "D7")
 
-	work := func() {
-		gobot.Every(1*time.Second, func() {
-			led.Toggle()
-		})
-	}
-
 	robot := gobot.NewRobot("spark",
 		[]gobot.Connection{sparkCore},
 		[]gobot.Device{led},
-		work,
 	)
 
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
+			led.Toggle()
+		})
+	}
+
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -14,11 +14,16 @@ func main() {
 	sparkCore := spark.NewSparkCoreAdaptor("spark", "device_id", "access_token")
 	led := gpio.NewLedDriver(sparkCore, "led", "A1")
 
-	work := func() {
+	robot := gobot.NewRobot("spark",
+		[]gobot.Connection{sparkCore},
+		[]gobot.Device{led},
+	)
+
+	robot.Work = func() {
 		brightness := uint8(0)
 		fadeAmount := uint8(25)
 
-		gobot.Every(500*time.Millisecond, func() {
+		robot.Every(500*time.Millisecond, func() {
 			led.Brightness(brightness)
 			brightness = brightness + fadeAmount
 			if brightness == 0 || brightness == 255 {
@@ -27,12 +32,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("spark",
-		[]gobot.Connection{sparkCore},
-		[]gobot.Device{led},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -13,8 +13,12 @@ func main() {
 
 	sparkCore := spark.NewSparkCoreAdaptor("spark", "DEVICE_ID", "ACCESS_TOKEN")
 
-	work := func() {
-		gobot.Every(1*time.Second, func() {
+	robot := gobot.NewRobot("spark",
+		[]gobot.Connection{sparkCore},
+	)
+
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
 			if temp, err := sparkCore.Variable("temperature"); err != nil {
 				fmt.Println(err)
 			} else {
@@ -23,11 +27,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("spark",
-		[]gobot.Connection{sparkCore},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -14,7 +14,12 @@ func main() {
 	adaptor := sphero.NewSpheroAdaptor("Sphero", "/dev/rfcomm0")
 	spheroDriver := sphero.NewSpheroDriver(adaptor, "sphero")
 
-	work := func() {
+	robot := gobot.NewRobot("sphero",
+		[]gobot.Connection{adaptor},
+		[]gobot.Device{spheroDriver},
+	)
+
+	robot.Work = func() {
 		spheroDriver.SetDataStreaming(sphero.DefaultDataStreamingConfig())
 
 		gobot.On(spheroDriver.Event(sphero.Collision), func(data interface{}) {
@@ -25,11 +30,11 @@ func main() {
 			fmt.Printf("Streaming Data! %+v\n", data)
 		})
 
-		gobot.Every(3*time.Second, func() {
+		robot.Every(3*time.Second, func() {
 			spheroDriver.Roll(30, uint16(gobot.Rand(360)))
 		})
 
-		gobot.Every(1*time.Second, func() {
+		robot.Every(1*time.Second, func() {
 			r := uint8(gobot.Rand(255))
 			g := uint8(gobot.Rand(255))
 			b := uint8(gobot.Rand(255))
@@ -37,12 +42,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot("sphero",
-		[]gobot.Connection{adaptor},
-		[]gobot.Device{spheroDriver},
-		work,
-	)
-
 	gbot.AddRobot(robot)
 
 	gbot.Start()
@@ -29,7 +29,12 @@ func main() {
 
 		cell := sphero.NewSpheroDriver(spheroAdaptor, "Sphero"+port)
 
-		work := func() {
+		robot := gobot.NewRobot("conway",
+			[]gobot.Connection{spheroAdaptor},
+			[]gobot.Device{cell},
+		)
+
+		robot.Work = func() {
 			conway := new(conway)
 			conway.cell = cell
 
@@ -39,24 +44,19 @@ func main() {
 				conway.contact()
 			})
 
-			gobot.Every(3*time.Second, func() {
+			robot.Every(3*time.Second, func() {
 				if conway.alive == true {
 					conway.movement()
 				}
 			})
 
-			gobot.Every(10*time.Second, func() {
+			robot.Every(10*time.Second, func() {
 				if conway.alive == true {
 					conway.birthday()
 				}
 			})
 		}
 
-		robot := gobot.NewRobot("conway",
-			[]gobot.Connection{spheroAdaptor},
-			[]gobot.Device{cell},
-			work,
-		)
 		gbot.AddRobot(robot)
 	}
 
@@ -32,17 +32,17 @@ func main() {
 		gbot.AddRobot(robot)
 	}
 
-	robot := gobot.NewRobot("",
-		func() {
-			gobot.Every(1*time.Second, func() {
-				sphero := gbot.Robot("Sphero-BPO").Device("sphero").(*sphero.SpheroDriver)
-				sphero.SetRGB(uint8(gobot.Rand(255)),
-					uint8(gobot.Rand(255)),
-					uint8(gobot.Rand(255)),
-				)
-			})
-		},
-	)
+	robot := gobot.NewRobot("")
+
+	robot.Work = func() {
+		robot.Every(1*time.Second, func() {
+			sphero := gbot.Robot("Sphero-BPO").Device("sphero").(*sphero.SpheroDriver)
+			sphero.SetRGB(uint8(gobot.Rand(255)),
+				uint8(gobot.Rand(255)),
+				uint8(gobot.Rand(255)),
+			)
+		})
+	}
 
 	gbot.AddRobot(robot)
 
@@ -22,17 +22,22 @@ func main() {
 		spheroAdaptor := sphero.NewSpheroAdaptor("Sphero", port)
 		spheroDriver := sphero.NewSpheroDriver(spheroAdaptor, "Sphero"+port)
 
-		work := func() {
+		robot := gobot.NewRobot("sphero",
+			[]gobot.Connection{spheroAdaptor},
+			[]gobot.Device{spheroDriver},
+		)
+
+		robot.Work = func() {
 			spheroDriver.Stop()
 
 			gobot.On(spheroDriver.Event("collision"), func(data interface{}) {
 				fmt.Println("Collision Detected!")
 			})
 
-			gobot.Every(1*time.Second, func() {
+			robot.Every(1*time.Second, func() {
 				spheroDriver.Roll(100, uint16(gobot.Rand(360)))
 			})
-			gobot.Every(3*time.Second, func() {
+			robot.Every(3*time.Second, func() {
 				spheroDriver.SetRGB(uint8(gobot.Rand(255)),
 					uint8(gobot.Rand(255)),
 					uint8(gobot.Rand(255)),
@@ -40,11 +45,6 @@ func main() {
 			})
 		}
 
-		robot := gobot.NewRobot("sphero",
-			[]gobot.Connection{spheroAdaptor},
-			[]gobot.Device{spheroDriver},
-			work,
-		)
 		gbot.AddRobot(robot)
 	}
 
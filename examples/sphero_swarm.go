@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/sphero"
+)
+
+func main() {
+	gbot := gobot.NewGobot()
+
+	spheros := map[string]string{
+		"Sphero-BPO": "/dev/rfcomm0",
+		"Sphero-YBW": "/dev/rfcomm1",
+		"Sphero-WRB": "/dev/rfcomm2",
+	}
+
+	for name, port := range spheros {
+		spheroAdaptor := sphero.NewSpheroAdaptor("sphero", port)
+		spheroDriver := sphero.NewSpheroDriver(spheroAdaptor, "sphero")
+
+		robot := gobot.NewRobot(name,
+			[]gobot.Connection{spheroAdaptor},
+			[]gobot.Device{spheroDriver},
+		)
+		robot.AddTag("swarm")
+
+		gbot.AddRobot(robot)
+	}
+
+	swarm := gbot.Group("swarm")
+
+	robot := gobot.NewRobot("master")
+
+	robot.Work = func() {
+		swarm.OnDevice("sphero", "collision", func(e gobot.GroupEvent) {
+			fmt.Println("Collision Detected on", e.Robot)
+		})
+
+		robot.Every(1*time.Second, func() {
+			results := swarm.DeviceCommand("sphero", "Roll", map[string]interface{}{
+				"speed":   100,
+				"heading": gobot.Rand(360),
+			})
+			for _, err := range results.Errors() {
+				fmt.Println(err)
+			}
+		})
+	}
+
+	gbot.AddRobot(robot)
+
+	gbot.Start()
+}
@@ -22,7 +22,14 @@ func main() {
 	button := gpio.NewButtonDriver(board, "button", "7")
 
 	enabled := true
-	work := func() {
+
+	robot := gobot.NewRobot(
+		"square of fire",
+		[]gobot.Connection{board},
+		[]gobot.Device{red, green, blue, button},
+	)
+
+	robot.Work = func() {
 		red.Brightness(0xff)
 		green.Brightness(0x00)
 		blue.Brightness(0x00)
@@ -30,7 +37,7 @@ func main() {
 		flash := false
 		on := true
 
-		gobot.Every(50*time.Millisecond, func() {
+		robot.Every(50*time.Millisecond, func() {
 			if enabled {
 				if flash {
 					if on {
@@ -60,13 +67,6 @@ func main() {
 		})
 	}
 
-	robot := gobot.NewRobot(
-		"square of fire",
-		[]gobot.Connection{board},
-		[]gobot.Device{red, green, blue, button},
-		work,
-	)
-
 	robot.AddCommand("enable", func(params map[string]interface{}) interface{} {
 		enabled = !enabled
 		return enabled
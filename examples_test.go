@@ -43,6 +43,16 @@ func ExampleOnce() {
 	gobot.Publish(e, 200)
 }
 
+func ExampleSubscription_Unsubscribe() {
+	e := gobot.NewEvent()
+	sub, _ := gobot.On(e, func(s interface{}) {
+		fmt.Println(s)
+	})
+	gobot.Publish(e, 100)
+	sub.Unsubscribe()
+	gobot.Publish(e, 200)
+}
+
 func ExampleRand() {
 	i := gobot.Rand(100)
 	fmt.Sprintln("%v is > 0 && < 100", i)
@@ -1,22 +1,33 @@
 package gobot
 
 import (
-	"log"
+	"context"
 	"os"
 	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 )
 
+// DefaultSignals are the signals which stop the Robots of a Gobot started with
+// AutoStop set, unless it is given Signals of its own.
+var DefaultSignals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+
 // JSONGobot is a JSON representation of a Gobot.
 type JSONGobot struct {
-	Robots   []*JSONRobot `json:"robots"`
-	Commands []string     `json:"commands"`
+	Robots         []*JSONRobot              `json:"robots"`
+	Events         []string                  `json:"events"`
+	Commands       []string                  `json:"commands"`
+	CommandSchemas map[string]*CommandSchema `json:"command_schemas"`
 }
 
 // NewJSONGobot returns a JSONGobt given a Gobot.
 func NewJSONGobot(gobot *Gobot) *JSONGobot {
 	jsonGobot := &JSONGobot{
-		Robots:   []*JSONRobot{},
-		Commands: []string{},
+		Robots:         []*JSONRobot{},
+		Events:         eventNames(gobot),
+		Commands:       []string{},
+		CommandSchemas: commandSchemas(gobot.Commander),
 	}
 
 	for command := range gobot.Commands() {
@@ -34,59 +45,137 @@ func NewJSONGobot(gobot *Gobot) *JSONGobot {
 type Gobot struct {
 	robots   *Robots
 	trap     func(chan os.Signal)
+	untrap   func(chan os.Signal)
+	logger   Logger
+	logMutex sync.RWMutex
 	AutoStop bool
+	// Signals are the signals which make Start stop all robots when AutoStop
+	// is set.
+	Signals []os.Signal
+	// ShutdownTimeout is how long all robots are given to stop once AutoStop
+	// stops them. Zero waits for as long as they take.
+	ShutdownTimeout time.Duration
+	// SafeState is called once AutoStop has stopped all robots, or once the
+	// ShutdownTimeout has passed if they have not stopped by then, to put the
+	// hardware in a safe state even if a Device hangs while halting.
+	SafeState func()
 	Commander
 	Eventer
 }
 
 // NewGobot returns a new Gobot
 func NewGobot() *Gobot {
-	return &Gobot{
-		robots: &Robots{},
-		trap: func(c chan os.Signal) {
-			signal.Notify(c, os.Interrupt)
-		},
+	g := &Gobot{
+		robots:    &Robots{},
 		AutoStop:  true,
+		Signals:   DefaultSignals,
 		Commander: NewCommander(),
 		Eventer:   NewEventer(),
 	}
+	g.trap = func(c chan os.Signal) {
+		signal.Notify(c, g.Signals...)
+	}
+	g.untrap = func(c chan os.Signal) {
+		signal.Stop(c)
+	}
+	return g
 }
 
 // Start calls the Start method on each robot in its collection of robots. On
-// error, call Stop to ensure that all robots are returned to a sane, stopped
-// state.
+// error, the robots, connections and devices started so far are rolled back so
+// that all robots are returned to a sane, stopped state. If AutoStop is set,
+// Start blocks until one of the Signals is trapped and then stops all robots
+// within the ShutdownTimeout.
 func (g *Gobot) Start() (errs []error) {
-	if rerrs := g.robots.Start(); len(rerrs) > 0 {
+	done := make(chan struct{})
+	if g.AutoStop {
+		c := make(chan os.Signal, 1)
+		g.trap(c)
+		defer g.untrap(c)
+		returned := make(chan struct{})
+		defer close(returned)
+		go func() {
+			// waiting for interrupt coming on the channel, unless start
+			// returns first because the robots failed to start
+			select {
+			case <-c:
+				close(done)
+			case <-returned:
+			}
+		}()
+	}
+	return g.start(context.Background(), done)
+}
+
+// StartContext calls the StartContext method on each robot in its collection of
+// robots. If AutoStop is set, StartContext blocks until ctx is done and then
+// stops all robots within the ShutdownTimeout, otherwise it returns as soon as all robots have started.
+// Cancelling ctx while robots are starting aborts any pending Connect or Start.
+func (g *Gobot) StartContext(ctx context.Context) (errs []error) {
+	return g.start(ctx, ctx.Done())
+}
+
+// start starts all robots with ctx and, if AutoStop is set, stops them again
+// once done is closed.
+func (g *Gobot) start(ctx context.Context, done <-chan struct{}) (errs []error) {
+	if rerrs := g.robots.StartContext(ctx); len(rerrs) > 0 {
 		for _, err := range rerrs {
-			log.Println("Error:", err)
+			g.Logger().Log(ErrorLevel, err.Error(), nil)
 			errs = append(errs, err)
 		}
 	}
 
-	if g.AutoStop {
-		c := make(chan os.Signal, 1)
-		g.trap(c)
-		if len(errs) > 0 {
-			// there was an error during start, so we immediatly pass the interrupt
-			// in order to disconnect the initialized robots, connections and devices
-			c <- os.Interrupt
-		}
+	// on error the robots have already rolled back everything they started,
+	// so there is nothing left to stop
+	if g.AutoStop && len(errs) == 0 {
+		<-done
+		g.shutdown()
+	}
 
-		// waiting for interrupt coming on the channel
-		_ = <-c
+	return errs
+}
 
-		// Stop calls the Stop method on each robot in its collection of robots.
-		g.Stop()
+// shutdown stops all robots within the ShutdownTimeout and then calls
+// SafeState. A robot which has not stopped by the deadline is left stopping in
+// the background.
+func (g *Gobot) shutdown() {
+	ctx := context.Background()
+	if g.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.ShutdownTimeout)
+		defer cancel()
 	}
 
-	return errs
+	stopped := make(chan struct{})
+	go func() {
+		g.StopContext(ctx)
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		g.Logger().Log(ErrorLevel, "Shutdown timed out after "+g.ShutdownTimeout.String(), nil)
+	}
+
+	if g.SafeState != nil {
+		g.Logger().Log(InfoLevel, "Entering safe state...", nil)
+		g.SafeState()
+	}
 }
 
 // Stop calls the Stop method on each robot in its collection of robots.
 func (g *Gobot) Stop() (errs []error) {
-	if rerrs := g.robots.Stop(); len(rerrs) > 0 {
+	return g.StopContext(context.Background())
+}
+
+// StopContext calls the StopContext method on each robot in its collection of
+// robots, giving up on any connection or device which has not stopped by the
+// time ctx is done.
+func (g *Gobot) StopContext(ctx context.Context) (errs []error) {
+	if rerrs := g.robots.StopContext(ctx); len(rerrs) > 0 {
 		for _, err := range rerrs {
-			log.Println("Error:", err)
+			g.Logger().Log(ErrorLevel, err.Error(), nil)
 			errs = append(errs, err)
 		}
 	}
@@ -102,7 +191,14 @@ func (g *Gobot) Robots() *Robots {
 // AddRobot adds a new robot to the internal collection of robots. Returns the
 // added robot
 func (g *Gobot) AddRobot(r *Robot) *Robot {
+	g.logMutex.Lock()
+	defer g.logMutex.Unlock()
 	*g.robots = append(*g.robots, r)
+	r.logMutex.Lock()
+	if r.logger == nil {
+		r.logger = g.logger
+	}
+	r.logMutex.Unlock()
 	return r
 }
 
@@ -287,23 +287,22 @@ func main() {
   conn := {{.Package}}.New{{.UpperName}}Adaptor("conn")
   dev := {{.Package}}.New{{.UpperName}}Driver(conn, "dev")
 
-  work := func() {
+  robot := gobot.NewRobot(
+    "robot",
+    []gobot.Connection{conn},
+    []gobot.Device{dev},
+  )
+
+  robot.Work = func() {
     gobot.On(dev.Event({{.Package}}.Hello), func(data interface{}) {
       fmt.Println(data)
     })
 
-    gobot.Every(1200*time.Millisecond, func() {
+    robot.Every(1200*time.Millisecond, func() {
       fmt.Println(dev.Ping())
     })
   }
 
-  robot := gobot.NewRobot(
-    "robot",
-    []gobot.Connection{conn},
-    []gobot.Device{dev},
-    work,
-  )
-
   gbot.AddRobot(robot)
   gbot.Start()
 }
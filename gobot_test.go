@@ -1,10 +1,13 @@
 package gobot
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestConnectionEach(t *testing.T) {
@@ -60,9 +63,37 @@ func TestGobotToJSON(t *testing.T) {
 	g.AddCommand("test_function", func(params map[string]interface{}) interface{} {
 		return nil
 	})
+	g.AddEvent("test_event")
 	json := NewJSONGobot(g)
 	Assert(t, len(json.Robots), g.Robots().Len())
 	Assert(t, len(json.Commands), len(g.Commands()))
+	Assert(t, json.Events, []string{"test_event"})
+	Assert(t, json.Robots[0].Events, []string{Disconnected, ErrorEvent, Reconnected, Reconnecting, SafeStateEvent, StateEvent})
+}
+
+type testReportingDriver struct {
+	testDriver
+	Eventer
+}
+
+func (t *testReportingDriver) ReportState() map[string]interface{} {
+	return map[string]interface{}{"on": true}
+}
+
+func TestJSONDevice(t *testing.T) {
+	adaptor := newTestAdaptor("Connection1", "/dev/null")
+	json := NewJSONDevice(newTestDriver(adaptor, "Device1", "3"))
+	Assert(t, json.Pin, "3")
+	Assert(t, json.Connection, "Connection1")
+	Assert(t, json.State, map[string]interface{}{})
+	Assert(t, json.Events, []string{})
+
+	d := &testReportingDriver{testDriver: testDriver{name: "Device2", Commander: NewCommander()}, Eventer: NewEventer()}
+	d.AddEvent("data")
+	d.AddEvent("error")
+	json = NewJSONDevice(d)
+	Assert(t, json.State, map[string]interface{}{"on": true})
+	Assert(t, json.Events, []string{"data", "error"})
 }
 
 func TestGobotStart(t *testing.T) {
@@ -125,3 +156,519 @@ func TestGobotStartErrors(t *testing.T) {
 	Assert(t, len(g.Start()), 0)
 	Assert(t, len(g.Stop()), 2)
 }
+
+func TestGobotStartContext(t *testing.T) {
+	testDriverHalt = func() (errs []error) { return }
+	testAdaptorFinalize = func() (errs []error) { return }
+	g := initTestGobot()
+	started := make(chan bool, 1)
+	g.AddRobot(NewRobot("Robot4", func() { started <- true }))
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan []error, 1)
+	go func() {
+		done <- g.StartContext(ctx)
+	}()
+	<-started
+	cancel()
+
+	select {
+	case errs := <-done:
+		Assert(t, len(errs), 0)
+	case <-time.After(1 * time.Second):
+		t.Errorf("StartContext did not return after ctx was cancelled")
+	}
+
+	g.AutoStop = false
+	Assert(t, len(g.StartContext(context.Background())), 0)
+	<-started
+	Assert(t, len(g.StopContext(context.Background())), 0)
+}
+
+type testBlockingAdaptor struct {
+	testAdaptor
+	block     chan struct{}
+	finalized chan bool
+}
+
+func (t *testBlockingAdaptor) Connect() (errs []error) {
+	<-t.block
+	return
+}
+
+func (t *testBlockingAdaptor) Finalize() (errs []error) {
+	if t.finalized != nil {
+		t.finalized <- true
+	}
+	return
+}
+
+type testBlockingDriver struct {
+	testDriver
+	block chan struct{}
+}
+
+func (t *testBlockingDriver) Start() (errs []error) {
+	<-t.block
+	return
+}
+
+func TestRobotStartContextAbortsConnect(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	adaptor := &testBlockingAdaptor{
+		testAdaptor: testAdaptor{name: "Connection1"},
+		block:       make(chan struct{}),
+	}
+	defer close(adaptor.block)
+
+	r := NewRobot("Robot1", []Connection{adaptor})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	errs := r.StartContext(ctx)
+	Assert(t, len(errs), 1)
+	Assert(t, errs[0].Error(), fmt.Sprintf("Connection %q: %v", "Connection1", context.DeadlineExceeded))
+}
+
+func TestRobotStartContextFinalizesAbandonedConnect(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	adaptor := &testBlockingAdaptor{
+		testAdaptor: testAdaptor{name: "Connection1"},
+		block:       make(chan struct{}),
+		finalized:   make(chan bool, 1),
+	}
+
+	r := NewRobot("Robot1", []Connection{adaptor})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	Assert(t, len(r.StartContext(ctx)), 1)
+
+	// the Connect which succeeds after being abandoned is undone
+	close(adaptor.block)
+	select {
+	case <-adaptor.finalized:
+	case <-time.After(time.Second):
+		t.Fatal("abandoned connection was not finalized")
+	}
+}
+
+func TestDevicesStartContext(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	driver := &testBlockingDriver{
+		testDriver: testDriver{name: "Device1"},
+		block:      make(chan struct{}),
+	}
+	defer close(driver.block)
+
+	devices := &Devices{driver}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	errs := devices.StartContext(ctx)
+	Assert(t, len(errs), 1)
+	Assert(t, errs[0].Error(), fmt.Sprintf("Device %q: %v", "Device1", context.Canceled))
+}
+
+type testHangingDriver struct {
+	testDriver
+	hang chan struct{}
+}
+
+func (t *testHangingDriver) Halt() (errs []error) {
+	<-t.hang
+	return
+}
+
+func TestRobotStopHaltTimeout(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	testDriverHalt = func() (errs []error) { return }
+	hanging := &testHangingDriver{
+		testDriver: testDriver{name: "Device1", Commander: NewCommander()},
+		hang:       make(chan struct{}),
+	}
+	defer close(hanging.hang)
+	other := &testDriver{name: "Device2", Commander: NewCommander()}
+
+	r := NewRobot("Robot1", []Device{hanging, other})
+	Assert(t, r.HaltTimeout, DefaultHaltTimeout)
+	r.HaltTimeout = time.Hour
+	r.SetHaltTimeout("Device1", 10*time.Millisecond)
+	Assert(t, len(r.Start()), 0)
+
+	errs := r.Stop()
+	Assert(t, len(errs), 1)
+	Assert(t, errs[0].Error(), fmt.Sprintf("Device %q: halt timed out after %v", "Device1", 10*time.Millisecond))
+	Assert(t, r.State(), StateStopped)
+}
+
+func TestRobotStartRollbackHaltTimeout(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	testDriverStart = func() (errs []error) { return }
+	hanging := &testHangingDriver{
+		testDriver: testDriver{name: "Device1", Commander: NewCommander()},
+		hang:       make(chan struct{}),
+	}
+	defer close(hanging.hang)
+	calls := []string{}
+	failing := &testRecordingDriver{
+		testDriver: testDriver{name: "Device2", Commander: NewCommander()},
+		calls:      &calls,
+		startErr:   errors.New("start error"),
+	}
+
+	r := NewRobot("Robot1", []Device{hanging, failing})
+	r.SetHaltTimeout("Device1", 10*time.Millisecond)
+
+	errs := r.Start()
+	Assert(t, len(errs), 2)
+	Assert(t, errs[0].Error(), `Device "Device2": start error`)
+	Assert(t, errs[1].Error(), fmt.Sprintf("Device %q: rollback: halt timed out after %v", "Device1", 10*time.Millisecond))
+	Assert(t, r.State(), StateFailed)
+}
+
+func TestRobotsStopAll(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r1 := newTestRecordingRobot("A", &calls)
+	r2 := newTestRecordingRobot("B", &calls)
+	robots := &Robots{r1, r2}
+	Assert(t, len(robots.Start()), 0)
+	r1.Device("AD1").(*testRecordingDriver).haltErr = errors.New("halt error")
+
+	calls = calls[:0]
+	errs := robots.Stop()
+	Assert(t, len(errs), 1)
+	Assert(t, errs[0].Error(), `Robot "A": Device "AD1": halt error`)
+	Assert(t, calls, []string{
+		"halt AD2", "halt AD1", "finalize AC2", "finalize AC1",
+		"halt BD2", "halt BD1", "finalize BC2", "finalize BC1",
+	})
+	Assert(t, r2.State(), StateStopped)
+}
+
+func TestGobotSignals(t *testing.T) {
+	g := NewGobot()
+	Assert(t, g.Signals, DefaultSignals)
+	Assert(t, len(g.Signals), 2)
+}
+
+func TestGobotStartUntrap(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	g := NewGobot()
+	trapped := make(chan chan os.Signal, 1)
+	untrapped := make(chan chan os.Signal, 1)
+	g.trap = func(c chan os.Signal) { trapped <- c }
+	g.untrap = func(c chan os.Signal) { untrapped <- c }
+	r := g.AddRobot(newTestRecordingRobot("R", &calls))
+	r.Connection("RC1").(*testRecordingAdaptor).connectErr = errors.New("connect error")
+
+	// the signals are released even though the robots failed to start
+	Assert(t, len(g.Start()), 1)
+	Assert(t, <-untrapped, <-trapped)
+}
+
+func TestGobotShutdownTimeout(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	hanging := &testHangingDriver{
+		testDriver: testDriver{name: "Device1", Commander: NewCommander()},
+		hang:       make(chan struct{}),
+	}
+	defer close(hanging.hang)
+
+	g := NewGobot()
+	g.trap = func(c chan os.Signal) {
+		c <- os.Interrupt
+	}
+	r := g.AddRobot(NewRobot("Robot1", []Device{hanging}))
+	r.HaltTimeout = 0
+	g.ShutdownTimeout = 10 * time.Millisecond
+	safe := make(chan bool, 1)
+	g.SafeState = func() { safe <- true }
+
+	done := make(chan []error, 1)
+	go func() {
+		done <- g.Start()
+	}()
+
+	select {
+	case errs := <-done:
+		Assert(t, len(errs), 0)
+	case <-time.After(time.Second):
+		t.Fatalf("Start did not return after the shutdown timeout")
+	}
+	Assert(t, <-safe, true)
+}
+
+type testRecordingAdaptor struct {
+	testAdaptor
+	calls      *[]string
+	connectErr error
+}
+
+func (t *testRecordingAdaptor) Connect() (errs []error) {
+	*t.calls = append(*t.calls, "connect "+t.name)
+	if t.connectErr != nil {
+		errs = append(errs, t.connectErr)
+	}
+	return
+}
+
+func (t *testRecordingAdaptor) Finalize() (errs []error) {
+	*t.calls = append(*t.calls, "finalize "+t.name)
+	return
+}
+
+type testRecordingDriver struct {
+	testDriver
+	calls    *[]string
+	startErr error
+	haltErr  error
+}
+
+func (t *testRecordingDriver) Start() (errs []error) {
+	*t.calls = append(*t.calls, "start "+t.name)
+	if t.startErr != nil {
+		errs = append(errs, t.startErr)
+	}
+	return
+}
+
+func (t *testRecordingDriver) Halt() (errs []error) {
+	*t.calls = append(*t.calls, "halt "+t.name)
+	if t.haltErr != nil {
+		errs = append(errs, t.haltErr)
+	}
+	return
+}
+
+func newTestRecordingRobot(name string, calls *[]string) *Robot {
+	a1 := &testRecordingAdaptor{testAdaptor: testAdaptor{name: name + "C1"}, calls: calls}
+	a2 := &testRecordingAdaptor{testAdaptor: testAdaptor{name: name + "C2"}, calls: calls}
+	d1 := &testRecordingDriver{testDriver: testDriver{name: name + "D1", connection: a1, Commander: NewCommander()}, calls: calls}
+	d2 := &testRecordingDriver{testDriver: testDriver{name: name + "D2", connection: a2, Commander: NewCommander()}, calls: calls}
+	return NewRobot(name, []Connection{a1, a2}, []Device{d1, d2})
+}
+
+func TestRobotStartRollbackConnections(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := newTestRecordingRobot("R", &calls)
+	r.Connection("RC2").(*testRecordingAdaptor).connectErr = errors.New("connect error")
+
+	errs := r.Start()
+	Assert(t, len(errs), 1)
+	Assert(t, errs[0].Error(), "Connection \"RC2\": connect error")
+	Assert(t, calls, []string{"connect RC1", "connect RC2", "finalize RC1"})
+}
+
+func TestRobotStartRollbackDevices(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := newTestRecordingRobot("R", &calls)
+	r.Device("RD2").(*testRecordingDriver).startErr = errors.New("start error")
+
+	errs := r.Start()
+	Assert(t, len(errs), 1)
+	Assert(t, errs[0].Error(), "Device \"RD2\": start error")
+	Assert(t, calls, []string{
+		"connect RC1", "connect RC2",
+		"start RD1", "start RD2",
+		"halt RD1",
+		"finalize RC2", "finalize RC1",
+	})
+}
+
+func TestRobotsStartRollback(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r1 := newTestRecordingRobot("A", &calls)
+	r2 := newTestRecordingRobot("B", &calls)
+	r2.Connection("BC1").(*testRecordingAdaptor).connectErr = errors.New("connect error")
+	robots := &Robots{r1, r2}
+
+	errs := robots.Start()
+	Assert(t, len(errs), 1)
+	Assert(t, errs[0].Error(), "Robot \"B\": Connection \"BC1\": connect error")
+	Assert(t, calls, []string{
+		"connect AC1", "connect AC2",
+		"start AD1", "start AD2",
+		"connect BC1",
+		"halt AD2", "halt AD1",
+		"finalize AC2", "finalize AC1",
+	})
+}
+
+func TestRobotState(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := newTestRecordingRobot("R", &calls)
+	Assert(t, r.State(), StateIdle)
+
+	states := make(chan State, 10)
+	On(r.Event(StateEvent), func(data interface{}) {
+		states <- data.(State)
+	})
+
+	var working State
+	r.Work = func() { working = r.State() }
+
+	Assert(t, len(r.Start()), 0)
+	Assert(t, working, StateRunning)
+	Assert(t, NewJSONRobot(r).State, StateRunning)
+
+	Assert(t, len(r.Stop()), 0)
+	Assert(t, r.State(), StateStopped)
+
+	for _, expected := range []State{StateConnecting, StateStarting, StateRunning, StateStopping, StateStopped} {
+		select {
+		case s := <-states:
+			Assert(t, s, expected)
+		case <-time.After(time.Second):
+			t.Fatalf("%v was not published", expected)
+		}
+	}
+}
+
+func TestRobotStateFailed(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := newTestRecordingRobot("R", &calls)
+	r.Connection("RC2").(*testRecordingAdaptor).connectErr = errors.New("connect error")
+	r.Start()
+	Assert(t, r.State(), StateFailed)
+
+	r = newTestRecordingRobot("R", &calls)
+	r.Device("RD2").(*testRecordingDriver).startErr = errors.New("start error")
+	r.Start()
+	Assert(t, r.State(), StateFailed)
+
+	r1 := newTestRecordingRobot("A", &calls)
+	r2 := newTestRecordingRobot("B", &calls)
+	r2.Connection("BC1").(*testRecordingAdaptor).connectErr = errors.New("connect error")
+	robots := &Robots{r1, r2}
+	robots.Start()
+	Assert(t, r1.State(), StateStopped)
+	Assert(t, r2.State(), StateFailed)
+}
+
+func TestRobotAttachDevice(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := newTestRecordingRobot("R", &calls)
+	a := r.Connection("RC1").(*testRecordingAdaptor)
+
+	d := &testRecordingDriver{testDriver: testDriver{name: "RD3", connection: a, Commander: NewCommander()}, calls: &calls}
+	Assert(t, len(r.AttachDevice(d)), 0)
+	Assert(t, r.Device("RD3"), Device(d))
+	Assert(t, len(calls), 0)
+
+	Assert(t, r.AttachDevice(d)[0].Error(), "Device \"RD3\": already attached")
+
+	other := &testRecordingDriver{testDriver: testDriver{name: "RD4", connection: newTestAdaptor("other", "")}, calls: &calls}
+	Assert(t, r.AttachDevice(other)[0].Error(), "Device \"RD4\": Connection \"other\" is not attached")
+
+	Assert(t, len(r.Start()), 0)
+	calls = calls[:0]
+
+	d = &testRecordingDriver{testDriver: testDriver{name: "RD5", connection: a, Commander: NewCommander()}, calls: &calls}
+	Assert(t, len(r.AttachDevice(d)), 0)
+	Assert(t, calls, []string{"start RD5"})
+
+	d = &testRecordingDriver{testDriver: testDriver{name: "RD6", connection: a}, calls: &calls, startErr: errors.New("start error")}
+	Assert(t, r.AttachDevice(d)[0].Error(), "Device \"RD6\": start error")
+	Assert(t, r.Device("RD6"), nil)
+
+	Assert(t, len(r.DetachDevice("RD5")), 0)
+	Assert(t, r.Device("RD5"), nil)
+	Assert(t, calls[len(calls)-1], "halt RD5")
+	Assert(t, r.DetachDevice("RD5")[0].Error(), "Device \"RD5\": not attached")
+	Assert(t, r.Devices().Len(), 3)
+}
+
+func TestRobotAttachConnection(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := newTestRecordingRobot("R", &calls)
+	Assert(t, len(r.Start()), 0)
+	calls = calls[:0]
+
+	a := &testRecordingAdaptor{testAdaptor: testAdaptor{name: "RC3"}, calls: &calls}
+	Assert(t, len(r.AttachConnection(a)), 0)
+	Assert(t, r.Connection("RC3"), Connection(a))
+	Assert(t, calls, []string{"connect RC3"})
+	Assert(t, r.AttachConnection(a)[0].Error(), "Connection \"RC3\": already attached")
+
+	failing := &testRecordingAdaptor{testAdaptor: testAdaptor{name: "RC4"}, calls: &calls, connectErr: errors.New("connect error")}
+	Assert(t, r.AttachConnection(failing)[0].Error(), "Connection \"RC4\": connect error")
+	Assert(t, r.Connection("RC4"), nil)
+
+	Assert(t, r.DetachConnection("RC1")[0].Error(), "Connection \"RC1\": used by Device \"RD1\"")
+	Assert(t, len(r.DetachConnection("RC3")), 0)
+	Assert(t, r.Connection("RC3"), nil)
+	Assert(t, calls[len(calls)-1], "finalize RC3")
+	Assert(t, r.DetachConnection("RC3")[0].Error(), "Connection \"RC3\": not attached")
+}
+
+func TestRobotAttachConcurrently(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := newTestRobot("Robot1")
+	c := r.Connection("Connection1").(*testAdaptor)
+
+	attached := make(chan bool, 10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			attached <- len(r.AttachDevice(newTestDriver(c, "Device", "0"))) == 0
+		}()
+	}
+	succeeded := 0
+	for i := 0; i < 10; i++ {
+		if <-attached {
+			succeeded++
+		}
+	}
+	Assert(t, succeeded, 1)
+	Assert(t, r.Devices().Len(), 4)
+}
+
+func TestRobotAttachWhileStarting(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	testDriverStart = func() (errs []error) { return }
+	driver := &testBlockingDriver{
+		testDriver: testDriver{name: "Device1", Commander: NewCommander()},
+		block:      make(chan struct{}),
+	}
+	r := NewRobot("Robot1", []Device{driver})
+	started := make(chan []error)
+	go func() { started <- r.Start() }()
+	for r.State() != StateStarting {
+		<-time.After(time.Millisecond)
+	}
+
+	late := &testDriver{name: "Device2", Commander: NewCommander()}
+	Assert(t, r.AttachDevice(late)[0].Error(), `Device "Device2": Robot is starting`)
+	Assert(t, r.DetachDevice("Device1")[0].Error(), `Device "Device1": Robot is starting`)
+	Assert(t, r.AttachConnection(newTestAdaptor("Connection1", ""))[0].Error(), `Connection "Connection1": Robot is starting`)
+
+	close(driver.block)
+	Assert(t, len(<-started), 0)
+	Assert(t, len(r.AttachDevice(late)), 0)
+	r.Stop()
+}
+
+func TestRobotDevicesSnapshot(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := newTestRobot("Robot1")
+	done := make(chan bool)
+	go func() {
+		for i := 0; i < 100; i++ {
+			r.AttachDevice(newTestDriver(r.Connection("Connection1").(*testAdaptor), fmt.Sprintf("Device%v", i+10), "0"))
+		}
+		done <- true
+	}()
+	for i := 0; i < 100; i++ {
+		r.Devices().Each(func(Device) {})
+	}
+	<-done
+	Assert(t, r.Devices().Len(), 103)
+}
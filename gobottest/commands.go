@@ -0,0 +1,33 @@
+package gobottest
+
+import (
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// CallCommand calls the command name of c with params and returns its result.
+// It fails the test if the command does not exist, panics, or rejects params
+// with a *gobot.ParamError. Call the command directly to test its validation.
+func CallCommand(t testing.TB, c gobot.Commander, name string, params map[string]interface{}) (result interface{}) {
+	t.Helper()
+	command := c.Command(name)
+	if command == nil {
+		t.Errorf("Command %q does not exist", name)
+		return nil
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("Command %q panicked: %v", name, r)
+			result = nil
+		}
+	}()
+	result = command(params)
+	if err, ok := result.(*gobot.ParamError); ok {
+		t.Errorf("Command %q rejected its params: %v", name, err)
+	}
+	return result
+}
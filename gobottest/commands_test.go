@@ -0,0 +1,38 @@
+package gobottest
+
+import (
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestCallCommand(t *testing.T) {
+	c := gobot.NewCommander().(gobot.SchemaCommander)
+	c.AddCommand("Echo", func(params map[string]interface{}) interface{} {
+		return params["value"]
+	})
+	c.AddCommand("Panic", func(params map[string]interface{}) interface{} {
+		panic("oops")
+	})
+	c.AddCommandSchema("Move", &gobot.CommandSchema{
+		Params: []gobot.Param{{Name: "angle", Type: gobot.NumberParam, Required: true}},
+	}, func(params map[string]interface{}) interface{} {
+		return params["angle"]
+	})
+
+	gobot.Assert(t, CallCommand(t, c, "Echo", map[string]interface{}{"value": 1}), 1)
+	gobot.Assert(t, CallCommand(t, c, "Echo", nil), nil)
+	gobot.Assert(t, CallCommand(t, c, "Move", map[string]interface{}{"angle": 90}), 90.0)
+
+	ft := &fakeT{}
+	gobot.Assert(t, CallCommand(ft, c, "Missing", nil), nil)
+	gobot.Assert(t, len(ft.failures), 1)
+
+	ft = &fakeT{}
+	gobot.Assert(t, CallCommand(ft, c, "Panic", nil), nil)
+	gobot.Assert(t, len(ft.failures), 1)
+
+	ft = &fakeT{}
+	CallCommand(ft, c, "Move", nil)
+	gobot.Assert(t, len(ft.failures), 1)
+}
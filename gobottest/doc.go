@@ -0,0 +1,33 @@
+/*
+Package gobottest provides helpers for testing Gobot robots, drivers and
+adaptors.
+
+WatchEvent, ExpectEvent and ExpectNoEvent assert on the data published on an
+Event within a timeout, and CallCommand calls a command of a Commander the way
+the API does. FakeConnection and FakeDriver record the calls made to them, and
+FakeLogger the records logged.
+
+FakeClock replaces the real clock used by gobot schedules and by the polling
+loops of drivers, so tests advance time themselves instead of sleeping:
+
+	func TestButtonPush(t *testing.T) {
+		clock := gobottest.NewFakeClock(time.Now())
+		defer gobot.SetClock(gobot.SetClock(clock))
+
+		adaptor := sim.NewSimAdaptor("sim")
+		button := gpio.NewButtonDriver(adaptor, "button", "2")
+		push := gobottest.WatchEvent(button.Event("push"))
+		defer push.Stop()
+
+		button.Start()
+		defer button.Halt()
+
+		// wait for the first poll, then press and poll again
+		clock.BlockUntil(1)
+		adaptor.Set("2", 1)
+		clock.Advance(10 * time.Millisecond)
+
+		push.Expect(t, 1, time.Second)
+	}
+*/
+package gobottest
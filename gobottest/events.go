@@ -0,0 +1,136 @@
+package gobottest
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// queueSize is the size of the queue the data of a watched Event is delivered
+// through, in order.
+const queueSize = 64
+
+// EventWatcher collects the data published on an Event from the moment it was
+// created, so that assertions on it do not miss data published before they
+// are made.
+type EventWatcher struct {
+	sub    *gobot.Subscription
+	err    error
+	mutex  sync.Mutex
+	values []interface{}
+	next   int
+	notify chan struct{}
+}
+
+// WatchEvent returns a new EventWatcher collecting the data published on e.
+func WatchEvent(e *gobot.Event) *EventWatcher {
+	w := &EventWatcher{notify: make(chan struct{}, 1)}
+	w.sub, w.err = gobot.OnQueued(e, queueSize, gobot.Block, w.add)
+	return w
+}
+
+// ExpectEvent fails the test unless value is published on e within timeout
+// after the call.
+func ExpectEvent(t testing.TB, e *gobot.Event, value interface{}, timeout time.Duration) {
+	t.Helper()
+	w := WatchEvent(e)
+	defer w.Stop()
+	w.Expect(t, value, timeout)
+}
+
+// ExpectNoEvent fails the test if anything is published on e within timeout
+// after the call.
+func ExpectNoEvent(t testing.TB, e *gobot.Event, timeout time.Duration) {
+	t.Helper()
+	w := WatchEvent(e)
+	defer w.Stop()
+	w.ExpectNone(t, timeout)
+}
+
+// Expect fails the test unless value is published within timeout. The data
+// published before value, and value itself, are consumed, so that consecutive
+// calls expect data in the order it is published.
+func (w *EventWatcher) Expect(t testing.TB, value interface{}, timeout time.Duration) {
+	t.Helper()
+	if w.err != nil {
+		t.Errorf("Cannot watch event: %v", w.err)
+		return
+	}
+	skipped := []interface{}{}
+	deadline := time.After(timeout)
+	for {
+		if v, ok := w.consume(); ok {
+			if reflect.DeepEqual(v, value) {
+				return
+			}
+			skipped = append(skipped, v)
+			continue
+		}
+		select {
+		case <-w.notify:
+		case <-deadline:
+			t.Errorf("Event %v was not published within %v, got %v", value, timeout, skipped)
+			return
+		}
+	}
+}
+
+// ExpectNone fails the test if any data not consumed by Expect yet is published
+// within timeout.
+func (w *EventWatcher) ExpectNone(t testing.TB, timeout time.Duration) {
+	t.Helper()
+	if w.err != nil {
+		t.Errorf("Cannot watch event: %v", w.err)
+		return
+	}
+	deadline := time.After(timeout)
+	for {
+		if v, ok := w.consume(); ok {
+			t.Errorf("Event should not be published, got %v", v)
+			return
+		}
+		select {
+		case <-w.notify:
+		case <-deadline:
+			return
+		}
+	}
+}
+
+// Values returns all data published since the EventWatcher was created.
+func (w *EventWatcher) Values() []interface{} {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return append([]interface{}{}, w.values...)
+}
+
+// Stop stops collecting data.
+func (w *EventWatcher) Stop() {
+	if w.sub != nil {
+		w.sub.Unsubscribe()
+	}
+}
+
+// consume returns the oldest data not consumed yet, if any.
+func (w *EventWatcher) consume() (interface{}, bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.next == len(w.values) {
+		return nil, false
+	}
+	w.next++
+	return w.values[w.next-1], true
+}
+
+func (w *EventWatcher) add(data interface{}) {
+	w.mutex.Lock()
+	w.values = append(w.values, data)
+	w.mutex.Unlock()
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
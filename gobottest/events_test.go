@@ -0,0 +1,77 @@
+package gobottest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// fakeT records the failures of the helpers under test.
+type fakeT struct {
+	testing.TB
+	failures []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.failures = append(t.failures, format)
+}
+
+func TestExpectEvent(t *testing.T) {
+	e := gobot.NewEvent()
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		gobot.Publish(e, 1)
+	}()
+	ExpectEvent(t, e, 1, time.Second)
+
+	ft := &fakeT{}
+	ExpectEvent(ft, e, 1, 10*time.Millisecond)
+	gobot.Assert(t, len(ft.failures), 1)
+
+	ft = &fakeT{}
+	ExpectEvent(ft, nil, 1, 10*time.Millisecond)
+	gobot.Assert(t, len(ft.failures), 1)
+}
+
+func TestExpectNoEvent(t *testing.T) {
+	e := gobot.NewEvent()
+	ExpectNoEvent(t, e, 10*time.Millisecond)
+
+	ft := &fakeT{}
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		gobot.Publish(e, 1)
+	}()
+	ExpectNoEvent(ft, e, time.Second)
+	gobot.Assert(t, len(ft.failures), 1)
+}
+
+func TestEventWatcher(t *testing.T) {
+	e := gobot.NewEvent()
+	w := WatchEvent(e)
+	defer w.Stop()
+
+	gobot.Publish(e, 1)
+	gobot.Publish(e, 2)
+	gobot.Publish(e, 3)
+
+	w.Expect(t, 1, time.Second)
+	w.Expect(t, 3, time.Second)
+	w.ExpectNone(t, 10*time.Millisecond)
+	gobot.Assert(t, w.Values(), []interface{}{1, 2, 3})
+
+	ft := &fakeT{}
+	w.Expect(ft, 2, 10*time.Millisecond)
+	gobot.Assert(t, len(ft.failures), 1)
+
+	gobot.Publish(e, 4)
+	ft = &fakeT{}
+	w.ExpectNone(ft, time.Second)
+	gobot.Assert(t, len(ft.failures), 1)
+
+	w.Stop()
+	gobot.Assert(t, e.SubscriberCount(), 0)
+}
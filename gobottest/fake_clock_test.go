@@ -0,0 +1,63 @@
+package gobottest
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewFakeClock(start)
+	gobot.Assert(t, c.Now(), start)
+
+	after := c.After(2 * time.Second)
+	timer := c.NewTimer(time.Second)
+	stopped := c.NewTimer(time.Second)
+	gobot.Assert(t, c.Timers(), 3)
+	gobot.Assert(t, stopped.Stop(), true)
+	gobot.Assert(t, stopped.Stop(), false)
+
+	c.Advance(1500 * time.Millisecond)
+	gobot.Assert(t, <-timer.C(), start.Add(time.Second))
+	gobot.Assert(t, timer.Stop(), false)
+	gobot.Assert(t, c.Now(), start.Add(1500*time.Millisecond))
+	gobot.Assert(t, c.Timers(), 1)
+
+	select {
+	case <-after:
+		t.Errorf("Timer should not fire before its time")
+	default:
+	}
+	c.Advance(time.Second)
+	gobot.Assert(t, <-after, start.Add(2*time.Second))
+
+	select {
+	case <-c.After(0):
+	default:
+		t.Errorf("Timer for 0 should fire immediately")
+	}
+}
+
+func TestFakeClockEvery(t *testing.T) {
+	c := NewFakeClock(time.Now())
+	defer gobot.SetClock(gobot.SetClock(c))
+
+	runs := int32(0)
+	ran := make(chan bool, 10)
+	s := gobot.Every(time.Minute, func() {
+		atomic.AddInt32(&runs, 1)
+		ran <- true
+	})
+	defer s.Stop()
+
+	for i := 0; i < 3; i++ {
+		c.BlockUntil(1)
+		c.Advance(time.Minute)
+		<-ran
+	}
+	gobot.Assert(t, atomic.LoadInt32(&runs), int32(3))
+	gobot.Assert(t, gobot.Now(), c.Now())
+}
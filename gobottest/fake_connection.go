@@ -0,0 +1,39 @@
+package gobottest
+
+import "github.com/hybridgroup/gobot"
+
+var _ gobot.Connection = (*FakeConnection)(nil)
+
+// FakeConnection is a Connection recording the calls to Connect and Finalize.
+type FakeConnection struct {
+	name string
+	port string
+	// ConnectErrs are returned by Connect.
+	ConnectErrs []error
+	// FinalizeErrs are returned by Finalize.
+	FinalizeErrs []error
+	Recorder
+}
+
+// NewFakeConnection returns a new FakeConnection given a name.
+func NewFakeConnection(name string) *FakeConnection {
+	return &FakeConnection{name: name, port: "/dev/null"}
+}
+
+// Name returns the FakeConnections name
+func (c *FakeConnection) Name() string { return c.name }
+
+// Port returns the FakeConnections port
+func (c *FakeConnection) Port() string { return c.port }
+
+// Connect records the call and returns ConnectErrs
+func (c *FakeConnection) Connect() (errs []error) {
+	c.Record("Connect")
+	return c.ConnectErrs
+}
+
+// Finalize records the call and returns FinalizeErrs
+func (c *FakeConnection) Finalize() (errs []error) {
+	c.Record("Finalize")
+	return c.FinalizeErrs
+}
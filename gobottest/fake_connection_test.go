@@ -0,0 +1,19 @@
+package gobottest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestFakeConnection(t *testing.T) {
+	c := NewFakeConnection("fake")
+	gobot.Assert(t, c.Name(), "fake")
+	gobot.Assert(t, c.Port(), "/dev/null")
+	gobot.Assert(t, len(c.Connect()), 0)
+
+	c.FinalizeErrs = []error{errors.New("finalize error")}
+	gobot.Assert(t, c.Finalize(), c.FinalizeErrs)
+	gobot.Assert(t, c.Calls(), []Call{{Method: "Connect"}, {Method: "Finalize"}})
+}
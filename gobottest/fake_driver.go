@@ -0,0 +1,52 @@
+package gobottest
+
+import "github.com/hybridgroup/gobot"
+
+var _ gobot.Driver = (*FakeDriver)(nil)
+
+// FakeDriver is a Driver recording the calls to Start and Halt. Tests add the
+// events and commands they need with the embedded Eventer and Commander.
+type FakeDriver struct {
+	name       string
+	pin        string
+	connection gobot.Connection
+	// StartErrs are returned by Start.
+	StartErrs []error
+	// HaltErrs are returned by Halt.
+	HaltErrs []error
+	Recorder
+	gobot.Eventer
+	gobot.Commander
+}
+
+// NewFakeDriver returns a new FakeDriver given a Connection, name and pin.
+func NewFakeDriver(c gobot.Connection, name string, pin string) *FakeDriver {
+	return &FakeDriver{
+		name:       name,
+		pin:        pin,
+		connection: c,
+		Eventer:    gobot.NewEventer(),
+		Commander:  gobot.NewCommander(),
+	}
+}
+
+// Name returns the FakeDrivers name
+func (d *FakeDriver) Name() string { return d.name }
+
+// Pin returns the FakeDrivers pin
+func (d *FakeDriver) Pin() string { return d.pin }
+
+// Connection returns the FakeDrivers Connection
+func (d *FakeDriver) Connection() gobot.Connection { return d.connection }
+
+// Start records the call and returns StartErrs
+func (d *FakeDriver) Start() (errs []error) {
+	d.Record("Start")
+	return d.StartErrs
+}
+
+// Halt records the call and returns HaltErrs
+func (d *FakeDriver) Halt() (errs []error) {
+	d.Record("Halt")
+	return d.HaltErrs
+}
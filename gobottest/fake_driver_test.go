@@ -0,0 +1,32 @@
+package gobottest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestFakeDriver(t *testing.T) {
+	c := NewFakeConnection("fake")
+	d := NewFakeDriver(c, "driver", "13")
+	gobot.Assert(t, d.Name(), "driver")
+	gobot.Assert(t, d.Pin(), "13")
+	gobot.Assert(t, d.Connection(), gobot.Connection(c))
+
+	d.StartErrs = []error{errors.New("start error")}
+	gobot.Assert(t, d.Start(), d.StartErrs)
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, d.Calls(), []Call{{Method: "Start"}, {Method: "Halt"}})
+}
+
+func TestFakeDriverRobot(t *testing.T) {
+	c := NewFakeConnection("fake")
+	d := NewFakeDriver(c, "driver", "13")
+	r := gobot.NewRobot("bot", []gobot.Connection{c}, []gobot.Device{d})
+
+	gobot.Assert(t, len(r.Start()), 0)
+	gobot.Assert(t, len(r.Stop()), 0)
+	gobot.Assert(t, c.Calls(), []Call{{Method: "Connect"}, {Method: "Finalize"}})
+	gobot.Assert(t, d.Calls(), []Call{{Method: "Start"}, {Method: "Halt"}})
+}
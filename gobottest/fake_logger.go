@@ -0,0 +1,41 @@
+package gobottest
+
+import (
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Logger = (*FakeLogger)(nil)
+
+// LogRecord is a record written to a FakeLogger.
+type LogRecord struct {
+	Level  gobot.Level
+	Msg    string
+	Fields gobot.Fields
+}
+
+// FakeLogger is a Logger keeping the records written to it.
+type FakeLogger struct {
+	mutex   sync.Mutex
+	records []LogRecord
+}
+
+// NewFakeLogger returns a new FakeLogger.
+func NewFakeLogger() *FakeLogger {
+	return &FakeLogger{}
+}
+
+// Log implements the Logger interface
+func (l *FakeLogger) Log(level gobot.Level, msg string, fields gobot.Fields) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.records = append(l.records, LogRecord{Level: level, Msg: msg, Fields: fields})
+}
+
+// Records returns the records written so far, in order.
+func (l *FakeLogger) Records() []LogRecord {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return append([]LogRecord{}, l.records...)
+}
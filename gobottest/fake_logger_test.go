@@ -0,0 +1,18 @@
+package gobottest
+
+import (
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestFakeLogger(t *testing.T) {
+	l := NewFakeLogger()
+	r := gobot.NewRobot("bot")
+	r.SetLogger(l)
+
+	r.Logger().Log(gobot.WarnLevel, "careful", gobot.Fields{"device": "led"})
+	gobot.Assert(t, l.Records(), []LogRecord{
+		{Level: gobot.WarnLevel, Msg: "careful", Fields: gobot.Fields{"robot": "bot", "device": "led"}},
+	})
+}
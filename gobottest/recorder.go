@@ -0,0 +1,50 @@
+package gobottest
+
+import "sync"
+
+// Call is a method call recorded by a Recorder.
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+// Recorder records method calls in order. FakeConnection and FakeDriver embed
+// it, and so can the fakes of platform specific interfaces.
+type Recorder struct {
+	mutex sync.Mutex
+	calls []Call
+}
+
+// Record records a call to method with args.
+func (r *Recorder) Record(method string, args ...interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls returns all calls recorded so far.
+func (r *Recorder) Calls() []Call {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]Call{}, r.calls...)
+}
+
+// CallsTo returns the calls to method recorded so far.
+func (r *Recorder) CallsTo(method string) []Call {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	calls := []Call{}
+	for _, c := range r.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+// Reset forgets all calls recorded so far.
+func (r *Recorder) Reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.calls = nil
+}
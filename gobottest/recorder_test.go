@@ -0,0 +1,25 @@
+package gobottest
+
+import (
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestRecorder(t *testing.T) {
+	r := &Recorder{}
+	r.Record("Write", "1", 1)
+	r.Record("Read", "2")
+	r.Record("Write", "1", 0)
+
+	gobot.Assert(t, len(r.Calls()), 3)
+	gobot.Assert(t, r.Calls()[1], Call{Method: "Read", Args: []interface{}{"2"}})
+	gobot.Assert(t, r.CallsTo("Write"), []Call{
+		{Method: "Write", Args: []interface{}{"1", 1}},
+		{Method: "Write", Args: []interface{}{"1", 0}},
+	})
+	gobot.Assert(t, r.CallsTo("Halt"), []Call{})
+
+	r.Reset()
+	gobot.Assert(t, len(r.Calls()), 0)
+}
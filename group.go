@@ -0,0 +1,253 @@
+package gobot
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// AddTag adds tags to the Robot. Robots sharing a tag form the Group of that name.
+func (r *Robot) AddTag(tags ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.tags == nil {
+		r.tags = make(map[string]bool)
+	}
+	for _, tag := range tags {
+		r.tags[tag] = true
+	}
+}
+
+// RemoveTag removes tags from the Robot.
+func (r *Robot) RemoveTag(tags ...string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, tag := range tags {
+		delete(r.tags, tag)
+	}
+}
+
+// HasTag returns true if the Robot has the tag.
+func (r *Robot) HasTag(tag string) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	return r.tags[tag]
+}
+
+// Tags returns the sorted tags of the Robot.
+func (r *Robot) Tags() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	tags := []string{}
+	for tag := range r.tags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// Group is the named set of Robots of a Gobot sharing the tag of that name.
+// Membership is evaluated whenever the Group is used, so robots tagged after
+// the Group was returned are included.
+type Group struct {
+	name  string
+	gobot *Gobot
+}
+
+// JSONGroup is a JSON representation of a Group.
+type JSONGroup struct {
+	Name   string   `json:"name"`
+	Robots []string `json:"robots"`
+}
+
+// NewJSONGroup returns a JSONGroup given a Group.
+func NewJSONGroup(g *Group) *JSONGroup {
+	jsonGroup := &JSONGroup{Name: g.Name(), Robots: []string{}}
+	g.Robots().Each(func(r *Robot) {
+		jsonGroup.Robots = append(jsonGroup.Robots, r.Name)
+	})
+	return jsonGroup
+}
+
+// GroupResult is the outcome of a command called on one member of a Group.
+type GroupResult struct {
+	Robot  string      `json:"robot"`
+	Result interface{} `json:"result,omitempty"`
+	Err    error       `json:"-"`
+}
+
+// GroupResults are the outcomes of a command called on each member of a Group.
+type GroupResults []GroupResult
+
+// Errors returns the errors of the members the command failed on. A member
+// fails if it is not running, does not have the command, panics or returns an
+// error.
+func (g GroupResults) Errors() (errs []error) {
+	for _, res := range g {
+		if res.Err != nil {
+			errs = append(errs, fmt.Errorf("Robot %q: %v", res.Robot, res.Err))
+		}
+	}
+	return
+}
+
+// GroupEvent is the data of a Group's merged event stream.
+type GroupEvent struct {
+	Robot  string      `json:"robot"`
+	Device string      `json:"device,omitempty"`
+	Event  string      `json:"event"`
+	Data   interface{} `json:"data"`
+}
+
+// GroupSubscription is a subscription to an event of each member of a Group.
+type GroupSubscription struct {
+	subs []*Subscription
+}
+
+// Unsubscribe removes the subscriptions from the event of each member.
+func (s *GroupSubscription) Unsubscribe() {
+	for _, sub := range s.subs {
+		sub.Unsubscribe()
+	}
+}
+
+// Group returns the Group of robots tagged with name.
+func (g *Gobot) Group(name string) *Group {
+	return &Group{name: name, gobot: g}
+}
+
+// Groups returns the sorted names of all groups, that is all tags of its robots.
+func (g *Gobot) Groups() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	g.robots.Each(func(r *Robot) {
+		for _, tag := range r.Tags() {
+			if !seen[tag] {
+				seen[tag] = true
+				names = append(names, tag)
+			}
+		}
+	})
+	sort.Strings(names)
+	return names
+}
+
+// Name returns the name of the Group
+func (g *Group) Name() string { return g.name }
+
+// Robots returns the robots currently in the Group.
+func (g *Group) Robots() *Robots {
+	robots := &Robots{}
+	g.gobot.robots.Each(func(r *Robot) {
+		if r.HasTag(g.name) {
+			*robots = append(*robots, r)
+		}
+	})
+	return robots
+}
+
+// Len returns the amount of Robots in the Group.
+func (g *Group) Len() int {
+	return g.Robots().Len()
+}
+
+// Command calls the named robot command with params on every member of the
+// Group concurrently and returns the results in the order of the members.
+func (g *Group) Command(name string, params map[string]interface{}) GroupResults {
+	return g.fanOut(func(r *Robot) (func(map[string]interface{}) interface{}, error) {
+		if f := r.Command(name); f != nil {
+			return f, nil
+		}
+		return nil, fmt.Errorf("Unknown Command %q", name)
+	}, params)
+}
+
+// DeviceCommand calls the named command of the named device with params on
+// every member of the Group concurrently and returns the results in the order
+// of the members.
+func (g *Group) DeviceCommand(device, name string, params map[string]interface{}) GroupResults {
+	return g.fanOut(func(r *Robot) (func(map[string]interface{}) interface{}, error) {
+		d := r.Device(device)
+		if d == nil {
+			return nil, fmt.Errorf("No Device found with the name %v", device)
+		}
+		if c, ok := d.(Commander); ok {
+			if f := c.Command(name); f != nil {
+				return f, nil
+			}
+		}
+		return nil, fmt.Errorf("Unknown Command %q", name)
+	}, params)
+}
+
+// fanOut calls the command found by lookup on every running member of the Group.
+func (g *Group) fanOut(lookup func(*Robot) (func(map[string]interface{}) interface{}, error), params map[string]interface{}) GroupResults {
+	robots := *g.Robots()
+	results := make(GroupResults, len(robots))
+
+	var wg sync.WaitGroup
+	for i, r := range robots {
+		results[i].Robot = r.Name
+		if state := r.State(); state != StateRunning {
+			results[i].Err = fmt.Errorf("Robot %v is %v, not running", r.Name, state)
+			continue
+		}
+		f, err := lookup(r)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(res *GroupResult, r *Robot) {
+			defer wg.Done()
+			// each member gets its own copy, as commands may modify their params
+			p := make(map[string]interface{}, len(params))
+			for k, v := range params {
+				p[k] = v
+			}
+			if err := r.Protect(func() { res.Result = f(p) }); err != nil {
+				res.Err = err
+			} else if err, ok := res.Result.(error); ok {
+				res.Result, res.Err = nil, err
+			}
+		}(&results[i], r)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// On subscribes f to the named robot event of every member of the Group,
+// merging them into a single stream of GroupEvents. Members without the event
+// are skipped. Membership is fixed when On is called.
+func (g *Group) On(event string, f func(GroupEvent)) *GroupSubscription {
+	return g.subscribe(func(r *Robot) Eventer { return r.Eventer }, "", event, f)
+}
+
+// OnDevice subscribes f to the named event of the named device of every member
+// of the Group, merging them into a single stream of GroupEvents. Members
+// without the device or event are skipped. Membership is fixed when OnDevice
+// is called.
+func (g *Group) OnDevice(device, event string, f func(GroupEvent)) *GroupSubscription {
+	return g.subscribe(func(r *Robot) Eventer {
+		e, _ := r.Device(device).(Eventer)
+		return e
+	}, device, event, f)
+}
+
+func (g *Group) subscribe(eventer func(*Robot) Eventer, device, event string, f func(GroupEvent)) *GroupSubscription {
+	s := &GroupSubscription{}
+	g.Robots().Each(func(r *Robot) {
+		e := eventer(r)
+		if e == nil || e.Event(event) == nil {
+			return
+		}
+		name := r.Name
+		sub, _ := On(e.Event(event), func(data interface{}) {
+			f(GroupEvent{Robot: name, Device: device, Event: event, Data: data})
+		})
+		s.subs = append(s.subs, sub)
+	})
+	return s
+}
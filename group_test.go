@@ -0,0 +1,129 @@
+package gobot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func initTestGroup() *Gobot {
+	g := NewGobot()
+	for _, name := range []string{"Robot1", "Robot2", "Robot3"} {
+		r := g.AddRobot(newTestRobot(name))
+		r.AddCommand("Name", func(params map[string]interface{}) interface{} {
+			return r.Name + params["suffix"].(string)
+		})
+	}
+	g.Robot("Robot1").AddTag("swarm", "red")
+	g.Robot("Robot2").AddTag("swarm")
+	g.Robot("Robot3").AddTag("blue")
+	return g
+}
+
+func TestRobotTags(t *testing.T) {
+	r := newTestRobot("Robot1")
+	Assert(t, r.Tags(), []string{})
+
+	r.AddTag("b", "a")
+	Assert(t, r.Tags(), []string{"a", "b"})
+	Assert(t, r.HasTag("a"), true)
+
+	r.RemoveTag("a")
+	Assert(t, r.HasTag("a"), false)
+	Assert(t, NewJSONRobot(r).Tags, []string{"b"})
+}
+
+func TestGroups(t *testing.T) {
+	g := initTestGroup()
+	Assert(t, g.Groups(), []string{"blue", "red", "swarm"})
+	Assert(t, g.Group("swarm").Len(), 2)
+	Assert(t, NewJSONGroup(g.Group("swarm")).Robots, []string{"Robot1", "Robot2"})
+	Assert(t, g.Group("green").Len(), 0)
+
+	g.Robot("Robot3").AddTag("swarm")
+	Assert(t, g.Group("swarm").Len(), 3)
+}
+
+func TestGroupCommand(t *testing.T) {
+	g := initTestGroup()
+	g.Robots().Start()
+	g.Robot("Robot3").AddTag("swarm")
+	g.Robot("Robot3").Stop()
+
+	results := g.Group("swarm").Command("Name", map[string]interface{}{"suffix": "!"})
+	Assert(t, len(results), 3)
+	Assert(t, results[0], GroupResult{Robot: "Robot1", Result: "Robot1!"})
+	Assert(t, results[1], GroupResult{Robot: "Robot2", Result: "Robot2!"})
+	Assert(t, results[2].Err.Error(), "Robot Robot3 is stopped, not running")
+	Assert(t, len(results.Errors()), 1)
+
+	g.Robot("Robot2").AddCommand("Name", func(params map[string]interface{}) interface{} {
+		return errors.New("failed")
+	})
+	g.Robot("Robot1").AddCommand("Name", func(params map[string]interface{}) interface{} {
+		panic("boom")
+	})
+	results = g.Group("swarm").Command("Name", nil)
+	Assert(t, results[0].Err.Error(), "panic: boom")
+	Assert(t, results[1], GroupResult{Robot: "Robot2", Err: errors.New("failed")})
+	Assert(t, results.Errors()[1].Error(), `Robot "Robot2": failed`)
+
+	results = g.Group("swarm").Command("Unknown", nil)
+	Assert(t, results[0].Err.Error(), `Unknown Command "Unknown"`)
+}
+
+func TestGroupDeviceCommand(t *testing.T) {
+	g := initTestGroup()
+	g.Robots().Start()
+
+	results := g.Group("swarm").DeviceCommand("Device1", "DriverCommand", nil)
+	Assert(t, results, GroupResults{{Robot: "Robot1"}, {Robot: "Robot2"}})
+
+	results = g.Group("swarm").DeviceCommand("Device4", "DriverCommand", nil)
+	Assert(t, results[0].Err.Error(), "No Device found with the name Device4")
+}
+
+func TestGroupOn(t *testing.T) {
+	g := initTestGroup()
+	events := make(chan GroupEvent, 2)
+	sub := g.Group("swarm").On(StateEvent, func(e GroupEvent) {
+		events <- e
+	})
+
+	g.Robot("Robot2").setState(StateRunning)
+	select {
+	case e := <-events:
+		Assert(t, e, GroupEvent{Robot: "Robot2", Event: StateEvent, Data: StateRunning})
+	case <-time.After(time.Second):
+		t.Error("group event was not published")
+	}
+
+	sub.Unsubscribe()
+	g.Robot("Robot1").setState(StateRunning)
+	select {
+	case <-events:
+		t.Error("unsubscribed group event was published")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestGroupOnDevice(t *testing.T) {
+	g := initTestGroup()
+	for _, name := range []string{"Robot1", "Robot2"} {
+		eventer := NewEventer()
+		eventer.AddEvent("collision")
+		g.Robot(name).AddDevice(&testEventDriver{testDriver: testDriver{name: "D", Commander: NewCommander()}, Eventer: eventer})
+	}
+	events := make(chan GroupEvent, 2)
+	g.Group("swarm").OnDevice("D", "collision", func(e GroupEvent) {
+		events <- e
+	})
+
+	Publish(g.Robot("Robot1").Device("D").(Eventer).Event("collision"), 1)
+	select {
+	case e := <-events:
+		Assert(t, e, GroupEvent{Robot: "Robot1", Device: "D", Event: "collision", Data: 1})
+	case <-time.After(time.Second):
+		t.Error("group event was not published")
+	}
+}
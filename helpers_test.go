@@ -1,5 +1,7 @@
 package gobot
 
+import "sync"
+
 type NullReadWriteCloser struct{}
 
 func (NullReadWriteCloser) Write(p []byte) (int, error) {
@@ -80,3 +82,33 @@ func newTestRobot(name string) *Robot {
 
 	return r
 }
+
+type testLogRecord struct {
+	level  Level
+	msg    string
+	fields Fields
+}
+
+type testLogger struct {
+	mutex   sync.Mutex
+	records []testLogRecord
+}
+
+func (t *testLogger) Log(level Level, msg string, fields Fields) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.records = append(t.records, testLogRecord{level, msg, fields})
+}
+
+func (t *testLogger) Records() []testLogRecord {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]testLogRecord{}, t.records...)
+}
+
+type testLoggingDriver struct {
+	*testDriver
+	logger Logger
+}
+
+func (t *testLoggingDriver) SetLogger(l Logger) { t.logger = l }
@@ -0,0 +1,110 @@
+package gobot
+
+import "time"
+
+// Sample is a value written to an Event, with the time it was written at.
+type Sample struct {
+	Time time.Time   `json:"time"`
+	Data interface{} `json:"data"`
+}
+
+// SetHistory makes the Event keep the values subsequently written to it, with
+// the time they were written at, for History to return. At most size values
+// are kept, none of them older than age. A size or an age of 0 leaves that
+// limit out, both of 0 stop keeping values and discard those kept so far.
+func (e *Event) SetHistory(size int, age time.Duration) {
+	e.Lock()
+	defer e.Unlock()
+
+	previous := e.history
+	e.history = nil
+	if size <= 0 && age <= 0 {
+		return
+	}
+	e.history = &eventHistory{size: size, age: age}
+	if previous != nil {
+		for _, s := range previous.since(time.Time{}, Now()) {
+			e.history.add(s)
+		}
+	}
+}
+
+// History returns the values kept by the Event which were written after
+// since, oldest first. A zero since returns all of them. Returns nil if the
+// Event keeps no history.
+func (e *Event) History(since time.Time) []Sample {
+	e.Lock()
+	defer e.Unlock()
+	if e.history == nil {
+		return nil
+	}
+	return e.history.since(since, Now())
+}
+
+// eventHistory is a ring buffer of the Samples of an Event, limited in size,
+// in age or both.
+type eventHistory struct {
+	size    int
+	age     time.Duration
+	samples []Sample
+	// head is the index of the oldest of the count Samples kept.
+	head  int
+	count int
+}
+
+// add adds s, discarding the samples which are too old and the oldest sample
+// if the buffer is full.
+func (h *eventHistory) add(s Sample) {
+	h.expire(s.Time)
+	if h.size > 0 && h.count == h.size {
+		h.pop()
+	}
+	if h.count == len(h.samples) {
+		h.grow()
+	}
+	h.samples[(h.head+h.count)%len(h.samples)] = s
+	h.count++
+}
+
+// since returns the Samples written after t which are not too old at now.
+func (h *eventHistory) since(t time.Time, now time.Time) []Sample {
+	h.expire(now)
+	samples := []Sample{}
+	for i := 0; i < h.count; i++ {
+		if s := h.samples[(h.head+i)%len(h.samples)]; s.Time.After(t) {
+			samples = append(samples, s)
+		}
+	}
+	return samples
+}
+
+// expire discards the Samples older than the age of the buffer at now.
+func (h *eventHistory) expire(now time.Time) {
+	for h.age > 0 && h.count > 0 && now.Sub(h.samples[h.head].Time) > h.age {
+		h.pop()
+	}
+}
+
+// pop discards the oldest Sample.
+func (h *eventHistory) pop() {
+	h.samples[h.head] = Sample{}
+	h.head = (h.head + 1) % len(h.samples)
+	h.count--
+}
+
+// grow doubles the capacity of the buffer, up to its size.
+func (h *eventHistory) grow() {
+	n := 2 * len(h.samples)
+	if n == 0 {
+		n = 16
+	}
+	if h.size > 0 && n > h.size {
+		n = h.size
+	}
+	samples := make([]Sample, n)
+	for i := 0; i < h.count; i++ {
+		samples[i] = h.samples[(h.head+i)%len(h.samples)]
+	}
+	h.samples = samples
+	h.head = 0
+}
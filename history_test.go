@@ -0,0 +1,58 @@
+package gobot
+
+import (
+	"testing"
+	"time"
+)
+
+func historyData(samples []Sample) []interface{} {
+	data := []interface{}{}
+	for _, s := range samples {
+		data = append(data, s.Data)
+	}
+	return data
+}
+
+func TestEventHistorySize(t *testing.T) {
+	e := NewEvent()
+	Assert(t, e.History(time.Time{}), []Sample(nil))
+
+	e.Write(0)
+	e.SetHistory(20, 0)
+	for i := 1; i <= 50; i++ {
+		e.Write(i)
+	}
+	samples := e.History(time.Time{})
+	Assert(t, len(samples), 20)
+	Assert(t, samples[0].Data, 31)
+	Assert(t, samples[19].Data, 50)
+
+	// shrinking keeps the newest samples
+	e.SetHistory(3, 0)
+	Assert(t, historyData(e.History(time.Time{})), []interface{}{48, 49, 50})
+
+	e.SetHistory(0, 0)
+	Assert(t, e.History(time.Time{}), []Sample(nil))
+}
+
+func TestEventHistoryAge(t *testing.T) {
+	clock := &testClock{now: time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)}
+	defer SetClock(SetClock(clock))
+	start := clock.now
+
+	e := NewEvent()
+	e.SetHistory(0, 10*time.Second)
+	for i := 0; i < 40; i++ {
+		e.Write(i)
+		clock.now = clock.now.Add(time.Second)
+	}
+	samples := e.History(time.Time{})
+	Assert(t, historyData(samples), []interface{}{30, 31, 32, 33, 34, 35, 36, 37, 38, 39})
+	Assert(t, samples[0].Time, start.Add(30*time.Second))
+
+	Assert(t, historyData(e.History(start.Add(37*time.Second))), []interface{}{38, 39})
+
+	// samples expire without new writes
+	clock.now = clock.now.Add(9 * time.Second)
+	Assert(t, historyData(e.History(time.Time{})), []interface{}{39})
+}
@@ -0,0 +1,158 @@
+package gobot
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// AsyncCommand is a long-running command. It is called with a context which is
+// cancelled when its Job is cancelled, and a function reporting its progress
+// from 0 to 1.
+type AsyncCommand func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{}
+
+// JobState is the state of a Job.
+type JobState string
+
+const (
+	// JobRunning is the state of a Job whose command has not returned yet
+	JobRunning JobState = "running"
+	// JobSucceeded is the state of a Job whose command returned a result
+	JobSucceeded JobState = "succeeded"
+	// JobFailed is the state of a Job whose command returned an error or panicked
+	JobFailed JobState = "failed"
+	// JobCancelled is the state of a Job whose command returned after being cancelled
+	JobCancelled JobState = "cancelled"
+)
+
+// Job is an AsyncCommand running in the background.
+type Job struct {
+	id       string
+	command  string
+	cancel   context.CancelFunc
+	done     chan struct{}
+	mutex    sync.RWMutex
+	state    JobState
+	progress float64
+	result   interface{}
+	err      error
+	started  time.Time
+	finished time.Time
+}
+
+// JobStatus is a snapshot of the status of a Job.
+type JobStatus struct {
+	ID       string      `json:"id"`
+	Command  string      `json:"command"`
+	State    JobState    `json:"state"`
+	Progress float64     `json:"progress"`
+	Result   interface{} `json:"result,omitempty"`
+	Error    string      `json:"error,omitempty"`
+	Started  time.Time   `json:"started"`
+	Finished *time.Time  `json:"finished,omitempty"`
+}
+
+// StartJob calls f with params in a new goroutine and returns the Job tracking
+// it. `protect` runs f, recovering from its panics; a panic fails the Job.
+func StartJob(id, command string, f AsyncCommand, params map[string]interface{}, protect func(func()) error) *Job {
+	return StartJobContext(context.Background(), id, command, f, params, protect)
+}
+
+// StartJobContext is similar to StartJob except that the Job is also cancelled
+// once parent is done.
+func StartJobContext(parent context.Context, id, command string, f AsyncCommand, params map[string]interface{}, protect func(func()) error) *Job {
+	ctx, cancel := context.WithCancel(parent)
+	j := &Job{
+		id:      id,
+		command: command,
+		cancel:  cancel,
+		done:    make(chan struct{}),
+		state:   JobRunning,
+		started: Now(),
+	}
+
+	commandInvocations.With(command).Inc()
+	go func() {
+		defer close(j.done)
+		defer cancel()
+
+		var result interface{}
+		err := protect(func() { result = f(ctx, params, j.setProgress) })
+
+		j.mutex.Lock()
+		defer j.mutex.Unlock()
+		j.finished = Now()
+		if err == nil {
+			err, _ = result.(error)
+		}
+		switch {
+		case ctx.Err() != nil:
+			j.state, j.err = JobCancelled, ctx.Err()
+		case err != nil:
+			j.state, j.err = JobFailed, err
+			commandErrors.With(command).Inc()
+		default:
+			j.state, j.result, j.progress = JobSucceeded, result, 1
+		}
+	}()
+
+	return j
+}
+
+// ID returns the id of the Job
+func (j *Job) ID() string { return j.id }
+
+// Cancel cancels the context of the Job's command. The Job remains running
+// until the command returns.
+func (j *Job) Cancel() { j.cancel() }
+
+// Done returns a channel which is closed once the Job's command has returned.
+func (j *Job) Done() <-chan struct{} { return j.done }
+
+// Wait blocks until the Job's command has returned and returns its status.
+func (j *Job) Wait() JobStatus {
+	<-j.done
+	return j.Status()
+}
+
+// State returns the current state of the Job
+func (j *Job) State() JobState {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.state
+}
+
+// Status returns a snapshot of the status of the Job
+func (j *Job) Status() JobStatus {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	s := JobStatus{
+		ID:       j.id,
+		Command:  j.command,
+		State:    j.state,
+		Progress: j.progress,
+		Result:   j.result,
+		Started:  j.started,
+	}
+	if j.err != nil {
+		s.Error = j.err.Error()
+	}
+	if j.state != JobRunning {
+		finished := j.finished
+		s.Finished = &finished
+	}
+	return s
+}
+
+func (j *Job) setProgress(p float64) {
+	if p < 0 {
+		p = 0
+	} else if p > 1 {
+		p = 1
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	if j.state == JobRunning {
+		j.progress = p
+	}
+}
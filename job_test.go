@@ -0,0 +1,77 @@
+package gobot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestJobSucceeded(t *testing.T) {
+	j := StartJob("1", "test", func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		progress(0.5)
+		return params["value"]
+	}, map[string]interface{}{"value": 42}, Protect)
+
+	Assert(t, j.ID(), "1")
+	s := j.Wait()
+	Assert(t, s.State, JobSucceeded)
+	Assert(t, s.Command, "test")
+	Assert(t, s.Result, 42)
+	Assert(t, s.Progress, 1.0)
+	Refute(t, s.Finished, (*time.Time)(nil))
+}
+
+func TestJobProgress(t *testing.T) {
+	release := make(chan struct{})
+	reported := make(chan struct{})
+	j := StartJob("1", "test", func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		progress(2)
+		progress(0.25)
+		close(reported)
+		<-release
+		return nil
+	}, nil, Protect)
+
+	<-reported
+	s := j.Status()
+	Assert(t, s.State, JobRunning)
+	Assert(t, s.Progress, 0.25)
+	Assert(t, s.Finished, (*time.Time)(nil))
+	close(release)
+	j.Wait()
+}
+
+func TestJobFailed(t *testing.T) {
+	j := StartJob("1", "test", func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		return errors.New("failed")
+	}, nil, Protect)
+	s := j.Wait()
+	Assert(t, s.State, JobFailed)
+	Assert(t, s.Error, "failed")
+	Assert(t, s.Result, nil)
+
+	j = StartJob("2", "test", func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		panic("boom")
+	}, nil, Protect)
+	s = j.Wait()
+	Assert(t, s.State, JobFailed)
+	Assert(t, s.Error, "panic: boom")
+}
+
+func TestJobCancel(t *testing.T) {
+	j := StartJob("1", "test", func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		<-ctx.Done()
+		return ctx.Err()
+	}, nil, Protect)
+
+	select {
+	case <-j.Done():
+		t.Error("job should still be running")
+	default:
+	}
+	j.Cancel()
+	s := j.Wait()
+	Assert(t, s.State, JobCancelled)
+	Assert(t, s.Error, context.Canceled.Error())
+}
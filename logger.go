@@ -0,0 +1,255 @@
+package gobot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level is the severity of a log record.
+type Level int
+
+const (
+	// DebugLevel is the Level of detailed records for troubleshooting
+	DebugLevel Level = iota
+	// InfoLevel is the Level of records about the normal operation of robots
+	InfoLevel
+	// WarnLevel is the Level of records about unexpected but handled conditions
+	WarnLevel
+	// ErrorLevel is the Level of records about failures
+	ErrorLevel
+)
+
+// String returns the name of the Level.
+func (l Level) String() string {
+	switch l {
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	}
+	return fmt.Sprintf("level(%d)", int(l))
+}
+
+// Fields are the structured fields of a log record, such as the names of the
+// robot, connection, device and pin it concerns.
+type Fields map[string]interface{}
+
+// Logger writes log records. Implementations must be safe for concurrent use.
+type Logger interface {
+	Log(level Level, msg string, fields Fields)
+}
+
+// LoggerSetter is the interface that describes an adaptor or driver which
+// logs. Robots give it their Logger, with the fields naming the connection or
+// device, when it is added to them.
+type LoggerSetter interface {
+	SetLogger(l Logger)
+}
+
+var (
+	loggerMutex   sync.RWMutex
+	defaultLogger = NewTextLogger(nil)
+)
+
+// DefaultLogger returns the Logger used by Gobots and Robots which have none
+// set, and by code which belongs to no Robot.
+func DefaultLogger() Logger {
+	loggerMutex.RLock()
+	defer loggerMutex.RUnlock()
+	return defaultLogger
+}
+
+// SetDefaultLogger replaces the DefaultLogger. A nil Logger restores the
+// default, which writes text lines through the standard log package.
+func SetDefaultLogger(l Logger) {
+	if l == nil {
+		l = NewTextLogger(nil)
+	}
+	loggerMutex.Lock()
+	defer loggerMutex.Unlock()
+	defaultLogger = l
+}
+
+// WithFields returns a Logger adding fields to the records written to l. A nil
+// l writes to the DefaultLogger at the time of each record.
+func WithFields(l Logger, fields Fields) Logger {
+	return &fieldLogger{logger: l, fields: fields}
+}
+
+type fieldLogger struct {
+	logger Logger
+	fields Fields
+}
+
+func (f *fieldLogger) Log(level Level, msg string, fields Fields) {
+	merged := Fields{}
+	for k, v := range f.fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	l := f.logger
+	if l == nil {
+		l = DefaultLogger()
+	}
+	l.Log(level, msg, merged)
+}
+
+// Logger returns the Logger of the Gobot, or the DefaultLogger if it has
+// none.
+func (g *Gobot) Logger() Logger {
+	g.logMutex.RLock()
+	defer g.logMutex.RUnlock()
+	if g.logger == nil {
+		return DefaultLogger()
+	}
+	return g.logger
+}
+
+// SetLogger sets the Logger of the Gobot and of all its Robots. Robots added
+// later without a Logger of their own use it too.
+func (g *Gobot) SetLogger(l Logger) {
+	g.logMutex.Lock()
+	defer g.logMutex.Unlock()
+	g.logger = l
+	g.robots.Each(func(r *Robot) { r.SetLogger(l) })
+}
+
+// Logger returns a Logger adding the name of the Robot to the records written
+// to the Logger set by SetLogger, or to the DefaultLogger if there is none.
+// Connections and Devices implementing LoggerSetter are given such a Logger,
+// also naming them, when they are added to the Robot.
+func (r *Robot) Logger() Logger {
+	return WithFields(robotLogger{r}, Fields{"robot": r.Name})
+}
+
+// SetLogger sets the Logger of the Robot, its Connections and its Devices.
+func (r *Robot) SetLogger(l Logger) {
+	r.logMutex.Lock()
+	defer r.logMutex.Unlock()
+	r.logger = l
+}
+
+// robotLogger writes to the Logger of a Robot at the time of each record.
+type robotLogger struct {
+	robot *Robot
+}
+
+func (r robotLogger) Log(level Level, msg string, fields Fields) {
+	r.robot.logMutex.RLock()
+	l := r.robot.logger
+	r.robot.logMutex.RUnlock()
+	if l == nil {
+		l = DefaultLogger()
+	}
+	l.Log(level, msg, fields)
+}
+
+// MinLevel returns a Logger writing the records of at least level to l and
+// discarding the others.
+func MinLevel(l Logger, level Level) Logger {
+	return &levelLogger{logger: l, level: level}
+}
+
+type levelLogger struct {
+	logger Logger
+	level  Level
+}
+
+func (m *levelLogger) Log(level Level, msg string, fields Fields) {
+	if level >= m.level {
+		m.logger.Log(level, msg, fields)
+	}
+}
+
+// NewNullLogger returns a Logger discarding all records.
+func NewNullLogger() Logger {
+	return nullLogger{}
+}
+
+type nullLogger struct{}
+
+func (nullLogger) Log(Level, string, Fields) {}
+
+// NewTextLogger returns a Logger writing a line per record to l, or through
+// the standard log package if l is nil. Lines hold the message followed by
+// the fields as sorted key=value pairs. Records of any Level but InfoLevel are
+// prefixed with the Level.
+func NewTextLogger(l *log.Logger) Logger {
+	return &textLogger{logger: l}
+}
+
+type textLogger struct {
+	logger *log.Logger
+}
+
+func (t *textLogger) Log(level Level, msg string, fields Fields) {
+	line := msg
+	if level != InfoLevel {
+		line = strings.ToUpper(level.String()) + ": " + line
+	}
+	for _, k := range sortedKeys(fields) {
+		line += fmt.Sprintf(" %v=%v", k, fields[k])
+	}
+	if t.logger == nil {
+		log.Println(line)
+		return
+	}
+	t.logger.Println(line)
+}
+
+// NewJSONLogger returns a Logger writing a JSON object per record and line to
+// w. Objects hold the time, level and msg of the record and its fields.
+// Errors are written as their message.
+func NewJSONLogger(w io.Writer) Logger {
+	return &jsonLogger{w: w}
+}
+
+type jsonLogger struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+func (j *jsonLogger) Log(level Level, msg string, fields Fields) {
+	record := map[string]interface{}{}
+	for k, v := range fields {
+		if err, ok := v.(error); ok {
+			v = err.Error()
+		}
+		record[k] = v
+	}
+	record["time"] = Now().Format(time.RFC3339Nano)
+	record["level"] = level.String()
+	record["msg"] = msg
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		for k, v := range record {
+			record[k] = fmt.Sprint(v)
+		}
+		line, _ = json.Marshal(record)
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.w.Write(append(line, '\n'))
+}
+
+func sortedKeys(fields Fields) []string {
+	keys := []string{}
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
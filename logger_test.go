@@ -0,0 +1,135 @@
+package gobot
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLevel(t *testing.T) {
+	Assert(t, DebugLevel.String(), "debug")
+	Assert(t, InfoLevel.String(), "info")
+	Assert(t, WarnLevel.String(), "warn")
+	Assert(t, ErrorLevel.String(), "error")
+	Assert(t, Level(7).String(), "level(7)")
+}
+
+func TestTextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewTextLogger(log.New(&buf, "", 0))
+	l.Log(InfoLevel, "Starting Robot bot...", Fields{"robot": "bot", "device": "led"})
+	l.Log(ErrorLevel, "oops", nil)
+	Assert(t, buf.String(), "Starting Robot bot... device=led robot=bot\nERROR: oops\n")
+}
+
+func TestJSONLogger(t *testing.T) {
+	now := time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer SetClock(SetClock(testClock{now: now}))
+
+	var buf bytes.Buffer
+	l := NewJSONLogger(&buf)
+	l.Log(WarnLevel, "disconnected", Fields{"robot": "bot", "error": errors.New("EOF"), "attempt": 2})
+	l.Log(InfoLevel, "bad", Fields{"f": func() {}})
+	lines := strings.Split(buf.String(), "\n")
+	Assert(t, len(lines), 3)
+	Assert(t, lines[0], `{"attempt":2,"error":"EOF","level":"warn","msg":"disconnected","robot":"bot","time":"2015-01-01T00:00:00Z"}`)
+	// fields which cannot be encoded are written as strings
+	Assert(t, strings.HasPrefix(lines[1], `{"f":"0x`), true)
+	Assert(t, strings.HasSuffix(lines[1], `"level":"info","msg":"bad","time":"2015-01-01T00:00:00Z"}`), true)
+}
+
+func TestWithFields(t *testing.T) {
+	l := &testLogger{}
+	WithFields(WithFields(l, Fields{"robot": "bot"}), Fields{"device": "led"}).
+		Log(InfoLevel, "msg", Fields{"pin": "13", "device": "button"})
+	Assert(t, l.Records(), []testLogRecord{
+		{InfoLevel, "msg", Fields{"robot": "bot", "device": "button", "pin": "13"}},
+	})
+
+	previous := DefaultLogger()
+	defer SetDefaultLogger(previous)
+	SetDefaultLogger(l)
+	WithFields(nil, nil).Log(DebugLevel, "default", nil)
+	Assert(t, len(l.Records()), 2)
+	SetDefaultLogger(nil)
+	Refute(t, DefaultLogger(), Logger(l))
+}
+
+func TestMinLevel(t *testing.T) {
+	l := &testLogger{}
+	m := MinLevel(l, WarnLevel)
+	m.Log(InfoLevel, "info", nil)
+	m.Log(WarnLevel, "warn", nil)
+	m.Log(ErrorLevel, "error", nil)
+	Assert(t, len(l.Records()), 2)
+	Assert(t, l.Records()[0].msg, "warn")
+
+	NewNullLogger().Log(ErrorLevel, "nothing", nil)
+}
+
+func TestRobotLogger(t *testing.T) {
+	l := &testLogger{}
+	adaptor := newTestAdaptor("Connection1", "/dev/null")
+	driver := &testLoggingDriver{testDriver: newTestDriver(adaptor, "Device1", "13")}
+	r := NewRobot("Robot1", []Connection{adaptor}, []Device{driver})
+
+	g := NewGobot()
+	g.AddRobot(r)
+	g.SetLogger(l)
+	Assert(t, g.Logger(), Logger(l))
+
+	r.Logger().Log(InfoLevel, "robot", nil)
+	driver.logger.Log(WarnLevel, "driver", Fields{"value": 1})
+	Assert(t, l.Records(), []testLogRecord{
+		{InfoLevel, "robot", Fields{"robot": "Robot1"}},
+		{WarnLevel, "driver", Fields{"robot": "Robot1", "device": "Device1", "pin": "13", "connection": "Connection1", "value": 1}},
+	})
+
+	own := &testLogger{}
+	other := NewRobot("Robot2")
+	other.SetLogger(own)
+	g.AddRobot(other)
+	other.Logger().Log(InfoLevel, "own", nil)
+	Assert(t, len(own.Records()), 1)
+
+	late := NewRobot("Robot3")
+	g.AddRobot(late)
+	late.Logger().Log(InfoLevel, "inherited", nil)
+	Assert(t, len(l.Records()), 3)
+}
+
+func TestGobotSetLoggerConcurrently(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	g := NewGobot()
+	robots := make(chan *Robot, 10)
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			g.SetLogger(&testLogger{})
+		}()
+		go func() {
+			defer wg.Done()
+			robots <- g.AddRobot(NewRobot(""))
+		}()
+		go func() {
+			defer wg.Done()
+			g.Logger()
+		}()
+	}
+	wg.Wait()
+	close(robots)
+
+	l := &testLogger{}
+	g.SetLogger(l)
+	for r := range robots {
+		r.Logger().Log(InfoLevel, "robot", nil)
+	}
+	Assert(t, len(l.Records()), 10)
+}
+
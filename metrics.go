@@ -0,0 +1,372 @@
+package gobot
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the upper bounds of the buckets of Histograms measuring
+// durations in seconds.
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// DefaultMetrics holds the metrics gobot records about itself.
+var DefaultMetrics = NewMetrics()
+
+var (
+	eventsPublished = DefaultMetrics.Counter("gobot_events_published_total",
+		"Data published on events.", "event")
+	callbackDuration = DefaultMetrics.Histogram("gobot_event_callback_duration_seconds",
+		"Time spent running event callbacks.", DefaultBuckets, "event")
+	commandInvocations = DefaultMetrics.Counter("gobot_command_invocations_total",
+		"Commands called, including the commands started as jobs.", "command")
+	commandErrors = DefaultMetrics.Counter("gobot_command_errors_total",
+		"Commands which returned an error, panicked or failed as a job.", "command")
+	connectionUp = DefaultMetrics.Gauge("gobot_connection_up",
+		"Whether a connection of a running robot is connected (1) or not (0).", "robot", "connection")
+	connectionReconnects = DefaultMetrics.Counter("gobot_connection_reconnects_total",
+		"Connections reconnected after they failed.", "robot", "connection")
+	bytesTransferred = DefaultMetrics.Counter("gobot_bytes_transferred_total",
+		"Bytes read from (in) and written to (out) I2C and serial buses.", "bus", "connection", "direction")
+)
+
+// Metrics is a registry of metrics by name, which writes them in the
+// Prometheus text exposition format. Each metric has a fixed set of label
+// names, and a Counter, Gauge or Histogram for each combination of label
+// values.
+type Metrics struct {
+	mutex    sync.Mutex
+	families map[string]*family
+}
+
+// NewMetrics returns a new, empty registry of metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{families: make(map[string]*family)}
+}
+
+// CounterVec is a Counter metric of Metrics, with a Counter for each
+// combination of label values.
+type CounterVec struct{ family *family }
+
+// GaugeVec is a Gauge metric of Metrics, with a Gauge for each combination
+// of label values.
+type GaugeVec struct{ family *family }
+
+// HistogramVec is a Histogram metric of Metrics, with a Histogram for each
+// combination of label values.
+type HistogramVec struct{ family *family }
+
+// Counter returns the counter metric name, adding it with help and labels if
+// it does not exist yet. Panics if name is taken by a metric of another type.
+func (r *Metrics) Counter(name, help string, labels ...string) *CounterVec {
+	return &CounterVec{r.family(name, help, "counter", nil, labels)}
+}
+
+// Gauge returns the gauge metric name, adding it with help and labels if it
+// does not exist yet. Panics if name is taken by a metric of another type.
+func (r *Metrics) Gauge(name, help string, labels ...string) *GaugeVec {
+	return &GaugeVec{r.family(name, help, "gauge", nil, labels)}
+}
+
+// Histogram returns the histogram metric name, adding it with help, the upper
+// bounds of its buckets and labels if it does not exist yet. Panics if name is
+// taken by a metric of another type.
+func (r *Metrics) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	return &HistogramVec{r.family(name, help, "histogram", buckets, labels)}
+}
+
+// With returns the Counter of the label values, in the order of the label
+// names of the metric.
+func (v *CounterVec) With(values ...string) *Counter {
+	return v.family.series(values).(*Counter)
+}
+
+// With returns the Gauge of the label values, in the order of the label names
+// of the metric.
+func (v *GaugeVec) With(values ...string) *Gauge {
+	return v.family.series(values).(*Gauge)
+}
+
+// With returns the Histogram of the label values, in the order of the label
+// names of the metric.
+func (v *HistogramVec) With(values ...string) *Histogram {
+	return v.family.series(values).(*Histogram)
+}
+
+// WriteText writes all metrics to w in the Prometheus text exposition format.
+func (r *Metrics) WriteText(w io.Writer) (err error) {
+	r.mutex.Lock()
+	families := []*family{}
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mutex.Unlock()
+
+	sort.Slice(families, func(i, j int) bool { return families[i].name < families[j].name })
+	for _, f := range families {
+		if err = f.write(w); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// BytesRead counts n bytes read over bus, such as "i2c" or "serial", by a
+// Connection.
+func BytesRead(bus, connection string, n int) {
+	if n > 0 {
+		bytesTransferred.With(bus, connection, "in").Add(float64(n))
+	}
+}
+
+// BytesWritten counts n bytes written over bus, such as "i2c" or "serial", by
+// a Connection.
+func BytesWritten(bus, connection string, n int) {
+	if n > 0 {
+		bytesTransferred.With(bus, connection, "out").Add(float64(n))
+	}
+}
+
+// MeterReadWriteCloser returns rwc, counting the bytes read and written
+// through it over bus by a Connection.
+func MeterReadWriteCloser(rwc io.ReadWriteCloser, bus, connection string) io.ReadWriteCloser {
+	return &meteredReadWriteCloser{ReadWriteCloser: rwc, bus: bus, connection: connection}
+}
+
+type meteredReadWriteCloser struct {
+	io.ReadWriteCloser
+	bus        string
+	connection string
+}
+
+func (m *meteredReadWriteCloser) Read(b []byte) (n int, err error) {
+	n, err = m.ReadWriteCloser.Read(b)
+	BytesRead(m.bus, m.connection, n)
+	return
+}
+
+func (m *meteredReadWriteCloser) Write(b []byte) (n int, err error) {
+	n, err = m.ReadWriteCloser.Write(b)
+	BytesWritten(m.bus, m.connection, n)
+	return
+}
+
+func (r *Metrics) family(name, help, kind string, buckets []float64, labels []string) *family {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if f, ok := r.families[name]; ok {
+		if f.kind != kind {
+			panic(fmt.Sprintf("metric %v is a %v, not a %v", name, f.kind, kind))
+		}
+		return f
+	}
+	f := &family{
+		name:    name,
+		help:    help,
+		kind:    kind,
+		labels:  labels,
+		buckets: buckets,
+		metrics: make(map[string]interface{}),
+		values:  make(map[string][]string),
+	}
+	r.families[name] = f
+	return f
+}
+
+// Counter is a value which only goes up, such as a number of calls.
+type Counter struct {
+	mutex sync.Mutex
+	value float64
+}
+
+// Inc adds 1 to the Counter.
+func (c *Counter) Inc() { c.Add(1) }
+
+// Add adds v, which must not be negative, to the Counter.
+func (c *Counter) Add(v float64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.value += v
+}
+
+// Value returns the value of the Counter.
+func (c *Counter) Value() float64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.value
+}
+
+// Gauge is a value which goes up and down, such as a state.
+type Gauge struct {
+	mutex sync.Mutex
+	value float64
+}
+
+// Set sets the Gauge to v.
+func (g *Gauge) Set(v float64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.value = v
+}
+
+// Add adds v to the Gauge.
+func (g *Gauge) Add(v float64) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.value += v
+}
+
+// Value returns the value of the Gauge.
+func (g *Gauge) Value() float64 {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	return g.value
+}
+
+// Histogram counts observed values, such as durations, in buckets.
+type Histogram struct {
+	mutex   sync.Mutex
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+// Observe adds v to the Histogram.
+func (h *Histogram) Observe(v float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	for i, bound := range h.buckets {
+		if v <= bound {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+// Count returns the number of values observed.
+func (h *Histogram) Count() uint64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.count
+}
+
+// Sum returns the sum of the values observed.
+func (h *Histogram) Sum() float64 {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+	return h.sum
+}
+
+// family is a metric of Metrics with its series by label values.
+type family struct {
+	name    string
+	help    string
+	kind    string
+	labels  []string
+	buckets []float64
+	mutex   sync.Mutex
+	metrics map[string]interface{}
+	values  map[string][]string
+}
+
+func (f *family) series(values []string) interface{} {
+	if len(values) != len(f.labels) {
+		panic(fmt.Sprintf("metric %v has labels %v, got values %v", f.name, f.labels, values))
+	}
+	key := strings.Join(values, "\xff")
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	if m, ok := f.metrics[key]; ok {
+		return m
+	}
+	var m interface{}
+	switch f.kind {
+	case "counter":
+		m = &Counter{}
+	case "gauge":
+		m = &Gauge{}
+	case "histogram":
+		m = &Histogram{buckets: f.buckets, counts: make([]uint64, len(f.buckets))}
+	}
+	f.metrics[key] = m
+	f.values[key] = append([]string{}, values...)
+	return m
+}
+
+func (f *family) write(w io.Writer) (err error) {
+	f.mutex.Lock()
+	keys := []string{}
+	for key := range f.metrics {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	lines := []string{
+		"# HELP " + f.name + " " + escapeHelp(f.help),
+		"# TYPE " + f.name + " " + f.kind,
+	}
+	for _, key := range keys {
+		labels := f.values[key]
+		switch m := f.metrics[key].(type) {
+		case *Counter:
+			lines = append(lines, f.name+f.labelSet(labels)+" "+formatValue(m.Value()))
+		case *Gauge:
+			lines = append(lines, f.name+f.labelSet(labels)+" "+formatValue(m.Value()))
+		case *Histogram:
+			m.mutex.Lock()
+			for i, bound := range m.buckets {
+				lines = append(lines, f.name+"_bucket"+f.labelSet(labels, "le", formatValue(bound))+
+					" "+strconv.FormatUint(m.counts[i], 10))
+			}
+			lines = append(lines,
+				f.name+"_bucket"+f.labelSet(labels, "le", "+Inf")+" "+strconv.FormatUint(m.count, 10),
+				f.name+"_sum"+f.labelSet(labels)+" "+formatValue(m.sum),
+				f.name+"_count"+f.labelSet(labels)+" "+strconv.FormatUint(m.count, 10),
+			)
+			m.mutex.Unlock()
+		}
+	}
+	f.mutex.Unlock()
+
+	_, err = io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return
+}
+
+// labelSet returns the label pairs of a series, followed by the extra name and
+// value pairs, as written in the text exposition format.
+func (f *family) labelSet(values []string, extra ...string) string {
+	pairs := []string{}
+	for i, name := range f.labels {
+		pairs = append(pairs, name+"=\""+escapeLabel(values[i])+"\"")
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		pairs = append(pairs, extra[i]+"=\""+escapeLabel(extra[i+1])+"\"")
+	}
+	if len(pairs) == 0 {
+		return ""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,129 @@
+package gobot
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetrics(t *testing.T) {
+	r := NewMetrics()
+	c := r.Counter("requests_total", "Requests.", "path")
+	c.With("/a").Inc()
+	c.With("/a").Add(2)
+	c.With(`"b"`).Inc()
+	Assert(t, r.Counter("requests_total", "", "path").With("/a").Value(), 3.0)
+
+	g := r.Gauge("up", "Up or\ndown.")
+	g.With().Set(1)
+	g.With().Add(-0.5)
+	Assert(t, g.With().Value(), 0.5)
+
+	h := r.Histogram("duration_seconds", "Durations.", []float64{0.1, 1}, "op")
+	h.With("read").Observe(0.05)
+	h.With("read").Observe(0.5)
+	h.With("read").Observe(5)
+	Assert(t, h.With("read").Count(), uint64(3))
+	Assert(t, h.With("read").Sum(), 5.55)
+
+	var buf bytes.Buffer
+	Assert(t, r.WriteText(&buf), nil)
+	Assert(t, buf.String(), `# HELP duration_seconds Durations.
+# TYPE duration_seconds histogram
+duration_seconds_bucket{op="read",le="0.1"} 1
+duration_seconds_bucket{op="read",le="1"} 2
+duration_seconds_bucket{op="read",le="+Inf"} 3
+duration_seconds_sum{op="read"} 5.55
+duration_seconds_count{op="read"} 3
+# HELP requests_total Requests.
+# TYPE requests_total counter
+requests_total{path="\"b\""} 1
+requests_total{path="/a"} 3
+# HELP up Up or\ndown.
+# TYPE up gauge
+up 0.5
+`)
+}
+
+func TestMetricsMisuse(t *testing.T) {
+	r := NewMetrics()
+	r.Counter("total", "Total.", "a")
+
+	defer func() {
+		Refute(t, recover(), nil)
+	}()
+	r.Gauge("total", "Total.")
+}
+
+func TestEventMetrics(t *testing.T) {
+	e := NewEventer()
+	e.AddEvent("metrics-test")
+	published := eventsPublished.With("metrics-test")
+	duration := callbackDuration.With("metrics-test")
+	value, count := published.Value(), duration.Count()
+
+	done := make(chan bool)
+	On(e.Event("metrics-test"), func(data interface{}) { done <- true })
+	Publish(e.Event("metrics-test"), 1)
+	<-done
+	Assert(t, published.Value(), value+1)
+	// the duration is observed once the callback has returned
+	for i := 0; i < 100 && duration.Count() == count; i++ {
+		time.Sleep(time.Millisecond)
+	}
+	Assert(t, duration.Count(), count+1)
+}
+
+func TestUnnamedEventMetrics(t *testing.T) {
+	e := NewEvent()
+	done := make(chan bool)
+	On(e, func(data interface{}) { done <- true })
+	Publish(e, 1)
+	<-done
+
+	var buf bytes.Buffer
+	Assert(t, DefaultMetrics.WriteText(&buf), nil)
+	Assert(t, strings.Contains(buf.String(), `event=""`), false)
+}
+
+func TestCommandMetrics(t *testing.T) {
+	c := NewCommander()
+	c.AddCommand("metrics-ok", func(map[string]interface{}) interface{} { return nil })
+	c.AddCommand("metrics-error", func(map[string]interface{}) interface{} { return errors.New("failed") })
+	c.AddCommand("metrics-panic", func(map[string]interface{}) interface{} { panic("oops") })
+
+	c.Command("metrics-ok")(nil)
+	c.Command("metrics-error")(nil)
+	Protect(func() { c.Command("metrics-panic")(nil) })
+
+	Assert(t, commandInvocations.With("metrics-ok").Value(), 1.0)
+	Assert(t, commandErrors.With("metrics-ok").Value(), 0.0)
+	Assert(t, commandInvocations.With("metrics-error").Value(), 1.0)
+	Assert(t, commandErrors.With("metrics-error").Value(), 1.0)
+	Assert(t, commandErrors.With("metrics-panic").Value(), 1.0)
+}
+
+func TestConnectionMetrics(t *testing.T) {
+	r := NewRobot("MetricsRobot", []Connection{newTestAdaptor("Connection1", "/dev/null")})
+	up := connectionUp.With("MetricsRobot", "Connection1")
+
+	Assert(t, len(r.Start()), 0)
+	Assert(t, up.Value(), 1.0)
+	Assert(t, len(r.Stop()), 0)
+	Assert(t, up.Value(), 0.0)
+}
+
+func TestMeterReadWriteCloser(t *testing.T) {
+	rwc := MeterReadWriteCloser(NullReadWriteCloser{}, "serial", "metrics-port")
+	rwc.Write([]byte{1, 2, 3})
+	rwc.Read(make([]byte, 2))
+	BytesWritten("i2c", "metrics-port", 4)
+	BytesRead("i2c", "metrics-port", 0)
+
+	Assert(t, bytesTransferred.With("serial", "metrics-port", "out").Value(), 3.0)
+	Assert(t, bytesTransferred.With("serial", "metrics-port", "in").Value(), 2.0)
+	Assert(t, bytesTransferred.With("i2c", "metrics-port", "out").Value(), 4.0)
+	Assert(t, bytesTransferred.With("i2c", "metrics-port", "in").Value(), 0.0)
+}
@@ -0,0 +1,116 @@
+package gobot
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+)
+
+// ErrorEvent is the Robot event published with a *PanicError whenever its
+// work, one of its event callbacks or one of its commands panics
+const ErrorEvent = "error"
+
+// PanicError is the error a recovered panic is turned into.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value interface{}
+	// Stack is the stack trace of the panicking goroutine.
+	Stack []byte
+}
+
+func (p *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", p.Value)
+}
+
+// PanicPolicy decides what happens to a Robot after a panic was recovered.
+type PanicPolicy int
+
+const (
+	// PanicContinue leaves the Robot running.
+	PanicContinue PanicPolicy = iota
+	// PanicStop stops the Robot.
+	PanicStop
+	// PanicRestart stops the Robot and starts it again after the delay of its
+	// Backoff, up to its MaxRestarts times.
+	PanicRestart
+)
+
+// DefaultMaxRestarts is the MaxRestarts of a new Robot.
+var DefaultMaxRestarts = 5
+
+// Protect runs f and returns a *PanicError if it panics instead of letting the
+// panic crash the process.
+func Protect(f func()) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	f()
+	return
+}
+
+// Protect runs f and returns a *PanicError if it panics. The panic is also
+// published on the Robot's ErrorEvent and handled according to its PanicPolicy.
+func (r *Robot) Protect(f func()) (err error) {
+	if err = Protect(f); err != nil {
+		r.panicked(err.(*PanicError))
+	}
+	return
+}
+
+// panicked publishes a recovered panic and applies the Robot's PanicPolicy.
+func (r *Robot) panicked(err *PanicError) {
+	r.Logger().Log(ErrorLevel, fmt.Sprintf("Recovered from %v", err), Fields{"stack": string(err.Stack)})
+	Publish(r.Event(ErrorEvent), err)
+	r.SafeState(SafeStatePanic)
+
+	switch r.PanicPolicy {
+	case PanicStop:
+		go r.Stop()
+	case PanicRestart:
+		r.restart()
+	}
+}
+
+// restart stops the Robot and starts it again after the delay of its Backoff,
+// unless it was restarted MaxRestarts times already or is stopped meanwhile.
+func (r *Robot) restart() {
+	r.mutex.Lock()
+	r.restarts++
+	attempt := r.restarts
+	r.mutex.Unlock()
+	if r.MaxRestarts > 0 && attempt > r.MaxRestarts {
+		r.Logger().Log(ErrorLevel, fmt.Sprintf("Giving up after %v restarts", r.MaxRestarts), nil)
+		go r.Stop()
+		return
+	}
+	go func() {
+		r.stop(context.Background())
+		<-Wait(r.Backoff.Delay(attempt))
+		r.mutex.RLock()
+		stopped := r.restarts != attempt
+		r.mutex.RUnlock()
+		if stopped {
+			return
+		}
+		if errs := r.Start(); len(errs) > 0 {
+			r.Logger().Log(ErrorLevel, fmt.Sprintf("Restart failed: %v", errs), nil)
+		}
+	}()
+}
+
+// guardEvents reports the panics of the callbacks of all events of e to the Robot.
+func (r *Robot) guardEvents(e interface{}) {
+	eventer, ok := e.(Eventer)
+	if !ok {
+		return
+	}
+	for _, event := range eventer.Events() {
+		if event == r.Event(ErrorEvent) {
+			// a panicking error callback must not publish another error
+			continue
+		}
+		event.OnPanic(func(err *PanicError) { r.panicked(err) })
+	}
+}
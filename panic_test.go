@@ -0,0 +1,175 @@
+package gobot
+
+import (
+	"log"
+	"strings"
+	"testing"
+	"time"
+)
+
+func waitPanicError(t *testing.T, errs chan *PanicError) *PanicError {
+	select {
+	case err := <-errs:
+		return err
+	case <-time.After(time.Second):
+		t.Fatal("error was not published")
+	}
+	return nil
+}
+
+func TestProtect(t *testing.T) {
+	Assert(t, Protect(func() {}), nil)
+
+	err := Protect(func() { panic("boom") })
+	Assert(t, err.Error(), "panic: boom")
+	Assert(t, err.(*PanicError).Value, "boom")
+	Assert(t, strings.Contains(string(err.(*PanicError).Stack), "TestProtect"), true)
+}
+
+func TestRobotWorkPanic(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	calls := []string{}
+	r := newTestRecordingRobot("R", &calls)
+	r.Work = func() { panic("work") }
+
+	errs := make(chan *PanicError, 1)
+	On(r.Event(ErrorEvent), func(data interface{}) {
+		errs <- data.(*PanicError)
+	})
+
+	Assert(t, len(r.Start()), 0)
+	Assert(t, waitPanicError(t, errs).Value, "work")
+	Assert(t, r.State(), StateRunning)
+	r.Stop()
+}
+
+func TestRobotCallbackPanicStop(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := NewRobot("R")
+	r.PanicPolicy = PanicStop
+	e := NewEvent()
+	eventer := NewEventer()
+	eventer.RegisterEvent("data", e)
+	d := &testEventDriver{testDriver: testDriver{name: "D", Commander: NewCommander()}, Eventer: eventer}
+	r.AddDevice(d)
+
+	errs := make(chan *PanicError, 1)
+	On(r.Event(ErrorEvent), func(data interface{}) {
+		errs <- data.(*PanicError)
+	})
+	stopped := make(chan bool, 1)
+	On(r.Event(StateEvent), func(data interface{}) {
+		if data.(State) == StateStopped {
+			stopped <- true
+		}
+	})
+
+	Assert(t, len(r.Start()), 0)
+	On(e, func(data interface{}) { panic(data) })
+	Publish(e, "callback")
+
+	Assert(t, waitPanicError(t, errs).Value, "callback")
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("robot was not stopped")
+	}
+}
+
+func TestRobotPanicRestart(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := NewRobot("R")
+	r.PanicPolicy = PanicRestart
+	r.Backoff = Backoff{Initial: time.Millisecond}
+	starts := make(chan bool, 2)
+	r.Work = func() {
+		starts <- true
+		if len(starts) == 1 {
+			panic("first start")
+		}
+	}
+
+	Assert(t, len(r.Start()), 0)
+	for i := 0; i < 2; i++ {
+		select {
+		case <-starts:
+		case <-time.After(time.Second):
+			t.Fatal("robot was not restarted")
+		}
+	}
+}
+
+func TestRobotPanicRestartGivesUp(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := NewRobot("R")
+	r.PanicPolicy = PanicRestart
+	r.MaxRestarts = 2
+	r.Backoff = Backoff{Initial: time.Millisecond}
+	starts := make(chan bool, 4)
+	stopped := make(chan bool, 4)
+	r.Work = func() {
+		starts <- true
+		panic("start")
+	}
+	On(r.Event(StateEvent), func(data interface{}) {
+		if data.(State) == StateStopped {
+			stopped <- true
+		}
+	})
+
+	Assert(t, len(r.Start()), 0)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-stopped:
+		case <-time.After(time.Second):
+			t.Fatal("robot was not stopped")
+		}
+	}
+	<-time.After(10 * time.Millisecond)
+	Assert(t, len(starts), 3)
+	Assert(t, r.State(), StateStopped)
+}
+
+func TestRobotPanicRestartStopped(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := NewRobot("R")
+	r.PanicPolicy = PanicRestart
+	r.Backoff = Backoff{Initial: 10 * time.Millisecond}
+	starts := make(chan bool, 2)
+	r.Work = func() {
+		starts <- true
+		panic("start")
+	}
+
+	Assert(t, len(r.Start()), 0)
+	<-time.After(2 * time.Millisecond)
+	r.Stop()
+	<-time.After(20 * time.Millisecond)
+	Assert(t, len(starts), 1)
+	Assert(t, r.State(), StateStopped)
+}
+
+func TestEventCallbackPanic(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	e := NewEvent()
+	done := make(chan bool)
+	On(e, func(data interface{}) { panic(data) })
+	OnQueued(e, 1, Block, func(data interface{}) {
+		defer func() { done <- true }()
+		panic(data)
+	})
+	Publish(e, "unhandled")
+	<-done
+
+	// the queue survives the panic
+	Publish(e, "again")
+	<-done
+}
+
+type testEventDriver struct {
+	testDriver
+	Eventer
+}
+
+func (t *testEventDriver) Start() (errs []error) { return }
+func (t *testEventDriver) Halt() (errs []error)  { return }
@@ -5,6 +5,7 @@ import (
 )
 
 var _ gobot.Driver = (*ArdroneDriver)(nil)
+var _ gobot.SafeStater = (*ArdroneDriver)(nil)
 
 // ArdroneDriver is gobot.Driver representation for the Ardrone
 type ArdroneDriver struct {
@@ -48,6 +49,14 @@ func (a *ArdroneDriver) Halt() (errs []error) {
 	return
 }
 
+// SafeState implements the gobot.SafeStater interface by making the drone
+// hover in place and land
+func (a *ArdroneDriver) SafeState() (errs []error) {
+	a.adaptor().drone.Hover()
+	a.adaptor().drone.Land()
+	return
+}
+
 // TakeOff makes the drone start flying, and publishes `flying` event
 func (a *ArdroneDriver) TakeOff() {
 	gobot.Publish(a.Event("flying"), a.adaptor().drone.Takeoff())
@@ -85,3 +85,8 @@ func TestArdroneDriverHover(t *testing.T) {
 	d := initTestArdroneDriver()
 	d.Hover()
 }
+
+func TestArdroneDriverSafeState(t *testing.T) {
+	d := initTestArdroneDriver()
+	gobot.Assert(t, len(d.SafeState()), 0)
+}
@@ -276,7 +276,8 @@ func (b *BeagleboneAdaptor) I2cWrite(address int, data []byte) (err error) {
 	if err = b.i2cDevice.SetAddress(address); err != nil {
 		return
 	}
-	_, err = b.i2cDevice.Write(data)
+	n, err := b.i2cDevice.Write(data)
+	gobot.BytesWritten("i2c", b.Name(), n)
 	return
 }
 
@@ -286,7 +287,8 @@ func (b *BeagleboneAdaptor) I2cRead(address int, size int) (data []byte, err err
 		return
 	}
 	data = make([]byte, size)
-	_, err = b.i2cDevice.Read(data)
+	n, err := b.i2cDevice.Read(data)
+	gobot.BytesRead("i2c", b.Name(), n)
 	return
 }
 
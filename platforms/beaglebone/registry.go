@@ -0,0 +1,9 @@
+package beaglebone
+
+import "github.com/hybridgroup/gobot"
+
+func init() {
+	gobot.RegisterAdaptor("beaglebone", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		return NewBeagleboneAdaptor(s.Name), nil
+	})
+}
@@ -50,6 +50,13 @@ func NewBebopAdaptor(name string) *BebopAdaptor {
 	}
 }
 
+// SetLogger sets the Logger of the drone client
+func (a *BebopAdaptor) SetLogger(l gobot.Logger) {
+	if setter, ok := a.drone.(gobot.LoggerSetter); ok {
+		setter.SetLogger(l)
+	}
+}
+
 // Name returns the BebopAdaptors Name
 func (a *BebopAdaptor) Name() string { return a.name }
 
@@ -7,7 +7,8 @@ import (
 
 var (
 	// TODO: Documentation as to what this achieves would be nice..
-	_ gobot.Driver = (*Driver)(nil)
+	_ gobot.Driver     = (*Driver)(nil)
+	_ gobot.SafeStater = (*Driver)(nil)
 )
 
 // Driver is gobot.Driver representation for the Bebop
@@ -114,6 +115,18 @@ func (a *Driver) Halt() (errs []error) {
 	return
 }
 
+// SafeState implements the gobot.SafeStater interface by making the drone
+// hover in place and land
+func (a *Driver) SafeState() (errs []error) {
+	if err := a.adaptor().drone.Stop(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := a.adaptor().drone.Land(); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
+
 // TakeOff makes the drone start flying
 func (a *Driver) TakeOff() {
 	a.adaptor().drone.TakeOff()
@@ -5,8 +5,10 @@ import (
 	"encoding/binary"
 	"fmt"
 	"net"
+	"sync"
 	"time"
 
+	"github.com/hybridgroup/gobot"
 	"github.com/hybridgroup/gobot/platforms/bebop/bbtelem"
 )
 
@@ -181,6 +183,8 @@ type Bebop struct {
 	telemetry             chan bbtelem.TelemetryPacket
 	telemetryHandlers     map[byte]map[byte]telemHandler
 	endTelemetry          chan struct{}
+	logger                gobot.Logger
+	logMutex              sync.RWMutex
 }
 
 // New returns a Bebop client object.
@@ -206,11 +210,28 @@ func New() *Bebop {
 		telemetry:         make(chan bbtelem.TelemetryPacket),
 		telemetryHandlers: make(map[byte]map[byte]telemHandler),
 		endTelemetry:      make(chan struct{}),
+		logger:            gobot.WithFields(nil, nil),
 	}
 	b.populateTelemetryHandlers()
 	return &b
 }
 
+// SetLogger sets the Logger network errors are logged to. It may be called
+// while the client is connected.
+func (b *Bebop) SetLogger(l gobot.Logger) {
+	b.logMutex.Lock()
+	defer b.logMutex.Unlock()
+	b.logger = l
+}
+
+// log logs an error to the Logger of the client.
+func (b *Bebop) log(msg string) {
+	b.logMutex.RLock()
+	l := b.logger
+	b.logMutex.RUnlock()
+	l.Log(gobot.ErrorLevel, msg, nil)
+}
+
 func (b *Bebop) write(buf []byte) (int, error) {
 	b.writeChan <- buf
 	return 0, nil
@@ -286,7 +307,7 @@ func (b *Bebop) Connect() error {
 			_, err := b.c2dClient.Write(<-b.writeChan)
 
 			if err != nil {
-				fmt.Println(err)
+				b.log("c2dClient error: " + err.Error())
 			}
 		}
 	}()
@@ -296,7 +317,7 @@ func (b *Bebop) Connect() error {
 			data := make([]byte, 40960)
 			i, _, err := b.d2cClient.ReadFromUDP(data)
 			if err != nil {
-				fmt.Println("d2cClient error:", err)
+				b.log("d2cClient error: " + err.Error())
 			}
 
 			b.packetReceiver(data[0:i])
@@ -310,7 +331,7 @@ func (b *Bebop) Connect() error {
 		for {
 			_, err := b.write(b.generatePcmd().Bytes())
 			if err != nil {
-				fmt.Println("pcmd c2dClient.Write", err)
+				b.log("pcmd c2dClient.Write: " + err.Error())
 			}
 			<-time.After(25 * time.Millisecond)
 		}
@@ -551,7 +572,7 @@ func (b *Bebop) packetReceiver(buf []byte) {
 		_, err := b.write(ack)
 
 		if err != nil {
-			fmt.Println("ARNETWORKAL_FRAME_TYPE_DATA_WITH_ACK", err)
+			b.log("ARNETWORKAL_FRAME_TYPE_DATA_WITH_ACK: " + err.Error())
 		}
 	}
 
@@ -563,7 +584,7 @@ func (b *Bebop) packetReceiver(buf []byte) {
 		ack := b.createARStreamACK(arstreamFrame).Bytes()
 		_, err := b.write(ack)
 		if err != nil {
-			fmt.Println("ARNETWORKAL_FRAME_TYPE_DATA_LOW_LATENCY", err)
+			b.log("ARNETWORKAL_FRAME_TYPE_DATA_LOW_LATENCY: " + err.Error())
 		}
 	}
 
@@ -574,7 +595,7 @@ func (b *Bebop) packetReceiver(buf []byte) {
 		pong := b.createPong(frame).Bytes()
 		_, err := b.write(pong)
 		if err != nil {
-			fmt.Println("ARNETWORK_MANAGER_INTERNAL_BUFFER_ID_PING", err)
+			b.log("ARNETWORK_MANAGER_INTERNAL_BUFFER_ID_PING: " + err.Error())
 		}
 	}
 }
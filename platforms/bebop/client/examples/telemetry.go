@@ -13,11 +13,16 @@ func main() {
     drone := bebop.NewBebopDriver(bebopAdaptor, "Drone")
 
 
-		work := func() {
+		robot := gobot.NewRobot("drone",
+        []gobot.Connection{bebopAdaptor},
+        []gobot.Device{drone},
+    )
+
+		robot.Work = func() {
 			fmt.Println("Beginning work.")
       drone.HullProtection(true)
   		gobot.On(drone.Event("flying"), func(data interface{}) {
-  			gobot.After(1*time.Second, func() {
+  			robot.After(1*time.Second, func() {
   				drone.Land()
   			})
   		})
@@ -39,12 +44,6 @@ func main() {
 		})
     //*/
 
-		robot := gobot.NewRobot("drone",
-        []gobot.Connection{bebopAdaptor},
-        []gobot.Device{drone},
-        work,
-    )
-
     gbot.AddRobot(robot)
 		fmt.Println("Starting.")
     gbot.Start()
@@ -5,7 +5,6 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"strconv"
 
 	"github.com/hybridgroup/gobot/platforms/bebop/bbtelem"
@@ -203,7 +202,7 @@ func (b *Bebop) sendRuntimeError(comment string, err error, data []byte) error {
 		Payload: data,
 	})
 	if internalErr != nil {
-		fmt.Println("RUNTIME ERROR: ", internalErr.Error())
+		b.log("Runtime error: " + internalErr.Error())
 	}
 	return internalErr
 }
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
@@ -66,6 +67,7 @@ type Client struct {
 	ProtocolVersion  string
 	connected        bool
 	connection       io.ReadWriteCloser
+	mutex            sync.RWMutex
 	analogPins       []int
 	initTimeInterval time.Duration
 	gobot.Eventer
@@ -116,15 +118,36 @@ func New() *Client {
 
 // Disconnect disconnects the Client
 func (b *Client) Disconnect() (err error) {
-	b.connected = false
-	return b.connection.Close()
+	b.setConnected(false)
+	return b.conn().Close()
 }
 
 // Connected returns the current connection state of the Client
 func (b *Client) Connected() bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
 	return b.connected
 }
 
+func (b *Client) setConnected(connected bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.connected = connected
+}
+
+func (b *Client) conn() io.ReadWriteCloser {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.connection
+}
+
+// reading reports whether the reading goroutine of conn should go on.
+func (b *Client) reading(conn io.ReadWriteCloser) bool {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+	return b.connected && b.connection == conn
+}
+
 // Pins returns all available pins
 func (b *Client) Pins() []Pin {
 	return b.pins
@@ -134,11 +157,13 @@ func (b *Client) Pins() []Pin {
 // then continuously polls the firmata board for new information when it's
 // available.
 func (b *Client) Connect(conn io.ReadWriteCloser) (err error) {
+	b.mutex.Lock()
 	if b.connected {
+		b.mutex.Unlock()
 		return ErrConnected
 	}
-
 	b.connection = conn
+	b.mutex.Unlock()
 	b.Reset()
 
 	initFunc := b.ProtocolVersionQuery
@@ -159,7 +184,7 @@ func (b *Client) Connect(conn io.ReadWriteCloser) (err error) {
 		initFunc = func() error { return nil }
 		b.ReportDigital(0, 1)
 		b.ReportDigital(1, 1)
-		b.connected = true
+		b.setConnected(true)
 	})
 
 	for {
@@ -169,15 +194,22 @@ func (b *Client) Connect(conn io.ReadWriteCloser) (err error) {
 		if err := b.process(); err != nil {
 			return err
 		}
-		if b.connected {
+		if b.Connected() {
 			go func() {
 				for {
-					if !b.connected {
+					if !b.reading(conn) {
 						break
 					}
 
 					if err := b.process(); err != nil {
 						gobot.Publish(b.Event("Error"), err)
+						// the board is gone, stop reading until it is connected again
+						b.mutex.Lock()
+						if b.connection == conn {
+							b.connected = false
+						}
+						b.mutex.Unlock()
+						break
 					}
 				}
 			}()
@@ -311,7 +343,7 @@ func (b *Client) writeSysex(data []byte) (err error) {
 }
 
 func (b *Client) write(data []byte) (err error) {
-	_, err = b.connection.Write(data[:])
+	_, err = b.conn().Write(data[:])
 	return
 }
 
@@ -319,7 +351,7 @@ func (b *Client) read(length int) (buf []byte, err error) {
 	i := 0
 	for length > 0 {
 		tmp := make([]byte, length)
-		if i, err = b.connection.Read(tmp); err != nil {
+		if i, err = b.conn().Read(tmp); err != nil {
 			if err.Error() != "EOF" {
 				return
 			}
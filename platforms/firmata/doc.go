@@ -23,18 +23,17 @@ Example:
 		firmataAdaptor := firmata.NewFirmataAdaptor("arduino", "/dev/ttyACM0")
 		led := gpio.NewLedDriver(firmataAdaptor, "led", "13")
 
-		work := func() {
-			gobot.Every(1*time.Second, func() {
-				led.Toggle()
-			})
-		}
-
 		robot := gobot.NewRobot("bot",
 			[]gobot.Connection{firmataAdaptor},
 			[]gobot.Device{led},
-			work,
 		)
 
+		robot.Work = func() {
+			robot.Every(1*time.Second, func() {
+				led.Toggle()
+			})
+		}
+
 		gbot.AddRobot(robot)
 
 		gbot.Start()
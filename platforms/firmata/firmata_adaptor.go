@@ -1,6 +1,8 @@
 package firmata
 
 import (
+	"context"
+	"errors"
 	"io"
 	"strconv"
 	"time"
@@ -22,9 +24,17 @@ var _ gpio.ServoWriter = (*FirmataAdaptor)(nil)
 
 var _ i2c.I2c = (*FirmataAdaptor)(nil)
 
+var _ gobot.HealthChecker = (*FirmataAdaptor)(nil)
+var _ gobot.Reconnector = (*FirmataAdaptor)(nil)
+var _ gobot.ContextConnector = (*FirmataAdaptor)(nil)
+
+// ErrDisconnected is the error reported by Healthy once the board stops responding
+var ErrDisconnected = errors.New("firmata board is disconnected")
+
 type firmataBoard interface {
 	Connect(io.ReadWriteCloser) error
 	Disconnect() error
+	Connected() bool
 	Pins() []client.Pin
 	AnalogWrite(int, int) error
 	SetPinMode(int, int) error
@@ -43,6 +53,7 @@ type FirmataAdaptor struct {
 	port   string
 	board  firmataBoard
 	conn   io.ReadWriteCloser
+	opened bool
 	openSP func(port string) (io.ReadWriteCloser, error)
 }
 
@@ -80,19 +91,60 @@ func NewFirmataAdaptor(name string, args ...interface{}) *FirmataAdaptor {
 
 // Connect starts a connection to the board.
 func (f *FirmataAdaptor) Connect() (errs []error) {
+	return f.ConnectContext(context.Background())
+}
+
+// ConnectContext starts a connection to the board like Connect, giving up once
+// ctx is done. The connection is then closed, aborting the handshake with the
+// board, and a serial port opened afterwards is closed too.
+func (f *FirmataAdaptor) ConnectContext(ctx context.Context) (errs []error) {
 	if f.conn == nil {
-		sp, err := f.openSP(f.Port())
+		sp, err := gobot.OpenContext(ctx, func() (io.ReadWriteCloser, error) {
+			return f.openSP(f.Port())
+		})
 		if err != nil {
 			return []error{err}
 		}
 		f.conn = sp
+		f.opened = true
 	}
-	if err := f.board.Connect(f.conn); err != nil {
+	stop := gobot.CloseOnDone(ctx, f.conn)
+	err := f.board.Connect(gobot.MeterReadWriteCloser(f.conn, "serial", f.Name()))
+	if stop() {
+		if err == nil {
+			f.board.Disconnect()
+		}
+		if f.opened {
+			f.conn = nil
+			f.opened = false
+		}
+		return []error{ctx.Err()}
+	}
+	if err != nil {
 		return []error{err}
 	}
 	return
 }
 
+// Healthy returns ErrDisconnected once the board has stopped responding.
+func (f *FirmataAdaptor) Healthy() error {
+	if !f.board.Connected() {
+		return ErrDisconnected
+	}
+	return nil
+}
+
+// Reconnect closes the connection to the board and connects to it again,
+// reopening the serial port if the FirmataAdaptor opened it.
+func (f *FirmataAdaptor) Reconnect() (errs []error) {
+	f.Disconnect()
+	if f.opened {
+		f.conn = nil
+		f.opened = false
+	}
+	return f.Connect()
+}
+
 // Disconnect closes the io connection to the board
 func (f *FirmataAdaptor) Disconnect() (err error) {
 	if f.board != nil {
@@ -1,8 +1,10 @@
 package firmata
 
 import (
+	"context"
 	"errors"
 	"io"
+	"sync"
 	"testing"
 	"time"
 
@@ -33,8 +35,28 @@ func (readWriteCloser) Close() error {
 	return nil
 }
 
+// silentReadWriteCloser is a board which never answers, blocking reads until
+// it is closed.
+type silentReadWriteCloser struct {
+	once   sync.Once
+	closed chan struct{}
+}
+
+func (s *silentReadWriteCloser) Write(p []byte) (int, error) { return len(p), nil }
+
+func (s *silentReadWriteCloser) Read(b []byte) (int, error) {
+	<-s.closed
+	return 0, io.ErrClosedPipe
+}
+
+func (s *silentReadWriteCloser) Close() error {
+	s.once.Do(func() { close(s.closed) })
+	return nil
+}
+
 type mockFirmataBoard struct {
 	disconnectError error
+	connected       bool
 	gobot.Eventer
 	pins []client.Pin
 }
@@ -53,10 +75,15 @@ func newMockFirmataBoard() *mockFirmataBoard {
 	return m
 }
 
-func (mockFirmataBoard) Connect(io.ReadWriteCloser) error { return nil }
-func (m mockFirmataBoard) Disconnect() error {
+func (m *mockFirmataBoard) Connect(io.ReadWriteCloser) error {
+	m.connected = true
+	return nil
+}
+func (m *mockFirmataBoard) Disconnect() error {
+	m.connected = false
 	return m.disconnectError
 }
+func (m *mockFirmataBoard) Connected() bool { return m.connected }
 func (m mockFirmataBoard) Pins() []client.Pin {
 	return m.pins
 }
@@ -116,6 +143,52 @@ func TestFirmataAdaptorConnect(t *testing.T) {
 
 }
 
+func TestFirmataAdaptorConnectContext(t *testing.T) {
+	sp := &silentReadWriteCloser{closed: make(chan struct{})}
+	a := NewFirmataAdaptor("board", "/dev/null")
+	a.openSP = func(port string) (io.ReadWriteCloser, error) {
+		return sp, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	gobot.Assert(t, a.ConnectContext(ctx), []error{context.DeadlineExceeded})
+	select {
+	case <-sp.closed:
+	default:
+		t.Error("serial port was not closed")
+	}
+	gobot.Assert(t, a.conn, (io.ReadWriteCloser)(nil))
+}
+
+func TestFirmataAdaptorReconnect(t *testing.T) {
+	opened := 0
+	a := NewFirmataAdaptor("board", "/dev/null")
+	a.board = newMockFirmataBoard()
+	a.openSP = func(port string) (io.ReadWriteCloser, error) {
+		opened++
+		return &readWriteCloser{}, nil
+	}
+	gobot.Assert(t, len(a.Connect()), 0)
+	gobot.Assert(t, a.Healthy(), nil)
+
+	a.board.(*mockFirmataBoard).connected = false
+	gobot.Assert(t, a.Healthy(), ErrDisconnected)
+
+	gobot.Assert(t, len(a.Reconnect()), 0)
+	gobot.Assert(t, a.Healthy(), nil)
+	gobot.Assert(t, opened, 2)
+
+	a = NewFirmataAdaptor("board", &readWriteCloser{})
+	a.board = newMockFirmataBoard()
+	a.openSP = func(port string) (io.ReadWriteCloser, error) {
+		opened++
+		return &readWriteCloser{}, nil
+	}
+	gobot.Assert(t, len(a.Reconnect()), 0)
+	gobot.Assert(t, opened, 2)
+}
+
 func TestFirmataAdaptorServoWrite(t *testing.T) {
 	a := initTestFirmataAdaptor()
 	a.ServoWrite("1", 50)
@@ -0,0 +1,9 @@
+package firmata
+
+import "github.com/hybridgroup/gobot"
+
+func init() {
+	gobot.RegisterAdaptor("firmata", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		return NewFirmataAdaptor(s.Name, s.Port), nil
+	})
+}
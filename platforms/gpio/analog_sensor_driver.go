@@ -1,18 +1,23 @@
 package gpio
 
 import (
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
 )
 
 var _ gobot.Driver = (*AnalogSensorDriver)(nil)
+var _ gobot.StateReporter = (*AnalogSensorDriver)(nil)
 
 // AnalogSensorDriver represents an Analog Sensor
 type AnalogSensorDriver struct {
 	name       string
 	pin        string
 	halt       chan bool
+	done       chan struct{}
+	mutex      sync.Mutex
+	value      int
 	interval   time.Duration
 	connection AnalogReader
 	gobot.Eventer
@@ -35,7 +40,6 @@ func NewAnalogSensorDriver(a AnalogReader, name string, pin string, v ...time.Du
 		Eventer:    gobot.NewEventer(),
 		Commander:  gobot.NewCommander(),
 		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
 	}
 
 	if len(v) > 0 {
@@ -58,19 +62,22 @@ func NewAnalogSensorDriver(a AnalogReader, name string, pin string, v ...time.Du
 //	Data int - Event is emitted on change and represents the current reading from the sensor.
 //	Error error - Event is emitted on error reading from the sensor.
 func (a *AnalogSensorDriver) Start() (errs []error) {
-	value := 0
+	a.setValue(0)
+	halt := make(chan bool)
+	done := make(chan struct{})
+	a.halt, a.done = halt, done
 	go func() {
+		defer close(done)
 		for {
 			newValue, err := a.Read()
 			if err != nil {
 				gobot.Publish(a.Event(Error), err)
-			} else if newValue != value && newValue != -1 {
-				value = newValue
-				gobot.Publish(a.Event(Data), value)
+			} else if newValue != -1 && a.setValue(newValue) {
+				gobot.Publish(a.Event(Data), newValue)
 			}
 			select {
-			case <-time.After(a.interval):
-			case <-a.halt:
+			case <-gobot.Wait(a.interval):
+			case <-halt:
 				return
 			}
 		}
@@ -78,12 +85,35 @@ func (a *AnalogSensorDriver) Start() (errs []error) {
 	return
 }
 
-// Halt stops polling the analog sensor for new information
+// Halt stops polling the analog sensor for new information, waiting for a read
+// in progress to finish.
 func (a *AnalogSensorDriver) Halt() (errs []error) {
-	a.halt <- true
+	if a.halt != nil {
+		close(a.halt)
+		<-a.done
+		a.halt, a.done = nil, nil
+	}
 	return
 }
 
+// ReportState implements the gobot.StateReporter interface by reporting the
+// last value read from the sensor
+func (a *AnalogSensorDriver) ReportState() map[string]interface{} {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return map[string]interface{}{"value": a.value}
+}
+
+// setValue sets the last value read from the sensor and reports whether it
+// changed.
+func (a *AnalogSensorDriver) setValue(value int) bool {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	changed := value != a.value
+	a.value = value
+	return changed
+}
+
 // Name returns the AnalogSensorDrivers name
 func (a *AnalogSensorDriver) Name() string { return a.name }
 
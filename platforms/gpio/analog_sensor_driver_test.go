@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/gobottest"
 )
 
 func TestAnalogSensorDriver(t *testing.T) {
@@ -27,9 +28,14 @@ func TestAnalogSensorDriver(t *testing.T) {
 }
 
 func TestAnalogSensorDriverStart(t *testing.T) {
-	sem := make(chan bool, 1)
+	clock := gobottest.NewFakeClock(time.Now())
+	defer gobot.SetClock(gobot.SetClock(clock))
 
 	d := NewAnalogSensorDriver(newGpioTestAdaptor("adaptor"), "bot", "1")
+	data := gobottest.WatchEvent(d.Event(Data))
+	defer data.Stop()
+	errs := gobottest.WatchEvent(d.Event(Error))
+	defer errs.Stop()
 
 	testAdaptorAnalogRead = func() (val int, err error) {
 		val = 0
@@ -38,62 +44,60 @@ func TestAnalogSensorDriverStart(t *testing.T) {
 	gobot.Assert(t, len(d.Start()), 0)
 
 	// data was received
-	gobot.Once(d.Event(Data), func(data interface{}) {
-		gobot.Assert(t, data.(int), 100)
-		sem <- true
-	})
-
+	clock.BlockUntil(1)
 	testAdaptorAnalogRead = func() (val int, err error) {
 		val = 100
 		return
 	}
-
-	select {
-	case <-sem:
-	case <-time.After(10 * time.Second):
-		t.Errorf("AnalogSensor Event \"Data\" was not published")
-	}
+	clock.Advance(d.interval)
+	data.Expect(t, 100, time.Second)
 
 	// read error
-	gobot.Once(d.Event(Error), func(data interface{}) {
-		gobot.Assert(t, data.(error).Error(), "read error")
-		sem <- true
-	})
-
+	readErr := errors.New("read error")
+	clock.BlockUntil(1)
 	testAdaptorAnalogRead = func() (val int, err error) {
-		err = errors.New("read error")
+		err = readErr
 		return
 	}
-
-	select {
-	case <-sem:
-	case <-time.After(10 * time.Second):
-		t.Errorf("AnalogSensor Event \"Error\" was not published")
-	}
+	clock.Advance(d.interval)
+	errs.Expect(t, readErr, time.Second)
 
 	// send a halt message
-	gobot.Once(d.Event(Data), func(data interface{}) {
-		sem <- true
-	})
-
+	clock.BlockUntil(1)
+	gobot.Assert(t, len(d.Halt()), 0)
 	testAdaptorAnalogRead = func() (val int, err error) {
 		val = 200
 		return
 	}
+	clock.Advance(d.interval)
+	data.ExpectNone(t, 15*time.Millisecond)
+}
 
-	d.halt <- true
+func TestAnalogSensorDriverHalt(t *testing.T) {
+	d := NewAnalogSensorDriver(newGpioTestAdaptor("adaptor"), "bot", "1")
+	// halting a driver which was never started must not block
+	gobot.Assert(t, len(d.Halt()), 0)
 
-	select {
-	case <-sem:
-		t.Errorf("AnalogSensor Event should not published")
-	case <-time.After(100 * time.Millisecond):
-	}
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, len(d.Halt()), 0)
 }
 
-func TestAnalogSensorDriverHalt(t *testing.T) {
+func TestAnalogSensorDriverReportState(t *testing.T) {
+	clock := gobottest.NewFakeClock(time.Now())
+	defer gobot.SetClock(gobot.SetClock(clock))
+
 	d := NewAnalogSensorDriver(newGpioTestAdaptor("adaptor"), "bot", "1")
-	go func() {
-		<-d.halt
-	}()
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"value": 0})
+
+	data := gobottest.WatchEvent(d.Event(Data))
+	defer data.Stop()
+	testAdaptorAnalogRead = func() (val int, err error) {
+		val = 42
+		return
+	}
+	gobot.Assert(t, len(d.Start()), 0)
+	data.Expect(t, 42, time.Second)
 	gobot.Assert(t, len(d.Halt()), 0)
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"value": 42})
 }
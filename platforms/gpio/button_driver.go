@@ -7,6 +7,7 @@ import (
 )
 
 var _ gobot.Driver = (*ButtonDriver)(nil)
+var _ gobot.StateReporter = (*ButtonDriver)(nil)
 
 // ButtonDriver Represents a digital Button
 type ButtonDriver struct {
@@ -32,7 +33,6 @@ func NewButtonDriver(a DigitalReader, name string, pin string, v ...time.Duratio
 		Active:     false,
 		Eventer:    gobot.NewEventer(),
 		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
 	}
 
 	if len(v) > 0 {
@@ -54,6 +54,8 @@ func NewButtonDriver(a DigitalReader, name string, pin string, v ...time.Duratio
 //	Error error - On button error
 func (b *ButtonDriver) Start() (errs []error) {
 	state := 0
+	halt := make(chan bool)
+	b.halt = halt
 	go func() {
 		for {
 			newValue, err := b.connection.DigitalRead(b.Pin())
@@ -64,8 +66,8 @@ func (b *ButtonDriver) Start() (errs []error) {
 				b.update(newValue)
 			}
 			select {
-			case <-time.After(b.interval):
-			case <-b.halt:
+			case <-gobot.Wait(b.interval):
+			case <-halt:
 				return
 			}
 		}
@@ -75,10 +77,19 @@ func (b *ButtonDriver) Start() (errs []error) {
 
 // Halt stops polling the button for new information
 func (b *ButtonDriver) Halt() (errs []error) {
-	b.halt <- true
+	if b.halt != nil {
+		close(b.halt)
+		b.halt = nil
+	}
 	return
 }
 
+// ReportState implements the gobot.StateReporter interface by reporting whether
+// the button is pushed
+func (b *ButtonDriver) ReportState() map[string]interface{} {
+	return map[string]interface{}{"active": b.Active}
+}
+
 // Name returns the ButtonDrivers name
 func (b *ButtonDriver) Name() string { return b.name }
 
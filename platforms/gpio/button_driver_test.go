@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/gobottest"
 )
 
 func initTestButtonDriver() *ButtonDriver {
@@ -14,9 +15,11 @@ func initTestButtonDriver() *ButtonDriver {
 
 func TestButtonDriverHalt(t *testing.T) {
 	d := initTestButtonDriver()
-	go func() {
-		<-d.halt
-	}()
+	// halting a driver which was never started must not block
+	gobot.Assert(t, len(d.Halt()), 0)
+
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, len(d.Halt()), 0)
 	gobot.Assert(t, len(d.Halt()), 0)
 }
 
@@ -29,73 +32,64 @@ func TestButtonDriver(t *testing.T) {
 	gobot.Assert(t, d.interval, 30*time.Second)
 }
 
-func TestButtonDriverStart(t *testing.T) {
-	sem := make(chan bool, 0)
+func TestButtonDriverReportState(t *testing.T) {
 	d := initTestButtonDriver()
-	gobot.Assert(t, len(d.Start()), 0)
-
-	testAdaptorDigitalRead = func() (val int, err error) {
-		val = 1
-		return
-	}
-
-	gobot.Once(d.Event(Push), func(data interface{}) {
-		gobot.Assert(t, d.Active, true)
-		sem <- true
-	})
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"active": false})
+	d.update(1)
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"active": true})
+}
 
-	select {
-	case <-sem:
-	case <-time.After(15 * time.Millisecond):
-		t.Errorf("Button Event \"Push\" was not published")
-	}
+func TestButtonDriverStart(t *testing.T) {
+	clock := gobottest.NewFakeClock(time.Now())
+	defer gobot.SetClock(gobot.SetClock(clock))
 
 	testAdaptorDigitalRead = func() (val int, err error) {
 		val = 0
 		return
 	}
+	d := initTestButtonDriver()
+	push := gobottest.WatchEvent(d.Event(Push))
+	defer push.Stop()
+	release := gobottest.WatchEvent(d.Event(Release))
+	defer release.Stop()
+	errs := gobottest.WatchEvent(d.Event(Error))
+	defer errs.Stop()
+	gobot.Assert(t, len(d.Start()), 0)
 
-	gobot.Once(d.Event(Release), func(data interface{}) {
-		gobot.Assert(t, d.Active, false)
-		sem <- true
-	})
-
-	select {
-	case <-sem:
-	case <-time.After(15 * time.Millisecond):
-		t.Errorf("Button Event \"Release\" was not published")
+	// poll waits for the current poll to finish, then reads the button again
+	poll := func(read func() (int, error)) {
+		clock.BlockUntil(1)
+		testAdaptorDigitalRead = read
+		clock.Advance(d.interval)
 	}
 
-	testAdaptorDigitalRead = func() (val int, err error) {
-		err = errors.New("digital read error")
+	poll(func() (val int, err error) {
+		val = 1
 		return
-	}
+	})
+	push.Expect(t, 1, time.Second)
+	gobot.Assert(t, d.Active, true)
 
-	gobot.Once(d.Event(Error), func(data interface{}) {
-		sem <- true
+	poll(func() (val int, err error) {
+		val = 0
+		return
 	})
+	release.Expect(t, 0, time.Second)
+	gobot.Assert(t, d.Active, false)
 
-	select {
-	case <-sem:
-	case <-time.After(15 * time.Millisecond):
-		t.Errorf("Button Event \"Error\" was not published")
-	}
+	readErr := errors.New("digital read error")
+	poll(func() (val int, err error) {
+		err = readErr
+		return
+	})
+	errs.Expect(t, readErr, time.Second)
 
+	clock.BlockUntil(1)
+	gobot.Assert(t, len(d.Halt()), 0)
 	testAdaptorDigitalRead = func() (val int, err error) {
 		val = 1
 		return
 	}
-
-	gobot.Once(d.Event(Push), func(data interface{}) {
-		sem <- true
-	})
-
-	d.halt <- true
-
-	select {
-	case <-sem:
-		t.Errorf("Button Event \"Press\" should not published")
-	case <-time.After(15 * time.Millisecond):
-	}
-
+	clock.Advance(d.interval)
+	push.ExpectNone(t, 15*time.Millisecond)
 }
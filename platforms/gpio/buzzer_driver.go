@@ -1,6 +1,7 @@
 package gpio
 
 import (
+	"sync"
 	"time"
 
 	"github.com/hybridgroup/gobot"
@@ -126,12 +127,15 @@ const (
 )
 
 var _ gobot.Driver = (*BuzzerDriver)(nil)
+var _ gobot.SafeStater = (*BuzzerDriver)(nil)
+var _ gobot.StateReporter = (*BuzzerDriver)(nil)
 
 // BuzzerDriver represents a digital buzzer
 type BuzzerDriver struct {
 	pin        string
 	name       string
 	connection DigitalWriter
+	mutex      sync.Mutex
 	high       bool
 	BPM        float64
 }
@@ -155,6 +159,22 @@ func (l *BuzzerDriver) Start() (errs []error) { return }
 // Halt implements the Driver interface
 func (l *BuzzerDriver) Halt() (errs []error) { return }
 
+// ReportState implements the gobot.StateReporter interface by reporting whether
+// the buzzer is on and its tempo
+func (l *BuzzerDriver) ReportState() map[string]interface{} {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return map[string]interface{}{"on": l.high, "bpm": l.BPM}
+}
+
+// SafeState implements the gobot.SafeStater interface by turning the buzzer off
+func (l *BuzzerDriver) SafeState() (errs []error) {
+	if err := l.Off(); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
+
 // Name returns the BuzzerDrivers name
 func (l *BuzzerDriver) Name() string { return l.name }
 
@@ -168,11 +188,26 @@ func (l *BuzzerDriver) Connection() gobot.Connection {
 
 // State return true if the buzzer is On and false if the led is Off
 func (l *BuzzerDriver) State() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
 	return l.high
 }
 
 // On sets the buzzer to a high state.
 func (l *BuzzerDriver) On() (err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.on()
+}
+
+// Off sets the buzzer to a low state.
+func (l *BuzzerDriver) Off() (err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.off()
+}
+
+func (l *BuzzerDriver) on() (err error) {
 	if err = l.connection.DigitalWrite(l.Pin(), 1); err != nil {
 		return
 	}
@@ -180,8 +215,7 @@ func (l *BuzzerDriver) On() (err error) {
 	return
 }
 
-// Off sets the buzzer to a low state.
-func (l *BuzzerDriver) Off() (err error) {
+func (l *BuzzerDriver) off() (err error) {
 	if err = l.connection.DigitalWrite(l.Pin(), 0); err != nil {
 		return
 	}
@@ -191,10 +225,12 @@ func (l *BuzzerDriver) Off() (err error) {
 
 // Toggle sets the buzzer to the opposite of it's current state
 func (l *BuzzerDriver) Toggle() (err error) {
-	if l.State() {
-		err = l.Off()
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.high {
+		err = l.off()
 	} else {
-		err = l.On()
+		err = l.on()
 	}
 	return
 }
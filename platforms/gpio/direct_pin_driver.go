@@ -19,12 +19,13 @@ type DirectPinDriver struct {
 // NewDirectPinDriver return a new DirectPinDriver given a Connection, name and pin.
 //
 // Adds the following API Commands:
-// 	"DigitalRead" - See DirectPinDriver.DigitalRead
-// 	"DigitalWrite" - See DirectPinDriver.DigitalWrite
-// 	"AnalogRead" - See DirectPinDriver.AnalogRead
-// 	"AnalogWrite" - See DirectPinDriver.AnalogWrite
-// 	"PwmWrite" - See DirectPinDriver.PwmWrite
-// 	"ServoWrite" - See DirectPinDriver.ServoWrite
+//
+//	"DigitalRead" - See DirectPinDriver.DigitalRead
+//	"DigitalWrite" - See DirectPinDriver.DigitalWrite
+//	"AnalogRead" - See DirectPinDriver.AnalogRead
+//	"AnalogWrite" - See DirectPinDriver.AnalogWrite
+//	"PwmWrite" - See DirectPinDriver.PwmWrite
+//	"ServoWrite" - See DirectPinDriver.ServoWrite
 func NewDirectPinDriver(a gobot.Connection, name string, pin string) *DirectPinDriver {
 	d := &DirectPinDriver{
 		name:       name,
@@ -37,7 +38,13 @@ func NewDirectPinDriver(a gobot.Connection, name string, pin string) *DirectPinD
 		val, err := d.DigitalRead()
 		return map[string]interface{}{"val": val, "err": err}
 	})
-	d.AddCommand("DigitalWrite", func(params map[string]interface{}) interface{} {
+	levelParam := gobot.Param{Name: "level", Type: gobot.StringParam, Required: true}
+
+	commander := d.Commander.(gobot.SchemaCommander)
+	commander.AddCommandSchema("DigitalWrite", &gobot.CommandSchema{
+		Description: "Writes level to the pin",
+		Params:      []gobot.Param{levelParam},
+	}, func(params map[string]interface{}) interface{} {
 		level, _ := strconv.Atoi(params["level"].(string))
 		return d.DigitalWrite(byte(level))
 	})
@@ -45,11 +52,17 @@ func NewDirectPinDriver(a gobot.Connection, name string, pin string) *DirectPinD
 		val, err := d.AnalogRead()
 		return map[string]interface{}{"val": val, "err": err}
 	})
-	d.AddCommand("PwmWrite", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("PwmWrite", &gobot.CommandSchema{
+		Description: "Writes the pwm level to the pin",
+		Params:      []gobot.Param{levelParam},
+	}, func(params map[string]interface{}) interface{} {
 		level, _ := strconv.Atoi(params["level"].(string))
 		return d.PwmWrite(byte(level))
 	})
-	d.AddCommand("ServoWrite", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("ServoWrite", &gobot.CommandSchema{
+		Description: "Writes the servo level to the pin",
+		Params:      []gobot.Param{levelParam},
+	}, func(params map[string]interface{}) interface{} {
 		level, _ := strconv.Atoi(params["level"].(string))
 		return d.ServoWrite(byte(level))
 	})
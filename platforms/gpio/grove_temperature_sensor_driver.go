@@ -8,6 +8,7 @@ import (
 )
 
 var _ gobot.Driver = (*GroveTemperatureSensorDriver)(nil)
+var _ gobot.StateReporter = (*GroveTemperatureSensorDriver)(nil)
 
 // GroveTemperatureSensorDriver represents a Temperature Sensor
 type GroveTemperatureSensorDriver struct {
@@ -35,7 +36,6 @@ func NewGroveTemperatureSensorDriver(a AnalogReader, name string, pin string, v
 		pin:        pin,
 		Eventer:    gobot.NewEventer(),
 		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
 	}
 
 	if len(v) > 0 {
@@ -56,6 +56,8 @@ func (a *GroveTemperatureSensorDriver) Start() (errs []error) {
 	thermistor := 3975.0
 	a.temperature = 0
 
+	halt := make(chan bool)
+	a.halt = halt
 	go func() {
 		for {
 			rawValue, err := a.Read()
@@ -70,8 +72,8 @@ func (a *GroveTemperatureSensorDriver) Start() (errs []error) {
 				gobot.Publish(a.Event(Data), a.temperature)
 			}
 			select {
-			case <-time.After(a.interval):
-			case <-a.halt:
+			case <-gobot.Wait(a.interval):
+			case <-halt:
 				return
 			}
 		}
@@ -81,10 +83,19 @@ func (a *GroveTemperatureSensorDriver) Start() (errs []error) {
 
 // Halt stops polling the analog sensor for new information
 func (a *GroveTemperatureSensorDriver) Halt() (errs []error) {
-	a.halt <- true
+	if a.halt != nil {
+		close(a.halt)
+		a.halt = nil
+	}
 	return
 }
 
+// ReportState implements the gobot.StateReporter interface by reporting the
+// last temperature read from the sensor
+func (a *GroveTemperatureSensorDriver) ReportState() map[string]interface{} {
+	return map[string]interface{}{"temperature": a.temperature}
+}
+
 // Name returns the GroveTemperatureSensorDrivers name
 func (a *GroveTemperatureSensorDriver) Name() string { return a.name }
 
@@ -1,21 +1,31 @@
 package gpio
 
-import "github.com/hybridgroup/gobot"
+import (
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
 
 var _ gobot.Driver = (*LedDriver)(nil)
+var _ gobot.SafeStater = (*LedDriver)(nil)
+var _ gobot.StateReporter = (*LedDriver)(nil)
+var _ gobot.Dependent = (*LedDriver)(nil)
 
 // LedDriver represents a digital Led
 type LedDriver struct {
 	pin        string
 	name       string
 	connection DigitalWriter
+	mutex      sync.Mutex
 	high       bool
+	brightness byte
 	gobot.Commander
 }
 
 // NewLedDriver return a new LedDriver given a DigitalWriter, name and pin.
 //
 // Adds the following API Commands:
+//
 //	"Brightness" - See LedDriver.Brightness
 //	"Toggle" - See LedDriver.Toggle
 //	"On" - See LedDriver.On
@@ -29,7 +39,13 @@ func NewLedDriver(a DigitalWriter, name string, pin string) *LedDriver {
 		Commander:  gobot.NewCommander(),
 	}
 
-	l.AddCommand("Brightness", func(params map[string]interface{}) interface{} {
+	commander := l.Commander.(gobot.SchemaCommander)
+	commander.AddCommandSchema("Brightness", &gobot.CommandSchema{
+		Description: "Sets the brightness of the led",
+		Params: []gobot.Param{
+			gobot.Param{Name: "level", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		level := byte(params["level"].(float64))
 		return l.Brightness(level)
 	})
@@ -55,6 +71,31 @@ func (l *LedDriver) Start() (errs []error) { return }
 // Halt implements the Driver interface
 func (l *LedDriver) Halt() (errs []error) { return }
 
+// ReportState implements the gobot.StateReporter interface by reporting whether
+// the led is on and its brightness
+func (l *LedDriver) ReportState() map[string]interface{} {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return map[string]interface{}{"on": l.high, "brightness": l.brightness}
+}
+
+// SafeState implements the gobot.SafeStater interface by turning the led off
+func (l *LedDriver) SafeState() (errs []error) {
+	if err := l.Off(); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
+
+// Dependencies implements the gobot.Dependent interface. An led on the pins of
+// another driver, such as an expander, starts after it.
+func (l *LedDriver) Dependencies() []string {
+	if d, ok := l.connection.(gobot.Driver); ok {
+		return []string{d.Name()}
+	}
+	return nil
+}
+
 // Name returns the LedDrivers name
 func (l *LedDriver) Name() string { return l.name }
 
@@ -68,41 +109,68 @@ func (l *LedDriver) Connection() gobot.Connection {
 
 // State return true if the led is On and false if the led is Off
 func (l *LedDriver) State() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
 	return l.high
 }
 
 // On sets the led to a high state.
 func (l *LedDriver) On() (err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.on()
+}
+
+// Off sets the led to a low state.
+func (l *LedDriver) Off() (err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.off()
+}
+
+func (l *LedDriver) on() (err error) {
 	if err = l.connection.DigitalWrite(l.Pin(), 1); err != nil {
 		return
 	}
 	l.high = true
+	l.brightness = 255
 	return
 }
 
-// Off sets the led to a low state.
-func (l *LedDriver) Off() (err error) {
+func (l *LedDriver) off() (err error) {
 	if err = l.connection.DigitalWrite(l.Pin(), 0); err != nil {
 		return
 	}
 	l.high = false
+	l.brightness = 0
 	return
 }
 
 // Toggle sets the led to the opposite of it's current state
 func (l *LedDriver) Toggle() (err error) {
-	if l.State() {
-		err = l.Off()
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.high {
+		err = l.off()
 	} else {
-		err = l.On()
+		err = l.on()
 	}
 	return
 }
 
-// Brightness sets the led to the specified level of brightness
+// Brightness sets the led to the specified level of brightness. The led is on
+// at any level but 0.
 func (l *LedDriver) Brightness(level byte) (err error) {
-	if writer, ok := l.connection.(PwmWriter); ok {
-		return writer.PwmWrite(l.Pin(), level)
+	writer, ok := l.connection.(PwmWriter)
+	if !ok {
+		return ErrPwmWriteUnsupported
 	}
-	return ErrPwmWriteUnsupported
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if err = writer.PwmWrite(l.Pin(), level); err != nil {
+		return
+	}
+	l.high = level > 0
+	l.brightness = level
+	return
 }
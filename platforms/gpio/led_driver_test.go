@@ -2,6 +2,7 @@ package gpio
 
 import (
 	"errors"
+	"sync"
 	"testing"
 
 	"github.com/hybridgroup/gobot"
@@ -57,6 +58,18 @@ func TestLedDriverHalt(t *testing.T) {
 	gobot.Assert(t, len(d.Halt()), 0)
 }
 
+func TestLedDriverSafeState(t *testing.T) {
+	d := initTestLedDriver(newGpioTestAdaptor("adaptor"))
+	d.On()
+	gobot.Assert(t, len(d.SafeState()), 0)
+	gobot.Assert(t, d.State(), false)
+
+	testAdaptorDigitalWrite = func() (err error) {
+		return errors.New("write error")
+	}
+	gobot.Assert(t, d.SafeState(), []error{errors.New("write error")})
+}
+
 func TestLedDriverToggle(t *testing.T) {
 	d := initTestLedDriver(newGpioTestAdaptor("adaptor"))
 	d.Off()
@@ -66,6 +79,25 @@ func TestLedDriverToggle(t *testing.T) {
 	gobot.Assert(t, d.State(), false)
 }
 
+func TestLedDriverToggleConcurrently(t *testing.T) {
+	d := initTestLedDriver(newGpioTestAdaptor("adaptor"))
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				d.Toggle()
+				d.ReportState()
+			}
+		}()
+	}
+	d.SafeState()
+	wg.Wait()
+	d.Toggle()
+	gobot.Assert(t, d.State(), true)
+}
+
 func TestLedDriverBrightness(t *testing.T) {
 	d := initTestLedDriver(&gpioTestDigitalWriter{})
 	gobot.Assert(t, d.Brightness(150), ErrPwmWriteUnsupported)
@@ -77,3 +109,42 @@ func TestLedDriverBrightness(t *testing.T) {
 	}
 	gobot.Assert(t, d.Brightness(150), errors.New("pwm error"))
 }
+
+func TestLedDriverReportState(t *testing.T) {
+	d := initTestLedDriver(newGpioTestAdaptor("adaptor"))
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"on": false, "brightness": byte(0)})
+	d.On()
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"on": true, "brightness": byte(255)})
+	d.Brightness(100)
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"on": true, "brightness": byte(100)})
+	d.Brightness(0)
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"on": false, "brightness": byte(0)})
+}
+
+// testExpanderDriver is a driver whose pins leds are on.
+type testExpanderDriver struct {
+	gpioTestAdaptor
+	started bool
+}
+
+func (t *testExpanderDriver) Start() (errs []error)        { t.started = true; return }
+func (t *testExpanderDriver) Halt() (errs []error)         { return }
+func (t *testExpanderDriver) Connection() gobot.Connection { return nil }
+
+func TestLedDriverDependencies(t *testing.T) {
+	d := initTestLedDriver(newGpioTestAdaptor("adaptor"))
+	gobot.Assert(t, len(d.Dependencies()), 0)
+
+	expander := &testExpanderDriver{gpioTestAdaptor: *newGpioTestAdaptor("expander")}
+	d = initTestLedDriver(expander)
+	gobot.Assert(t, d.Dependencies(), []string{"expander"})
+
+	_, err := gobot.NewCheckedRobot("lights", []gobot.Device{d})
+	gobot.Assert(t, err.Error(), `Robot "lights": Device "bot": depends on unknown Device or Connection "expander"`)
+
+	r, err := gobot.NewCheckedRobot("lights", []gobot.Device{d, expander})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, len(r.Start()), 0)
+	gobot.Assert(t, expander.started, true)
+	gobot.Assert(t, len(r.Stop()), 0)
+}
@@ -7,6 +7,7 @@ import (
 )
 
 var _ gobot.Driver = (*MakeyButtonDriver)(nil)
+var _ gobot.StateReporter = (*MakeyButtonDriver)(nil)
 
 // MakeyButtonDriver Represents a Makey Button
 type MakeyButtonDriver struct {
@@ -32,7 +33,6 @@ func NewMakeyButtonDriver(a DigitalReader, name string, pin string, v ...time.Du
 		Active:     false,
 		Eventer:    gobot.NewEventer(),
 		interval:   10 * time.Millisecond,
-		halt:       make(chan bool),
 	}
 
 	if len(v) > 0 {
@@ -63,6 +63,8 @@ func (b *MakeyButtonDriver) Connection() gobot.Connection { return b.connection.
 //	Error error - On button error
 func (b *MakeyButtonDriver) Start() (errs []error) {
 	state := 1
+	halt := make(chan bool)
+	b.halt = halt
 	go func() {
 		for {
 			newValue, err := b.connection.DigitalRead(b.Pin())
@@ -79,8 +81,8 @@ func (b *MakeyButtonDriver) Start() (errs []error) {
 				}
 			}
 			select {
-			case <-time.After(b.interval):
-			case <-b.halt:
+			case <-gobot.Wait(b.interval):
+			case <-halt:
 				return
 			}
 		}
@@ -90,6 +92,15 @@ func (b *MakeyButtonDriver) Start() (errs []error) {
 
 // Halt stops polling the makey button for new information
 func (b *MakeyButtonDriver) Halt() (errs []error) {
-	b.halt <- true
+	if b.halt != nil {
+		close(b.halt)
+		b.halt = nil
+	}
 	return
 }
+
+// ReportState implements the gobot.StateReporter interface by reporting whether
+// the button is pushed
+func (b *MakeyButtonDriver) ReportState() map[string]interface{} {
+	return map[string]interface{}{"active": b.Active}
+}
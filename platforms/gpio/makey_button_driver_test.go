@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/gobottest"
 )
 
 func initTestMakeyButtonDriver() *MakeyButtonDriver {
@@ -14,9 +15,11 @@ func initTestMakeyButtonDriver() *MakeyButtonDriver {
 
 func TestMakeyButtonDriverHalt(t *testing.T) {
 	d := initTestMakeyButtonDriver()
-	go func() {
-		<-d.halt
-	}()
+	// halting a driver which was never started must not block
+	gobot.Assert(t, len(d.Halt()), 0)
+
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, len(d.Halt()), 0)
 	gobot.Assert(t, len(d.Halt()), 0)
 }
 
@@ -30,70 +33,56 @@ func TestMakeyButtonDriver(t *testing.T) {
 }
 
 func TestMakeyButtonDriverStart(t *testing.T) {
-	sem := make(chan bool, 0)
-	d := initTestMakeyButtonDriver()
-	gobot.Assert(t, len(d.Start()), 0)
+	clock := gobottest.NewFakeClock(time.Now())
+	defer gobot.SetClock(gobot.SetClock(clock))
 
 	testAdaptorDigitalRead = func() (val int, err error) {
-		val = 0
+		val = 1
 		return
 	}
+	d := initTestMakeyButtonDriver()
+	push := gobottest.WatchEvent(d.Event(Push))
+	defer push.Stop()
+	release := gobottest.WatchEvent(d.Event(Release))
+	defer release.Stop()
+	errs := gobottest.WatchEvent(d.Event(Error))
+	defer errs.Stop()
+	gobot.Assert(t, len(d.Start()), 0)
 
-	gobot.Once(d.Event(Push), func(data interface{}) {
-		gobot.Assert(t, d.Active, true)
-		sem <- true
-	})
-
-	select {
-	case <-sem:
-	case <-time.After(15 * time.Millisecond):
-		t.Errorf("MakeyButton Event \"Push\" was not published")
+	// poll waits for the current poll to finish, then reads the button again
+	poll := func(read func() (int, error)) {
+		clock.BlockUntil(1)
+		testAdaptorDigitalRead = read
+		clock.Advance(d.interval)
 	}
 
-	testAdaptorDigitalRead = func() (val int, err error) {
-		val = 1
+	poll(func() (val int, err error) {
+		val = 0
 		return
-	}
-
-	gobot.Once(d.Event(Release), func(data interface{}) {
-		gobot.Assert(t, d.Active, false)
-		sem <- true
 	})
+	push.Expect(t, 0, time.Second)
+	gobot.Assert(t, d.Active, true)
 
-	select {
-	case <-sem:
-	case <-time.After(15 * time.Millisecond):
-		t.Errorf("MakeyButton Event \"Release\" was not published")
-	}
-
-	testAdaptorDigitalRead = func() (val int, err error) {
-		err = errors.New("digital read error")
+	poll(func() (val int, err error) {
+		val = 1
 		return
-	}
-
-	gobot.Once(d.Event(Error), func(data interface{}) {
-		sem <- true
 	})
+	release.Expect(t, 1, time.Second)
+	gobot.Assert(t, d.Active, false)
 
-	select {
-	case <-sem:
-	case <-time.After(15 * time.Millisecond):
-		t.Errorf("MakeyButton Event \"Error\" was not published")
-	}
-
-	gobot.Once(d.Event(Release), func(data interface{}) {
-		sem <- true
+	readErr := errors.New("digital read error")
+	poll(func() (val int, err error) {
+		err = readErr
+		return
 	})
+	errs.Expect(t, readErr, time.Second)
+
+	clock.BlockUntil(1)
+	gobot.Assert(t, len(d.Halt()), 0)
 	testAdaptorDigitalRead = func() (val int, err error) {
-		val = 1
+		val = 0
 		return
 	}
-
-	d.halt <- true
-
-	select {
-	case <-sem:
-		t.Errorf("MakeyButton Event should not published")
-	case <-time.After(30 * time.Millisecond):
-	}
+	clock.Advance(d.interval)
+	push.ExpectNone(t, 15*time.Millisecond)
 }
@@ -1,15 +1,20 @@
 package gpio
 
 import (
+	"sync"
+
 	"github.com/hybridgroup/gobot"
 )
 
 var _ gobot.Driver = (*MotorDriver)(nil)
+var _ gobot.SafeStater = (*MotorDriver)(nil)
+var _ gobot.StateReporter = (*MotorDriver)(nil)
 
 // MotorDriver Represents a Motor
 type MotorDriver struct {
 	name             string
 	connection       DigitalWriter
+	mutex            sync.Mutex
 	SpeedPin         string
 	SwitchPin        string
 	DirectionPin     string
@@ -46,27 +51,40 @@ func (m *MotorDriver) Start() (errs []error) { return }
 // Halt implements the Driver interface
 func (m *MotorDriver) Halt() (errs []error) { return }
 
-// Off turns the motor off or sets the motor to a 0 speed
-func (m *MotorDriver) Off() (err error) {
-	if m.isDigital() {
-		err = m.changeState(0)
-	} else {
-		err = m.Speed(0)
+// ReportState implements the gobot.StateReporter interface by reporting the
+// state, speed, mode and direction of the motor
+func (m *MotorDriver) ReportState() map[string]interface{} {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return map[string]interface{}{
+		"on":        m.isOn(),
+		"state":     m.CurrentState,
+		"speed":     m.CurrentSpeed,
+		"mode":      m.CurrentMode,
+		"direction": m.CurrentDirection,
+	}
+}
+
+// SafeState implements the gobot.SafeStater interface by turning the motor off
+func (m *MotorDriver) SafeState() (errs []error) {
+	if err := m.Off(); err != nil {
+		errs = append(errs, err)
 	}
 	return
 }
 
+// Off turns the motor off or sets the motor to a 0 speed
+func (m *MotorDriver) Off() (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.off()
+}
+
 // On turns the motor on or sets the motor to a maximum speed
 func (m *MotorDriver) On() (err error) {
-	if m.isDigital() {
-		err = m.changeState(1)
-	} else {
-		if m.CurrentSpeed == 0 {
-			m.CurrentSpeed = 255
-		}
-		err = m.Speed(m.CurrentSpeed)
-	}
-	return
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.on()
 }
 
 // Min sets the motor to the minimum speed
@@ -81,10 +99,9 @@ func (m *MotorDriver) Max() (err error) {
 
 // IsOn returns true if the motor is on
 func (m *MotorDriver) IsOn() bool {
-	if m.isDigital() {
-		return m.CurrentState == 1
-	}
-	return m.CurrentSpeed > 0
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.isOn()
 }
 
 // IsOff returns true if the motor is off
@@ -94,52 +111,87 @@ func (m *MotorDriver) IsOff() bool {
 
 // Toggle sets the motor to the opposite of it's current state
 func (m *MotorDriver) Toggle() (err error) {
-	if m.IsOn() {
-		err = m.Off()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.isOn() {
+		err = m.off()
 	} else {
-		err = m.On()
+		err = m.on()
 	}
 	return
 }
 
 // Speed sets the speed of the motor
 func (m *MotorDriver) Speed(value byte) (err error) {
-	if writer, ok := m.connection.(PwmWriter); ok {
-		m.CurrentMode = "analog"
-		m.CurrentSpeed = value
-		return writer.PwmWrite(m.SpeedPin, value)
-	}
-	return ErrPwmWriteUnsupported
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.speed(value)
 }
 
 // Forward sets the forward pin to the specified speed
 func (m *MotorDriver) Forward(speed byte) (err error) {
-	err = m.Direction("forward")
-	if err != nil {
-		return
+	return m.move("forward", speed)
+}
+
+// Backward sets the backward pin to the specified speed
+func (m *MotorDriver) Backward(speed byte) (err error) {
+	return m.move("backward", speed)
+}
+
+// Direction sets the direction pin to the specified speed
+func (m *MotorDriver) Direction(direction string) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.direction(direction)
+}
+
+func (m *MotorDriver) off() (err error) {
+	if m.isDigital() {
+		err = m.changeState(0)
+	} else {
+		err = m.speed(0)
 	}
-	err = m.Speed(speed)
-	if err != nil {
-		return
+	return
+}
+
+func (m *MotorDriver) on() (err error) {
+	if m.isDigital() {
+		err = m.changeState(1)
+	} else {
+		if m.CurrentSpeed == 0 {
+			m.CurrentSpeed = 255
+		}
+		err = m.speed(m.CurrentSpeed)
 	}
 	return
 }
 
-// Backward sets the backward pin to the specified speed
-func (m *MotorDriver) Backward(speed byte) (err error) {
-	err = m.Direction("backward")
-	if err != nil {
-		return
+func (m *MotorDriver) isOn() bool {
+	if m.isDigital() {
+		return m.CurrentState == 1
 	}
-	err = m.Speed(speed)
-	if err != nil {
+	return m.CurrentSpeed > 0
+}
+
+func (m *MotorDriver) move(direction string, speed byte) (err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if err = m.direction(direction); err != nil {
 		return
 	}
-	return
+	return m.speed(speed)
 }
 
-// Direction sets the direction pin to the specified speed
-func (m *MotorDriver) Direction(direction string) (err error) {
+func (m *MotorDriver) speed(value byte) (err error) {
+	if writer, ok := m.connection.(PwmWriter); ok {
+		m.CurrentMode = "analog"
+		m.CurrentSpeed = value
+		return writer.PwmWrite(m.SpeedPin, value)
+	}
+	return ErrPwmWriteUnsupported
+}
+
+func (m *MotorDriver) direction(direction string) (err error) {
 	m.CurrentDirection = direction
 	if m.DirectionPin != "" {
 		var level byte
@@ -190,18 +242,18 @@ func (m *MotorDriver) changeState(state byte) (err error) {
 	}
 	if m.ForwardPin != "" {
 		if state == 0 {
-			err = m.Direction(m.CurrentDirection)
+			err = m.direction(m.CurrentDirection)
 			if err != nil {
 				return
 			}
 			if m.SpeedPin != "" {
-				err = m.Speed(m.CurrentSpeed)
+				err = m.speed(m.CurrentSpeed)
 				if err != nil {
 					return
 				}
 			}
 		} else {
-			err = m.Direction("none")
+			err = m.direction("none")
 		}
 	} else {
 		err = m.connection.DigitalWrite(m.SpeedPin, state)
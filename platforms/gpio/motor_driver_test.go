@@ -26,6 +26,29 @@ func TestMotorDriverHalt(t *testing.T) {
 	gobot.Assert(t, len(d.Halt()), 0)
 }
 
+func TestMotorDriverSafeState(t *testing.T) {
+	testAdaptorPwmWrite = func() (err error) { return }
+	d := initTestMotorDriver()
+	d.CurrentMode = "analog"
+	d.CurrentSpeed = 100
+	gobot.Assert(t, len(d.SafeState()), 0)
+	gobot.Assert(t, d.IsOff(), true)
+}
+
+func TestMotorDriverReportState(t *testing.T) {
+	d := initTestMotorDriver()
+	d.CurrentMode = "analog"
+	d.CurrentSpeed = 100
+	d.CurrentDirection = "backward"
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{
+		"on":        true,
+		"state":     byte(0),
+		"speed":     byte(100),
+		"mode":      "analog",
+		"direction": "backward",
+	})
+}
+
 func TestMotorDriverIsOn(t *testing.T) {
 	d := initTestMotorDriver()
 	d.CurrentMode = "digital"
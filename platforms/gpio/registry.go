@@ -0,0 +1,100 @@
+package gpio
+
+import "github.com/hybridgroup/gobot"
+
+func init() {
+	registerDigitalWriter("gpio.led", func(a DigitalWriter, s gobot.DriverSpec) gobot.Driver {
+		return NewLedDriver(a, s.Name, s.Pin)
+	})
+	registerDigitalWriter("gpio.relay", func(a DigitalWriter, s gobot.DriverSpec) gobot.Driver {
+		return NewRelayDriver(a, s.Name, s.Pin)
+	})
+	registerDigitalWriter("gpio.buzzer", func(a DigitalWriter, s gobot.DriverSpec) gobot.Driver {
+		return NewBuzzerDriver(a, s.Name, s.Pin)
+	})
+	registerDigitalWriter("gpio.motor", func(a DigitalWriter, s gobot.DriverSpec) gobot.Driver {
+		return NewMotorDriver(a, s.Name, s.Pin)
+	})
+	registerDigitalWriter("gpio.grove_led", func(a DigitalWriter, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveLedDriver(a, s.Name, s.Pin)
+	})
+	registerDigitalWriter("gpio.grove_relay", func(a DigitalWriter, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveRelayDriver(a, s.Name, s.Pin)
+	})
+	registerDigitalWriter("gpio.grove_buzzer", func(a DigitalWriter, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveBuzzerDriver(a, s.Name, s.Pin)
+	})
+
+	registerDigitalReader("gpio.button", func(a DigitalReader, s gobot.DriverSpec) gobot.Driver {
+		return NewButtonDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+	registerDigitalReader("gpio.makey_button", func(a DigitalReader, s gobot.DriverSpec) gobot.Driver {
+		return NewMakeyButtonDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+	registerDigitalReader("gpio.grove_button", func(a DigitalReader, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveButtonDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+	registerDigitalReader("gpio.grove_touch", func(a DigitalReader, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveTouchDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+
+	registerAnalogReader("gpio.analog_sensor", func(a AnalogReader, s gobot.DriverSpec) gobot.Driver {
+		return NewAnalogSensorDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+	registerAnalogReader("gpio.grove_rotary", func(a AnalogReader, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveRotaryDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+	registerAnalogReader("gpio.grove_light_sensor", func(a AnalogReader, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveLightSensorDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+	registerAnalogReader("gpio.grove_piezo_vibration_sensor", func(a AnalogReader, s gobot.DriverSpec) gobot.Driver {
+		return NewGrovePiezoVibrationSensorDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+	registerAnalogReader("gpio.grove_sound_sensor", func(a AnalogReader, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveSoundSensorDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+	registerAnalogReader("gpio.grove_temperature_sensor", func(a AnalogReader, s gobot.DriverSpec) gobot.Driver {
+		return NewGroveTemperatureSensorDriver(a, s.Name, s.Pin, s.Intervals()...)
+	})
+
+	gobot.RegisterDriver("gpio.servo", func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		a, ok := c.(ServoWriter)
+		if !ok {
+			return nil, ErrServoWriteUnsupported
+		}
+		return NewServoDriver(a, s.Name, s.Pin), nil
+	})
+	gobot.RegisterDriver("gpio.direct_pin", func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewDirectPinDriver(c, s.Name, s.Pin), nil
+	})
+}
+
+func registerDigitalWriter(kind string, f func(DigitalWriter, gobot.DriverSpec) gobot.Driver) {
+	gobot.RegisterDriver(kind, func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		a, ok := c.(DigitalWriter)
+		if !ok {
+			return nil, ErrDigitalWriteUnsupported
+		}
+		return f(a, s), nil
+	})
+}
+
+func registerDigitalReader(kind string, f func(DigitalReader, gobot.DriverSpec) gobot.Driver) {
+	gobot.RegisterDriver(kind, func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		a, ok := c.(DigitalReader)
+		if !ok {
+			return nil, ErrDigitalReadUnsupported
+		}
+		return f(a, s), nil
+	})
+}
+
+func registerAnalogReader(kind string, f func(AnalogReader, gobot.DriverSpec) gobot.Driver) {
+	gobot.RegisterDriver(kind, func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		a, ok := c.(AnalogReader)
+		if !ok {
+			return nil, ErrAnalogReadUnsupported
+		}
+		return f(a, s), nil
+	})
+}
@@ -0,0 +1,26 @@
+package gpio
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestRegisteredDrivers(t *testing.T) {
+	a := newGpioTestAdaptor("adaptor")
+
+	d, err := gobot.NewDriver("gpio.led", a, gobot.DriverSpec{Name: "led", Pin: "13"})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, d.(*LedDriver).Pin(), "13")
+
+	d, err = gobot.NewDriver("gpio.button", a, gobot.DriverSpec{Name: "button", Pin: "2", Interval: time.Second})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, d.(*ButtonDriver).interval, time.Second)
+
+	_, err = gobot.NewDriver("gpio.servo", &gpioTestDigitalWriter{}, gobot.DriverSpec{Name: "servo", Pin: "3"})
+	gobot.Assert(t, err, ErrServoWriteUnsupported)
+
+	_, err = gobot.NewDriver("gpio.analog_sensor", &gpioTestDigitalWriter{}, gobot.DriverSpec{Name: "sensor", Pin: "1"})
+	gobot.Assert(t, err, ErrAnalogReadUnsupported)
+}
@@ -1,14 +1,21 @@
 package gpio
 
-import "github.com/hybridgroup/gobot"
+import (
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
 
 var _ gobot.Driver = (*RelayDriver)(nil)
+var _ gobot.SafeStater = (*RelayDriver)(nil)
+var _ gobot.StateReporter = (*RelayDriver)(nil)
 
 // RelayDriver represents a digital relay
 type RelayDriver struct {
 	pin        string
 	name       string
 	connection DigitalWriter
+	mutex      sync.Mutex
 	high       bool
 	gobot.Commander
 }
@@ -49,6 +56,22 @@ func (l *RelayDriver) Start() (errs []error) { return }
 // Halt implements the Driver interface
 func (l *RelayDriver) Halt() (errs []error) { return }
 
+// ReportState implements the gobot.StateReporter interface by reporting whether
+// the relay is on
+func (l *RelayDriver) ReportState() map[string]interface{} {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return map[string]interface{}{"on": l.high}
+}
+
+// SafeState implements the gobot.SafeStater interface by turning the relay off
+func (l *RelayDriver) SafeState() (errs []error) {
+	if err := l.Off(); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
+
 // Name returns the RelayDrivers name
 func (l *RelayDriver) Name() string { return l.name }
 
@@ -62,11 +85,26 @@ func (l *RelayDriver) Connection() gobot.Connection {
 
 // State return true if the relay is On and false if the relay is Off
 func (l *RelayDriver) State() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
 	return l.high
 }
 
 // On sets the relay to a high state.
 func (l *RelayDriver) On() (err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.on()
+}
+
+// Off sets the relay to a low state.
+func (l *RelayDriver) Off() (err error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.off()
+}
+
+func (l *RelayDriver) on() (err error) {
 	if err = l.connection.DigitalWrite(l.Pin(), 1); err != nil {
 		return
 	}
@@ -74,8 +112,7 @@ func (l *RelayDriver) On() (err error) {
 	return
 }
 
-// Off sets the relay to a low state.
-func (l *RelayDriver) Off() (err error) {
+func (l *RelayDriver) off() (err error) {
 	if err = l.connection.DigitalWrite(l.Pin(), 0); err != nil {
 		return
 	}
@@ -85,10 +122,12 @@ func (l *RelayDriver) Off() (err error) {
 
 // Toggle sets the relay to the opposite of it's current state
 func (l *RelayDriver) Toggle() (err error) {
-	if l.State() {
-		err = l.Off()
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if l.high {
+		err = l.off()
 	} else {
-		err = l.On()
+		err = l.on()
 	}
 	return
 }
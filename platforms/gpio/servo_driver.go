@@ -1,25 +1,41 @@
 package gpio
 
-import "github.com/hybridgroup/gobot"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
 
 var _ gobot.Driver = (*ServoDriver)(nil)
+var _ gobot.SafeStater = (*ServoDriver)(nil)
+var _ gobot.StateReporter = (*ServoDriver)(nil)
 
 // ServoDriver Represents a Servo
 type ServoDriver struct {
 	name       string
 	pin        string
 	connection ServoWriter
+	mutex      sync.Mutex
 	gobot.Commander
+	// CurrentAngle is the angle the servo was last moved to. Use Angle to
+	// read it while the servo may be moving.
 	CurrentAngle byte
+	// SafeAngle is the angle the servo is moved to by SafeState, 90 unless
+	// set otherwise.
+	SafeAngle byte
 }
 
 // NewServoDriver returns a new ServoDriver given a ServoWriter, name and pin.
 //
 // Adds the following API Commands:
-// 	"Move" - See ServoDriver.Move
+//
+//	"Move" - See ServoDriver.Move
 //	"Min" - See ServoDriver.Min
 //	"Center" - See ServoDriver.Center
 //	"Max" - See ServoDriver.Max
+//	"Sweep" - See ServoDriver.Sweep, runs asynchronously
 func NewServoDriver(a ServoWriter, name string, pin string) *ServoDriver {
 	s := &ServoDriver{
 		name:         name,
@@ -27,9 +43,16 @@ func NewServoDriver(a ServoWriter, name string, pin string) *ServoDriver {
 		pin:          pin,
 		Commander:    gobot.NewCommander(),
 		CurrentAngle: 0,
+		SafeAngle:    90,
 	}
 
-	s.AddCommand("Move", func(params map[string]interface{}) interface{} {
+	commander := s.Commander.(gobot.AsyncCommander)
+	commander.AddCommandSchema("Move", &gobot.CommandSchema{
+		Description: "Moves the servo to angle",
+		Params: []gobot.Param{
+			gobot.Param{Name: "angle", Type: gobot.IntegerParam, Required: true}.Range(0, 180),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		angle := byte(params["angle"].(float64))
 		return s.Move(angle)
 	})
@@ -43,6 +66,18 @@ func NewServoDriver(a ServoWriter, name string, pin string) *ServoDriver {
 		return s.Max()
 	})
 
+	commander.AddAsyncCommand("Sweep", &gobot.CommandSchema{
+		Description: "Sweeps the servo to angle, one degree every interval milliseconds",
+		Params: []gobot.Param{
+			gobot.Param{Name: "angle", Type: gobot.IntegerParam, Required: true}.Range(0, 180),
+			gobot.Param{Name: "interval", Type: gobot.IntegerParam, Default: 20.0}.Range(0, 1000),
+		},
+	}, func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		angle := byte(params["angle"].(float64))
+		interval := time.Duration(params["interval"].(float64)) * time.Millisecond
+		return s.Sweep(ctx, angle, interval, progress)
+	})
+
 	return s
 
 }
@@ -62,15 +97,68 @@ func (s *ServoDriver) Start() (errs []error) { return }
 // Halt implements the Driver interface
 func (s *ServoDriver) Halt() (errs []error) { return }
 
+// ReportState implements the gobot.StateReporter interface by reporting the
+// angle of the servo
+func (s *ServoDriver) ReportState() map[string]interface{} {
+	return map[string]interface{}{"angle": s.Angle()}
+}
+
+// Angle returns the angle the servo was last moved to
+func (s *ServoDriver) Angle() byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.CurrentAngle
+}
+
+// SafeState implements the gobot.SafeStater interface by moving the servo to its SafeAngle
+func (s *ServoDriver) SafeState() (errs []error) {
+	if err := s.Move(s.SafeAngle); err != nil {
+		errs = append(errs, err)
+	}
+	return
+}
+
 // Move sets the servo to the specified angle. Acceptable angles are 0-180
 func (s *ServoDriver) Move(angle uint8) (err error) {
 	if !(angle >= 0 && angle <= 180) {
 		return ErrServoOutOfRange
 	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 	s.CurrentAngle = angle
 	return s.connection.ServoWrite(s.Pin(), s.angleToSpan(angle))
 }
 
+// Sweep moves the servo one degree every interval until it reaches the specified
+// angle, reporting the fraction of the way done to progress. Stops early,
+// returning the error of ctx, when ctx is done.
+func (s *ServoDriver) Sweep(ctx context.Context, angle uint8, interval time.Duration, progress func(float64)) (err error) {
+	if angle > 180 {
+		return ErrServoOutOfRange
+	}
+	from := s.Angle()
+	steps := int(angle) - int(from)
+	if steps < 0 {
+		steps = -steps
+	}
+	for i := 1; i <= steps; i++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-gobot.Wait(interval):
+		}
+		next := int(from) + i
+		if angle < from {
+			next = int(from) - i
+		}
+		if err = s.Move(uint8(next)); err != nil {
+			return
+		}
+		progress(float64(i) / float64(steps))
+	}
+	return
+}
+
 // Min sets the servo to it's minimum position
 func (s *ServoDriver) Min() (err error) {
 	return s.Move(0)
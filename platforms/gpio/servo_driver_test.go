@@ -1,8 +1,10 @@
 package gpio
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 )
@@ -48,6 +50,25 @@ func TestServoDriverHalt(t *testing.T) {
 	gobot.Assert(t, len(d.Halt()), 0)
 }
 
+func TestServoDriverSafeState(t *testing.T) {
+	testAdaptorServoWrite = func() (err error) { return }
+	d := initTestServoDriver()
+	d.Max()
+	gobot.Assert(t, len(d.SafeState()), 0)
+	gobot.Assert(t, d.CurrentAngle, uint8(90))
+
+	d.SafeAngle = 0
+	gobot.Assert(t, len(d.SafeState()), 0)
+	gobot.Assert(t, d.CurrentAngle, uint8(0))
+}
+
+func TestServoDriverReportState(t *testing.T) {
+	testAdaptorServoWrite = func() (err error) { return }
+	d := initTestServoDriver()
+	d.Move(45)
+	gobot.Assert(t, d.ReportState(), map[string]interface{}{"angle": byte(45)})
+}
+
 func TestServoDriverMove(t *testing.T) {
 	d := initTestServoDriver()
 	d.Move(100)
@@ -73,3 +94,48 @@ func TestServoDriverCenter(t *testing.T) {
 	d.Center()
 	gobot.Assert(t, d.CurrentAngle, uint8(90))
 }
+
+func TestServoDriverSweep(t *testing.T) {
+	d := initTestServoDriver()
+	testAdaptorServoWrite = func() (err error) {
+		return nil
+	}
+
+	progress := []float64{}
+	err := d.Sweep(context.Background(), 4, 0, func(p float64) {
+		progress = append(progress, p)
+	})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, d.CurrentAngle, uint8(4))
+	gobot.Assert(t, progress, []float64{0.25, 0.5, 0.75, 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = d.Sweep(ctx, 0, time.Millisecond, func(float64) {})
+	gobot.Assert(t, err, context.Canceled)
+	gobot.Assert(t, d.CurrentAngle, uint8(4))
+
+	gobot.Refute(t, d.Commander.(gobot.AsyncCommander).AsyncCommand("Sweep"), (gobot.AsyncCommand)(nil))
+	err, _ = d.Command("Sweep")(map[string]interface{}{"angle": 2, "interval": 0}).(error)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, d.CurrentAngle, uint8(2))
+}
+
+func TestServoDriverAngle(t *testing.T) {
+	d := initTestServoDriver()
+	testAdaptorServoWrite = func() (err error) {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		d.Sweep(context.Background(), 10, 0, func(float64) {})
+		close(done)
+	}()
+	for i := 0; i < 10; i++ {
+		d.ReportState()
+	}
+	<-done
+	gobot.Assert(t, d.Angle(), uint8(10))
+	gobot.Assert(t, d.ReportState()["angle"], uint8(10))
+}
@@ -19,6 +19,7 @@ type BlinkMDriver struct {
 // NewBlinkMDriver creates a new BlinkMDriver with specified name.
 //
 // Adds the following API commands:
+//
 //	Rgb - sets RGB color
 //	Fade - fades the RGB color
 //	FirmwareVersion - returns the version of the current Frimware
@@ -30,13 +31,28 @@ func NewBlinkMDriver(a I2c, name string) *BlinkMDriver {
 		Commander:  gobot.NewCommander(),
 	}
 
-	b.AddCommand("Rgb", func(params map[string]interface{}) interface{} {
+	commander := b.Commander.(gobot.SchemaCommander)
+	commander.AddCommandSchema("Rgb", &gobot.CommandSchema{
+		Description: "Sets the color of the blinkm",
+		Params: []gobot.Param{
+			gobot.Param{Name: "red", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "green", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "blue", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		red := byte(params["red"].(float64))
 		green := byte(params["green"].(float64))
 		blue := byte(params["blue"].(float64))
 		return b.Rgb(red, green, blue)
 	})
-	b.AddCommand("Fade", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("Fade", &gobot.CommandSchema{
+		Description: "Fades the blinkm to color",
+		Params: []gobot.Param{
+			gobot.Param{Name: "red", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "green", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "blue", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		red := byte(params["red"].(float64))
 		green := byte(params["green"].(float64))
 		blue := byte(params["blue"].(float64))
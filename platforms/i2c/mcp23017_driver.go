@@ -19,7 +19,7 @@ package i2c
 import (
 	"bytes"
 	"encoding/binary"
-	"log"
+	"fmt"
 	"strings"
 	"time"
 
@@ -85,6 +85,7 @@ type MCP23017Driver struct {
 	conf            MCP23017Config
 	mcp23017Address int
 	interval        time.Duration
+	logger          gobot.Logger
 	gobot.Commander
 	gobot.Eventer
 }
@@ -96,18 +97,33 @@ func NewMCP23017Driver(a I2c, name string, conf MCP23017Config, deviceAddress in
 		connection:      a,
 		conf:            conf,
 		mcp23017Address: deviceAddress,
+		logger:          gobot.WithFields(nil, gobot.Fields{"device": name}),
 		Commander:       gobot.NewCommander(),
 		Eventer:         gobot.NewEventer(),
 	}
 
-	m.AddCommand("WriteGPIO", func(params map[string]interface{}) interface{} {
+	commander := m.Commander.(gobot.SchemaCommander)
+	commander.AddCommandSchema("WriteGPIO", &gobot.CommandSchema{
+		Description: "Writes val to pin of port",
+		Params: []gobot.Param{
+			gobot.Param{Name: "pin", Type: gobot.IntegerParam, Required: true}.Range(0, 7),
+			gobot.Param{Name: "val", Type: gobot.IntegerParam, Required: true}.Range(0, 1),
+			{Name: "port", Type: gobot.StringParam, Required: true, Description: "A or B"},
+		},
+	}, func(params map[string]interface{}) interface{} {
 		pin := params["pin"].(float64)
 		val := params["val"].(float64)
 		port := params["port"].(string)
 		return m.WriteGPIO(pin, val, port)
 	})
 
-	m.AddCommand("ReadGPIO", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("ReadGPIO", &gobot.CommandSchema{
+		Description: "Reads pin of port",
+		Params: []gobot.Param{
+			gobot.Param{Name: "pin", Type: gobot.IntegerParam, Required: true}.Range(0, 7),
+			{Name: "port", Type: gobot.StringParam, Required: true, Description: "A or B"},
+		},
+	}, func(params map[string]interface{}) interface{} {
 		pin := params["pin"].(float64)
 		port := params["port"].(string)
 		val, err := m.ReadGPIO(pin, port)
@@ -121,6 +137,9 @@ func (m *MCP23017Driver) Name() string { return m.name }
 
 func (m *MCP23017Driver) Connection() gobot.Connection { return m.connection.(gobot.Connection) }
 
+// SetLogger sets the Logger the register values read are logged to in Debug mode
+func (m *MCP23017Driver) SetLogger(l gobot.Logger) { m.logger = l }
+
 func (m *MCP23017Driver) Halt() (err []error) { return }
 
 // Start writes initialization bytes and reads.
@@ -217,7 +236,7 @@ func (m *MCP23017Driver) read(reg byte) (val uint8, err error) {
 		return val, err
 	}
 	if Debug {
-		log.Printf("Register addr:0x%X val: 0x%X\n", reg, v[bytesToRead])
+		m.logger.Log(gobot.DebugLevel, fmt.Sprintf("Register addr:0x%X val: 0x%X", reg, v[bytesToRead]), nil)
 	}
 	return v[bytesToRead], nil
 }
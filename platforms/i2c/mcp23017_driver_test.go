@@ -2,12 +2,10 @@ package i2c
 
 import (
 	"errors"
-	"io/ioutil"
-	"log"
-	"os"
 	"testing"
 
 	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/gobottest"
 )
 
 func initTestMCP23017Driver(b uint8) (driver *MCP23017Driver) {
@@ -211,8 +209,9 @@ func TestMCP23017DriverReadPort(t *testing.T) {
 
 	// debug
 	Debug = true
-	log.SetOutput(ioutil.Discard)
 	mcp, adaptor = initTestMCP23017DriverWithStubbedAdaptor(0)
+	logger := gobottest.NewFakeLogger()
+	mcp.SetLogger(logger)
 	port = mcp.getPort("A")
 
 	adaptor.i2cReadImpl = func() ([]byte, error) {
@@ -221,8 +220,10 @@ func TestMCP23017DriverReadPort(t *testing.T) {
 
 	val, _ = mcp.read(port.IODIR)
 	gobot.Assert(t, val, uint8(255))
+	gobot.Assert(t, logger.Records(), []gobottest.LogRecord{
+		{Level: gobot.DebugLevel, Msg: "Register addr:0x0 val: 0xFF"},
+	})
 	Debug = false
-	log.SetOutput(os.Stdout)
 }
 
 func TestMCP23017DriverGetPort(t *testing.T) {
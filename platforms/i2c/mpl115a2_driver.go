@@ -100,7 +100,7 @@ func (h *MPL115A2Driver) Start() (errs []error) {
 				h.Pressure = (65.0/1023.0)*pressureComp + 50.0
 				h.Temperature = ((float32(temperature) - 498.0) / -5.35) + 25.0
 			}
-			<-time.After(h.interval)
+			<-gobot.Wait(h.interval)
 		}
 	}()
 	return
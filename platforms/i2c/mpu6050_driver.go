@@ -9,6 +9,7 @@ import (
 )
 
 var _ gobot.Driver = (*MPU6050Driver)(nil)
+var _ gobot.StateReporter = (*MPU6050Driver)(nil)
 
 const mpu6050Address = 0x68
 
@@ -86,7 +87,7 @@ func (h *MPU6050Driver) Start() (errs []error) {
 			binary.Read(buf, binary.BigEndian, &h.Accelerometer)
 			binary.Read(buf, binary.BigEndian, &h.Gyroscope)
 			binary.Read(buf, binary.BigEndian, &h.Temperature)
-			<-time.After(h.interval)
+			<-gobot.Wait(h.interval)
 		}
 	}()
 	return
@@ -95,6 +96,16 @@ func (h *MPU6050Driver) Start() (errs []error) {
 // Halt returns true if devices is halted successfully
 func (h *MPU6050Driver) Halt() (errs []error) { return }
 
+// ReportState implements the gobot.StateReporter interface by reporting the
+// last readings of the sensor
+func (h *MPU6050Driver) ReportState() map[string]interface{} {
+	return map[string]interface{}{
+		"accelerometer": h.Accelerometer,
+		"gyroscope":     h.Gyroscope,
+		"temperature":   h.Temperature,
+	}
+}
+
 func (h *MPU6050Driver) initialize() (err error) {
 	if err = h.connection.I2cStart(mpu6050Address); err != nil {
 		return
@@ -51,3 +51,14 @@ func TestMPU6050DriverHalt(t *testing.T) {
 
 	gobot.Assert(t, len(mpu.Halt()), 0)
 }
+
+func TestMPU6050DriverReportState(t *testing.T) {
+	mpu := initTestMPU6050Driver()
+	mpu.Accelerometer = ThreeDData{X: 1, Y: 2, Z: 3}
+	mpu.Temperature = 20
+	gobot.Assert(t, mpu.ReportState(), map[string]interface{}{
+		"accelerometer": ThreeDData{X: 1, Y: 2, Z: 3},
+		"gyroscope":     ThreeDData{},
+		"temperature":   int16(20),
+	})
+}
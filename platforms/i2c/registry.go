@@ -0,0 +1,69 @@
+package i2c
+
+import "github.com/hybridgroup/gobot"
+
+func init() {
+	register("i2c.blinkm", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewBlinkMDriver(a, s.Name), nil
+	})
+	register("i2c.grove_lcd", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewGroveLcdDriver(a, s.Name), nil
+	})
+	register("i2c.grove_accelerometer", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewGroveAccelerometerDriver(a, s.Name), nil
+	})
+	register("i2c.hmc6352", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewHMC6352Driver(a, s.Name), nil
+	})
+	register("i2c.jhd1313m1", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewJHD1313M1Driver(a, s.Name), nil
+	})
+	register("i2c.lidarlite", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewLIDARLiteDriver(a, s.Name), nil
+	})
+	register("i2c.mma7660", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewMMA7660Driver(a, s.Name), nil
+	})
+	register("i2c.mpl115a2", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewMPL115A2Driver(a, s.Name, s.Intervals()...), nil
+	})
+	register("i2c.mpu6050", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewMPU6050Driver(a, s.Name, s.Intervals()...), nil
+	})
+	register("i2c.wiichuck", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		return NewWiichuckDriver(a, s.Name, s.Intervals()...), nil
+	})
+	register("i2c.mcp23017", func(a I2c, s gobot.DriverSpec) (gobot.Driver, error) {
+		address, err := s.Params.Int("address", 0x20)
+		if err != nil {
+			return nil, err
+		}
+		conf := MCP23017Config{}
+		for key, bit := range map[string]*uint8{
+			"bank":   &conf.Bank,
+			"mirror": &conf.Mirror,
+			"seqop":  &conf.Seqop,
+			"disslw": &conf.Disslw,
+			"haen":   &conf.Haen,
+			"odr":    &conf.Odr,
+			"intpol": &conf.Intpol,
+		} {
+			v, err := s.Params.Int(key, 0)
+			if err != nil {
+				return nil, err
+			}
+			*bit = uint8(v)
+		}
+		return NewMCP23017Driver(a, s.Name, conf, address, s.Intervals()...), nil
+	})
+}
+
+func register(kind string, f func(I2c, gobot.DriverSpec) (gobot.Driver, error)) {
+	gobot.RegisterDriver(kind, func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		a, ok := c.(I2c)
+		if !ok {
+			return nil, ErrI2cUnsupported
+		}
+		return f(a, s)
+	})
+}
@@ -83,7 +83,7 @@ func (w *WiichuckDriver) Start() (errs []error) {
 					continue
 				}
 			}
-			<-time.After(w.interval)
+			<-gobot.Wait(w.interval)
 		}
 	}()
 	return
@@ -504,7 +504,8 @@ func (e *EdisonAdaptor) I2cWrite(address int, data []byte) (err error) {
 	if err = e.i2cDevice.SetAddress(address); err != nil {
 		return err
 	}
-	_, err = e.i2cDevice.Write(data)
+	n, err := e.i2cDevice.Write(data)
+	gobot.BytesWritten("i2c", e.Name(), n)
 	return
 }
 
@@ -514,6 +515,7 @@ func (e *EdisonAdaptor) I2cRead(address int, size int) (data []byte, err error)
 	if err = e.i2cDevice.SetAddress(address); err != nil {
 		return
 	}
-	_, err = e.i2cDevice.Read(data)
+	n, err := e.i2cDevice.Read(data)
+	gobot.BytesRead("i2c", e.Name(), n)
 	return
 }
@@ -0,0 +1,9 @@
+package edison
+
+import "github.com/hybridgroup/gobot"
+
+func init() {
+	gobot.RegisterAdaptor("edison", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		return NewEdisonAdaptor(s.Name), nil
+	})
+}
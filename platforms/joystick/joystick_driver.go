@@ -62,7 +62,6 @@ func NewJoystickDriver(a *JoystickAdaptor, name string, config string, v ...time
 			return sdl.PollEvent()
 		},
 		interval: 10 * time.Millisecond,
-		halt:     make(chan bool, 0),
 	}
 
 	if len(v) > 0 {
@@ -114,6 +113,8 @@ func (j *JoystickDriver) Start() (errs []error) {
 		j.AddEvent(value.Name)
 	}
 
+	halt := make(chan bool)
+	j.halt = halt
 	go func() {
 		for {
 			event := j.poll()
@@ -124,7 +125,7 @@ func (j *JoystickDriver) Start() (errs []error) {
 			}
 			select {
 			case <-time.After(j.interval):
-			case <-j.halt:
+			case <-halt:
 				return
 			}
 		}
@@ -134,7 +135,10 @@ func (j *JoystickDriver) Start() (errs []error) {
 
 // Halt stops joystick driver
 func (j *JoystickDriver) Halt() (errs []error) {
-	j.halt <- true
+	if j.halt != nil {
+		close(j.halt)
+		j.halt = nil
+	}
 	return
 }
 
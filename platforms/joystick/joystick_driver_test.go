@@ -31,9 +31,11 @@ func TestJoystickDriverStart(t *testing.T) {
 
 func TestJoystickDriverHalt(t *testing.T) {
 	d := initTestJoystickDriver()
-	go func() {
-		<-d.halt
-	}()
+	// halting a driver which was never started must not block
+	gobot.Assert(t, len(d.Halt()), 0)
+
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Assert(t, len(d.Halt()), 0)
 	gobot.Assert(t, len(d.Halt()), 0)
 }
 
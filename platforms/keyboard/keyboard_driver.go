@@ -1,7 +1,6 @@
 package keyboard
 
 import (
-	"log"
 	"os"
 
 	"github.com/hybridgroup/gobot"
@@ -14,6 +13,7 @@ type KeyboardDriver struct {
 	connect func(*KeyboardDriver) (err error)
 	listen  func(*KeyboardDriver)
 	stdin   *os.File
+	logger  gobot.Logger
 	gobot.Eventer
 }
 
@@ -38,7 +38,8 @@ func NewKeyboardDriver(name string) *KeyboardDriver {
 				if keybuf == ctrlc {
 					proc, err := os.FindProcess(os.Getpid())
 					if err != nil {
-						log.Fatal(err)
+						k.logger.Log(gobot.ErrorLevel, err.Error(), nil)
+						break
 					}
 
 					proc.Signal(os.Interrupt)
@@ -49,6 +50,7 @@ func NewKeyboardDriver(name string) *KeyboardDriver {
 
 			}
 		},
+		logger:  gobot.WithFields(nil, gobot.Fields{"device": name}),
 		Eventer: gobot.NewEventer(),
 	}
 
@@ -60,6 +62,9 @@ func NewKeyboardDriver(name string) *KeyboardDriver {
 func (k *KeyboardDriver) Name() string                 { return k.name }
 func (k *KeyboardDriver) Connection() gobot.Connection { return nil }
 
+// SetLogger sets the Logger errors reading the keyboard are logged to
+func (k *KeyboardDriver) SetLogger(l gobot.Logger) { k.logger = l }
+
 // Start initializes keyboard by grabbing key events as they come in and
 // publishing a key event
 func (k *KeyboardDriver) Start() (errs []error) {
@@ -1,19 +1,26 @@
 package mavlink
 
 import (
+	"context"
 	"io"
+	"sync"
 
 	"github.com/hybridgroup/gobot"
 	"github.com/tarm/goserial"
 )
 
 var _ gobot.Adaptor = (*MavlinkAdaptor)(nil)
+var _ gobot.HealthChecker = (*MavlinkAdaptor)(nil)
+var _ gobot.Reconnector = (*MavlinkAdaptor)(nil)
+var _ gobot.ContextConnector = (*MavlinkAdaptor)(nil)
 
 type MavlinkAdaptor struct {
 	name    string
 	port    string
 	sp      io.ReadWriteCloser
 	connect func(string) (io.ReadWriteCloser, error)
+	mutex   sync.Mutex
+	err     error
 }
 
 // NewMavLinkAdaptor creates a new mavlink adaptor with specified name and port
@@ -32,17 +39,58 @@ func (m *MavlinkAdaptor) Port() string { return m.port }
 
 // Connect returns true if connection to device is successful
 func (m *MavlinkAdaptor) Connect() (errs []error) {
-	if sp, err := m.connect(m.Port()); err != nil {
+	return m.ConnectContext(context.Background())
+}
+
+// ConnectContext opens the serial port like Connect, giving up once ctx is
+// done, in which case a port opened afterwards is closed.
+func (m *MavlinkAdaptor) ConnectContext(ctx context.Context) (errs []error) {
+	sp, err := gobot.OpenContext(ctx, func() (io.ReadWriteCloser, error) {
+		return m.connect(m.Port())
+	})
+	if err != nil {
 		return []error{err}
-	} else {
-		m.sp = sp
 	}
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.sp = gobot.MeterReadWriteCloser(sp, "serial", m.Name())
+	m.err = nil
 	return
 }
 
+// Reconnect closes the serial port and opens it again
+func (m *MavlinkAdaptor) Reconnect() (errs []error) {
+	if sp := m.serialPort(); sp != nil {
+		sp.Close()
+	}
+	return m.Connect()
+}
+
+// serialPort returns the current serial port, which Reconnect may replace
+// while the driver is reading from it
+func (m *MavlinkAdaptor) serialPort() io.ReadWriteCloser {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.sp
+}
+
+// Healthy returns the last error reading from the serial port, or nil if the
+// last read succeeded
+func (m *MavlinkAdaptor) Healthy() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.err
+}
+
+func (m *MavlinkAdaptor) setErr(err error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.err = err
+}
+
 // Finalize returns true if connection to devices is closed successfully
 func (m *MavlinkAdaptor) Finalize() (errs []error) {
-	if err := m.sp.Close(); err != nil {
+	if err := m.serialPort().Close(); err != nil {
 		return []error{err}
 	}
 	return
@@ -1,9 +1,11 @@
 package mavlink
 
 import (
+	"context"
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 )
@@ -70,3 +72,45 @@ func TestMavlinkAdaptorFinalize(t *testing.T) {
 	}
 	gobot.Assert(t, a.Finalize()[0], errors.New("close error"))
 }
+
+func TestMavlinkAdaptorReconnect(t *testing.T) {
+	a := initTestMavlinkAdaptor()
+	a.setErr(errors.New("read error"))
+	gobot.Assert(t, a.Healthy(), errors.New("read error"))
+
+	connected := false
+	a.connect = func(port string) (io.ReadWriteCloser, error) {
+		connected = true
+		return nullReadWriteCloser{}, nil
+	}
+	gobot.Assert(t, len(a.Reconnect()), 0)
+	gobot.Assert(t, connected, true)
+	gobot.Assert(t, a.Healthy(), nil)
+}
+
+func TestMavlinkAdaptorConnectContext(t *testing.T) {
+	a := initTestMavlinkAdaptor()
+	release := make(chan bool)
+	a.connect = func(string) (io.ReadWriteCloser, error) {
+		<-release
+		return &nullReadWriteCloser{}, nil
+	}
+	closed := make(chan bool, 1)
+	testAdaptorClose = func() error {
+		closed <- true
+		return nil
+	}
+	defer func() { testAdaptorClose = func() error { return nil } }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	gobot.Assert(t, a.ConnectContext(ctx), []error{context.DeadlineExceeded})
+
+	// the port opened after giving up is closed
+	close(release)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Error("serial port was not closed")
+	}
+}
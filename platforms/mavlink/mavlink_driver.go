@@ -13,6 +13,7 @@ type MavlinkDriver struct {
 	name       string
 	connection gobot.Connection
 	interval   time.Duration
+	halt       chan bool
 	gobot.Eventer
 }
 
@@ -55,31 +56,44 @@ func (m *MavlinkDriver) adaptor() *MavlinkAdaptor {
 // Start begins process to read mavlink packets every m.Interval
 // and process them
 func (m *MavlinkDriver) Start() (errs []error) {
+	halt := make(chan bool)
+	m.halt = halt
 	go func() {
 		for {
-			packet, err := common.ReadMAVLinkPacket(m.adaptor().sp)
+			packet, err := common.ReadMAVLinkPacket(m.adaptor().serialPort())
+			m.adaptor().setErr(err)
 			if err != nil {
 				gobot.Publish(m.Event("errorIO"), err)
-				continue
+			} else {
+				gobot.Publish(m.Event("packet"), packet)
+				message, err := packet.MAVLinkMessage()
+				if err != nil {
+					gobot.Publish(m.Event("errorMAVLink"), err)
+				} else {
+					gobot.Publish(m.Event("message"), message)
+				}
 			}
-			gobot.Publish(m.Event("packet"), packet)
-			message, err := packet.MAVLinkMessage()
-			if err != nil {
-				gobot.Publish(m.Event("errorMAVLink"), err)
-				continue
+			select {
+			case <-halt:
+				return
+			case <-time.After(m.interval):
 			}
-			gobot.Publish(m.Event("message"), message)
-			<-time.After(m.interval)
 		}
 	}()
 	return
 }
 
-// Halt returns true if device is halted successfully
-func (m *MavlinkDriver) Halt() (errs []error) { return }
+// Halt stops reading mavlink packets
+func (m *MavlinkDriver) Halt() (errs []error) {
+	if m.halt != nil {
+		close(m.halt)
+		m.halt = nil
+	}
+	return
+}
 
 // SendPacket sends a packet to mavlink device
 func (m *MavlinkDriver) SendPacket(packet *common.MAVLinkPacket) (err error) {
-	_, err = m.adaptor().sp.Write(packet.Pack())
+	_, err = m.adaptor().serialPort().Write(packet.Pack())
 	return err
 }
@@ -1,6 +1,7 @@
 package mavlink
 
 import (
+	"bytes"
 	"errors"
 	"io"
 	"testing"
@@ -85,3 +86,45 @@ func TestMavlinkDriverHalt(t *testing.T) {
 	d := initTestMavlinkDriver()
 	gobot.Assert(t, len(d.Halt()), 0)
 }
+
+func TestMavlinkDriverRestart(t *testing.T) {
+	d := initTestMavlinkDriver()
+	gobot.Assert(t, len(d.Start()), 0)
+	halt := d.halt
+	gobot.Assert(t, len(d.Halt()), 0)
+
+	select {
+	case <-halt:
+	default:
+		t.Errorf("reading was not halted")
+	}
+
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Refute(t, d.halt, halt)
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+type testPacketPort struct {
+	*bytes.Reader
+}
+
+func (testPacketPort) Write(b []byte) (int, error) { return len(b), nil }
+func (testPacketPort) Close() error                { return nil }
+
+func TestMavlinkDriverReconnectWhileReading(t *testing.T) {
+	packet := []byte{0xFE, 0x09, 0x4E, 0x01, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0x03, 0x51, 0x04, 0x03, 0x1C, 0x7F}
+	m := NewMavlinkAdaptor("myAdaptor", "/dev/null")
+	m.connect = func(port string) (io.ReadWriteCloser, error) {
+		return testPacketPort{bytes.NewReader(packet)}, nil
+	}
+	gobot.Assert(t, len(m.Connect()), 0)
+
+	d := NewMavlinkDriver(m, "myDriver", time.Millisecond)
+	gobot.Assert(t, len(d.Start()), 0)
+	for i := 0; i < 20; i++ {
+		gobot.Assert(t, len(m.Reconnect()), 0)
+		gobot.Assert(t, d.SendPacket(&common.MAVLinkPacket{}), nil)
+		time.Sleep(time.Millisecond)
+	}
+	gobot.Assert(t, len(d.Halt()), 0)
+}
@@ -0,0 +1,20 @@
+package mavlink
+
+import (
+	"errors"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func init() {
+	gobot.RegisterAdaptor("mavlink", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		return NewMavlinkAdaptor(s.Name, s.Port), nil
+	})
+	gobot.RegisterDriver("mavlink", func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		a, ok := c.(*MavlinkAdaptor)
+		if !ok {
+			return nil, errors.New("mavlink driver requires a mavlink connection")
+		}
+		return NewMavlinkDriver(a, s.Name, s.Intervals()...), nil
+	})
+}
@@ -34,7 +34,7 @@ func (n *NeuroskyAdaptor) Connect() (errs []error) {
 	if sp, err := n.connect(n); err != nil {
 		return []error{err}
 	} else {
-		n.sp = sp
+		n.sp = gobot.MeterReadWriteCloser(sp, "serial", n.Name())
 	}
 	return
 }
@@ -16,13 +16,16 @@ type PebbleDriver struct {
 
 // NewPebbleDriver creates a new pebble driver with specified name
 // Adds following events:
-//		button - Sent when a pebble button is pressed
-//		accel - Pebble watch acceleromenter data
-//		tab - When a pebble watch tap event is detected
+//
+//	button - Sent when a pebble button is pressed
+//	accel - Pebble watch acceleromenter data
+//	tab - When a pebble watch tap event is detected
+//
 // And the following API commands:
-//		"publish_event"
-//		"send_notification"
-//		"pending_message"
+//
+//	"publish_event"
+//	"send_notification"
+//	"pending_message"
 func NewPebbleDriver(adaptor *PebbleAdaptor, name string) *PebbleDriver {
 	p := &PebbleDriver{
 		name:       name,
@@ -36,12 +39,24 @@ func NewPebbleDriver(adaptor *PebbleAdaptor, name string) *PebbleDriver {
 	p.AddEvent("accel")
 	p.AddEvent("tap")
 
-	p.AddCommand("publish_event", func(params map[string]interface{}) interface{} {
+	commander := p.Commander.(gobot.SchemaCommander)
+	commander.AddCommandSchema("publish_event", &gobot.CommandSchema{
+		Description: "Publishes an event with data",
+		Params: []gobot.Param{
+			{Name: "name", Type: gobot.StringParam, Required: true},
+			{Name: "data", Type: gobot.StringParam, Required: true},
+		},
+	}, func(params map[string]interface{}) interface{} {
 		p.PublishEvent(params["name"].(string), params["data"].(string))
 		return nil
 	})
 
-	p.AddCommand("send_notification", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("send_notification", &gobot.CommandSchema{
+		Description: "Sends a notification to the pebble",
+		Params: []gobot.Param{
+			{Name: "message", Type: gobot.StringParam, Required: true},
+		},
+	}, func(params map[string]interface{}) interface{} {
 		p.SendNotification(params["message"].(string))
 		return nil
 	})
@@ -263,7 +263,8 @@ func (r *RaspiAdaptor) I2cWrite(address int, data []byte) (err error) {
 	if err = r.i2cDevice.SetAddress(address); err != nil {
 		return
 	}
-	_, err = r.i2cDevice.Write(data)
+	n, err := r.i2cDevice.Write(data)
+	gobot.BytesWritten("i2c", r.Name(), n)
 	return
 }
 
@@ -273,7 +274,8 @@ func (r *RaspiAdaptor) I2cRead(address int, size int) (data []byte, err error) {
 		return
 	}
 	data = make([]byte, size)
-	_, err = r.i2cDevice.Read(data)
+	n, err := r.i2cDevice.Read(data)
+	gobot.BytesRead("i2c", r.Name(), n)
 	return
 }
 
@@ -0,0 +1,9 @@
+package raspi
+
+import "github.com/hybridgroup/gobot"
+
+func init() {
+	gobot.RegisterAdaptor("raspi", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		return NewRaspiAdaptor(s.Name), nil
+	})
+}
@@ -0,0 +1,57 @@
+/*
+Package replay records the events of robots and plays them back.
+
+A Recorder writes every event of every robot and device of a Gobot to a
+compact recording, such as a file:
+
+	f, _ := os.Create("field.rec")
+	recorder := replay.NewRecorder(gbot, f)
+	recorder.Start()
+	defer recorder.Stop()
+
+Event data is gob encoded, so the concrete types of data which are not builtin
+types must be registered with Register before recording and replaying.
+
+A ReplayAdaptor plays a recording back to ReplayDrivers, which republish the
+records of the device of the same name on identically named events. Work
+written for the recorded devices runs unchanged against the recording:
+
+	package main
+
+	import (
+		"fmt"
+
+		"github.com/hybridgroup/gobot"
+		"github.com/hybridgroup/gobot/platforms/replay"
+	)
+
+	func main() {
+		gbot := gobot.NewGobot()
+
+		replayAdaptor := replay.NewReplayAdaptor("replay", "field.rec")
+		replayAdaptor.Speed = 2
+		sensor := replay.NewReplayDriver(replayAdaptor, "sensor")
+
+		work := func() {
+			gobot.On(sensor.Event("data"), func(data interface{}) {
+				fmt.Println("sensor", data)
+			})
+			replayAdaptor.Play()
+		}
+
+		robot := gobot.NewRobot("bot",
+			[]gobot.Connection{replayAdaptor},
+			[]gobot.Device{sensor},
+			work,
+		)
+
+		gbot.AddRobot(robot)
+
+		gbot.Start()
+	}
+
+Playing starts once Play is called, after the callbacks are subscribed.
+Records are published in order from a single goroutine and delivered to
+callbacks in order, so replaying a recording is deterministic.
+*/
+package replay
@@ -0,0 +1,118 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/gob"
+	"errors"
+	"io"
+	"os"
+	"sort"
+	"time"
+)
+
+// magic starts every recording, followed by a stream of gob encoded Records.
+const magic = "GOBOTREC1\n"
+
+// ErrNotRecording is the error resulting from reading data which is not a recording
+var ErrNotRecording = errors.New("replay: not a gobot recording")
+
+// Record is an event published while recording.
+type Record struct {
+	// Offset is the time elapsed since the recording started.
+	Offset time.Duration
+	Robot  string
+	// Device is the name of the device which published the event, or empty for
+	// an event of the robot itself.
+	Device string
+	Event  string
+	Data   interface{}
+}
+
+func init() {
+	Register(map[string]interface{}{})
+	Register([]interface{}{})
+	Register(map[string]string{})
+}
+
+// Register records the concrete type of value so event data of that type can
+// be recorded and replayed. Builtin types such as numbers, strings and byte
+// slices need not be registered.
+func Register(value interface{}) {
+	gob.Register(value)
+}
+
+// Writer writes Records to a recording.
+type Writer struct {
+	w   *bufio.Writer
+	enc *gob.Encoder
+}
+
+// NewWriter returns a new Writer writing a recording to w.
+func NewWriter(w io.Writer) (*Writer, error) {
+	b := bufio.NewWriter(w)
+	if _, err := b.WriteString(magic); err != nil {
+		return nil, err
+	}
+	return &Writer{w: b, enc: gob.NewEncoder(b)}, nil
+}
+
+// Write writes a Record. Returns an error if the type of its data is not
+// registered, in which case nothing is written.
+func (w *Writer) Write(r Record) error {
+	return w.enc.Encode(&r)
+}
+
+// Flush writes any buffered Records to the underlying writer.
+func (w *Writer) Flush() error {
+	return w.w.Flush()
+}
+
+// Reader reads Records from a recording.
+type Reader struct {
+	dec *gob.Decoder
+}
+
+// NewReader returns a new Reader reading a recording from r. Returns
+// ErrNotRecording if r does not start with a recording.
+func NewReader(r io.Reader) (*Reader, error) {
+	b := bufio.NewReader(r)
+	header := make([]byte, len(magic))
+	if _, err := io.ReadFull(b, header); err != nil || string(header) != magic {
+		return nil, ErrNotRecording
+	}
+	return &Reader{dec: gob.NewDecoder(b)}, nil
+}
+
+// Read returns the next Record. Returns io.EOF at the end of the recording.
+func (r *Reader) Read() (rec Record, err error) {
+	err = r.dec.Decode(&rec)
+	return
+}
+
+// ReadFile returns all Records of the recording at path ordered by offset.
+func ReadFile(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader, err := NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	records := []Record{}
+	for {
+		rec, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		records = append(records, rec)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Offset < records[j].Offset
+	})
+	return records, nil
+}
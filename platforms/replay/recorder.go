@@ -0,0 +1,117 @@
+package replay
+
+import (
+	"io"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// Recorder writes every event of every robot of a Gobot and of their devices
+// to a recording.
+//
+// Each event is recorded in order through a queue of QueueSize values, which
+// blocks the publisher when full so no data is lost. Records of different
+// events may be slightly out of order; ReadFile sorts them by offset.
+type Recorder struct {
+	gobot   *gobot.Gobot
+	out     io.Writer
+	mutex   sync.Mutex
+	w       *Writer
+	start   time.Time
+	subs    []*gobot.Subscription
+	err     error
+	count   uint64
+	skipped map[string]bool
+}
+
+// QueueSize is the number of values of an event waiting to be recorded
+const QueueSize = 1024
+
+// NewRecorder returns a new Recorder writing the events of the robots of g to w.
+func NewRecorder(g *gobot.Gobot, w io.Writer) *Recorder {
+	return &Recorder{gobot: g, out: w}
+}
+
+// Start writes the start of the recording and subscribes to the events of the
+// robots and devices of the Gobot. Devices attached afterwards are not
+// recorded.
+func (r *Recorder) Start() (err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.w, err = NewWriter(r.out); err != nil {
+		return
+	}
+	r.start = gobot.Now()
+	r.skipped = make(map[string]bool)
+
+	r.gobot.Robots().Each(func(robot *gobot.Robot) {
+		r.subscribe(robot.Name, "", robot)
+		robot.Devices().Each(func(d gobot.Device) {
+			if e, ok := d.(gobot.Eventer); ok {
+				r.subscribe(robot.Name, d.Name(), e)
+			}
+		})
+	})
+	return
+}
+
+// Stop unsubscribes from all events and flushes the recording. Events still
+// waiting in their queue are not recorded. Returns the first error writing the
+// recording.
+func (r *Recorder) Stop() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for _, sub := range r.subs {
+		sub.Unsubscribe()
+	}
+	r.subs = nil
+	if r.w != nil {
+		if err := r.w.Flush(); err != nil && r.err == nil {
+			r.err = err
+		}
+	}
+	return r.err
+}
+
+// Count returns the number of records written so far.
+func (r *Recorder) Count() uint64 {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.count
+}
+
+func (r *Recorder) subscribe(robot, device string, e gobot.Eventer) {
+	for name, event := range e.Events() {
+		name := name
+		sub, _ := gobot.OnQueuedSample(event, QueueSize, gobot.Block, func(s gobot.Sample) {
+			r.record(Record{Robot: robot, Device: device, Event: name, Data: s.Data}, s.Time)
+		})
+		r.subs = append(r.subs, sub)
+	}
+}
+
+// record writes rec, published at t.
+func (r *Recorder) record(rec Record, t time.Time) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.subs == nil || r.err != nil {
+		return
+	}
+	rec.Offset = t.Sub(r.start)
+	if err := r.w.Write(rec); err != nil {
+		// the data of this event cannot be encoded, which is only logged once
+		key := rec.Robot + "/" + rec.Device + "/" + rec.Event
+		if !r.skipped[key] {
+			r.skipped[key] = true
+			r.gobot.Logger().Log(gobot.WarnLevel, "Recorder: skipping event "+key+": "+err.Error(), gobot.Fields{
+				"robot":  rec.Robot,
+				"device": rec.Device,
+				"event":  rec.Event,
+			})
+		}
+		return
+	}
+	r.count++
+}
@@ -0,0 +1,159 @@
+package replay
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/gobottest"
+)
+
+type testDevice struct {
+	name string
+	gobot.Eventer
+}
+
+func (t *testDevice) Start() (errs []error)        { return }
+func (t *testDevice) Halt() (errs []error)         { return }
+func (t *testDevice) Name() string                 { return t.name }
+func (t *testDevice) Connection() gobot.Connection { return nil }
+
+type unregistered struct{ X int }
+
+// writeTestRecording writes records to a temporary file and returns its path.
+func writeTestRecording(t *testing.T, records ...Record) string {
+	dir, err := ioutil.TempDir("", "replay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "test.rec")
+	f, _ := os.Create(path)
+	defer f.Close()
+	w, _ := NewWriter(f)
+	for _, rec := range records {
+		if err := w.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Flush()
+	return path
+}
+
+func TestWriterReader(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, w.Write(Record{Offset: time.Second, Robot: "bot", Device: "sensor", Event: "data", Data: 42}), nil)
+	gobot.Assert(t, w.Write(Record{Robot: "bot", Event: "state", Data: map[string]interface{}{"a": "b"}}), nil)
+	gobot.Refute(t, w.Write(Record{Data: unregistered{1}}), nil)
+	gobot.Assert(t, w.Write(Record{Event: "nil"}), nil)
+	w.Flush()
+
+	r, err := NewReader(&buf)
+	gobot.Assert(t, err, nil)
+	rec, _ := r.Read()
+	gobot.Assert(t, rec, Record{Offset: time.Second, Robot: "bot", Device: "sensor", Event: "data", Data: 42})
+	rec, _ = r.Read()
+	gobot.Assert(t, rec.Data, map[string]interface{}{"a": "b"})
+	rec, _ = r.Read()
+	gobot.Assert(t, rec, Record{Event: "nil"})
+	_, err = r.Read()
+	gobot.Assert(t, err, io.EOF)
+
+	_, err = NewReader(bytes.NewBufferString("not a recording"))
+	gobot.Assert(t, err, ErrNotRecording)
+}
+
+func TestReadFile(t *testing.T) {
+	path := writeTestRecording(t,
+		Record{Offset: 2, Event: "b"},
+		Record{Offset: 1, Event: "a"},
+	)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	records, err := ReadFile(path)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, records[0].Event, "a")
+	gobot.Assert(t, records[1].Event, "b")
+
+	_, err = ReadFile(filepath.Join(filepath.Dir(path), "missing.rec"))
+	gobot.Refute(t, err, nil)
+}
+
+func TestRecorderOffset(t *testing.T) {
+	clock := gobottest.NewFakeClock(time.Date(2015, 1, 1, 0, 0, 0, 0, time.UTC))
+	defer gobot.SetClock(gobot.SetClock(clock))
+	sensor := &testDevice{name: "sensor", Eventer: gobot.NewEventer()}
+	sensor.AddEvent("data")
+	g := gobot.NewGobot()
+	g.AddRobot(gobot.NewRobot("bot", []gobot.Device{sensor}))
+
+	var buf bytes.Buffer
+	recorder := NewRecorder(g, &buf)
+	gobot.Assert(t, recorder.Start(), nil)
+
+	// records are stamped when published, not when taken off the queue
+	clock.Advance(5 * time.Second)
+	gobot.Publish(sensor.Event("data"), 1)
+	clock.Advance(time.Minute)
+	for recorder.Count() == 0 {
+		<-time.After(time.Millisecond)
+	}
+	gobot.Assert(t, recorder.Stop(), nil)
+
+	r, _ := NewReader(&buf)
+	for {
+		rec, err := r.Read()
+		if err != nil {
+			break
+		}
+		if rec.Device == "sensor" {
+			gobot.Assert(t, rec.Offset, 5*time.Second)
+		}
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	sensor := &testDevice{name: "sensor", Eventer: gobot.NewEventer()}
+	sensor.AddEvent("data")
+	sensor.AddEvent("raw")
+	g := gobot.NewGobot()
+	g.AddRobot(gobot.NewRobot("bot", []gobot.Device{sensor}))
+
+	var buf bytes.Buffer
+	recorder := NewRecorder(g, &buf)
+	gobot.Assert(t, recorder.Start(), nil)
+
+	gobot.Publish(sensor.Event("data"), 1)
+	gobot.Publish(sensor.Event("data"), 2)
+	gobot.Publish(sensor.Event("raw"), unregistered{1})
+	gobot.Publish(g.Robot("bot").Event(gobot.StateEvent), "running")
+	<-time.After(20 * time.Millisecond)
+	gobot.Assert(t, recorder.Count(), uint64(3))
+	gobot.Assert(t, recorder.Stop(), nil)
+
+	gobot.Publish(sensor.Event("data"), 3)
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, recorder.Count(), uint64(3))
+
+	r, _ := NewReader(&buf)
+	data := []interface{}{}
+	for {
+		rec, err := r.Read()
+		if err != nil {
+			break
+		}
+		if rec.Device == "sensor" {
+			gobot.Assert(t, rec.Robot, "bot")
+			data = append(data, rec.Data)
+		} else {
+			gobot.Assert(t, rec.Event, gobot.StateEvent)
+		}
+	}
+	gobot.Assert(t, data, []interface{}{1, 2})
+}
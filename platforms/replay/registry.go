@@ -0,0 +1,36 @@
+package replay
+
+import (
+	"errors"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func init() {
+	gobot.RegisterAdaptor("replay", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		a := NewReplayAdaptor(s.Name, s.Port)
+		var err error
+		if a.Speed, err = s.Params.Float("speed", a.Speed); err != nil {
+			return nil, err
+		}
+		if a.Loop, err = s.Params.Bool("loop", a.Loop); err != nil {
+			return nil, err
+		}
+		return a, nil
+	})
+	gobot.RegisterDriver("replay", func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		a, ok := c.(*ReplayAdaptor)
+		if !ok {
+			return nil, errors.New("replay driver requires a replay connection")
+		}
+		d := NewReplayDriver(a, s.Name)
+		var err error
+		if d.Robot, err = s.Params.String("robot", d.Robot); err != nil {
+			return nil, err
+		}
+		if d.Device, err = s.Params.String("device", d.Device); err != nil {
+			return nil, err
+		}
+		return d, nil
+	})
+}
@@ -0,0 +1,168 @@
+package replay
+
+import (
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Adaptor = (*ReplayAdaptor)(nil)
+
+// ReplayAdaptor plays back a recording to the ReplayDrivers using it. All
+// records are published in order from a single goroutine, so replaying the
+// same recording always publishes the same events in the same order.
+type ReplayAdaptor struct {
+	name string
+	path string
+	// Speed scales the time between records: 2 plays twice as fast as
+	// recorded, 0 plays without waiting.
+	Speed float64
+	// Loop restarts the recording once it has been played.
+	Loop    bool
+	records []Record
+	drivers []*ReplayDriver
+	mutex   sync.Mutex
+	halt    chan struct{}
+	done    chan struct{}
+	playing bool
+	// played reports whether done was handed to a playing of the recording,
+	// which closes it once it ends.
+	played bool
+}
+
+// NewReplayAdaptor returns a new ReplayAdaptor given a name and the path of a
+// recording, playing at the recorded speed.
+func NewReplayAdaptor(name string, path string) *ReplayAdaptor {
+	return &ReplayAdaptor{
+		name:  name,
+		path:  path,
+		Speed: 1,
+		done:  make(chan struct{}),
+	}
+}
+
+// Name returns the ReplayAdaptors name
+func (r *ReplayAdaptor) Name() string { return r.name }
+
+// Port returns the path of the recording
+func (r *ReplayAdaptor) Port() string { return r.path }
+
+// Connect reads the recording.
+func (r *ReplayAdaptor) Connect() (errs []error) {
+	records, err := ReadFile(r.path)
+	if err != nil {
+		return []error{err}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.records = records
+	if r.played {
+		r.done = make(chan struct{})
+		r.played = false
+	}
+	return
+}
+
+// Finalize stops playing the recording.
+func (r *ReplayAdaptor) Finalize() (errs []error) {
+	r.stop()
+	return
+}
+
+// Records returns the records of the recording read by Connect
+func (r *ReplayAdaptor) Records() []Record {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.records
+}
+
+// Done returns a channel which is closed once the recording has been played
+// without Loop, or playing was stopped.
+func (r *ReplayAdaptor) Done() <-chan struct{} {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.done
+}
+
+// register adds a driver the records of its source are published to.
+func (r *ReplayAdaptor) register(d *ReplayDriver) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.drivers = append(r.drivers, d)
+}
+
+// Play starts playing the recording read by Connect unless it is playing
+// already. It is usually called at the end of the work of a robot, once its
+// callbacks are subscribed to the events of the ReplayDrivers. Records of
+// drivers which have not started or have halted are skipped. Once the
+// recording has been played, or playing was stopped, Play plays it again.
+func (r *ReplayAdaptor) Play() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.playing {
+		return
+	}
+	if r.played {
+		r.done = make(chan struct{})
+	}
+	r.played = true
+	r.playing = true
+	r.halt = make(chan struct{})
+	go r.run(r.records, r.Speed, r.halt, r.done)
+}
+
+func (r *ReplayAdaptor) stop() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.playing {
+		close(r.halt)
+		r.playing = false
+	}
+}
+
+func (r *ReplayAdaptor) run(records []Record, speed float64, halt, done chan struct{}) {
+	defer func() {
+		r.mutex.Lock()
+		if r.halt == halt {
+			r.playing = false
+		}
+		r.mutex.Unlock()
+		close(done)
+	}()
+	if len(records) == 0 {
+		return
+	}
+	for {
+		start := time.Now()
+		for _, rec := range records {
+			if speed > 0 {
+				wait := time.Duration(float64(rec.Offset)/speed) - time.Since(start)
+				select {
+				case <-halt:
+					return
+				case <-time.After(wait):
+				}
+			}
+			select {
+			case <-halt:
+				return
+			default:
+			}
+			r.publish(rec)
+		}
+		if !r.Loop {
+			return
+		}
+	}
+}
+
+// publish publishes a record to the drivers replaying its source.
+func (r *ReplayAdaptor) publish(rec Record) {
+	r.mutex.Lock()
+	drivers := append([]*ReplayDriver{}, r.drivers...)
+	r.mutex.Unlock()
+	for _, d := range drivers {
+		d.publish(rec)
+	}
+}
@@ -0,0 +1,107 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestReplayAdaptor(t *testing.T) {
+	path := writeTestRecording(t, Record{Device: "sensor", Event: "data", Data: 1})
+	defer os.RemoveAll(filepath.Dir(path))
+
+	a := NewReplayAdaptor("replay", path)
+	gobot.Assert(t, a.Name(), "replay")
+	gobot.Assert(t, a.Port(), path)
+	gobot.Assert(t, a.Speed, 1.0)
+	gobot.Assert(t, len(a.Connect()), 0)
+	gobot.Assert(t, len(a.Records()), 1)
+
+	a.Play()
+	select {
+	case <-a.Done():
+	case <-time.After(time.Second):
+		t.Error("recording was not played")
+	}
+	gobot.Assert(t, len(a.Finalize()), 0)
+
+	a = NewReplayAdaptor("replay", filepath.Join(filepath.Dir(path), "missing.rec"))
+	gobot.Assert(t, len(a.Connect()), 1)
+}
+
+func TestReplayAdaptorPlayAgain(t *testing.T) {
+	path := writeTestRecording(t, Record{Device: "sensor", Event: "data", Data: 1})
+	defer os.RemoveAll(filepath.Dir(path))
+
+	a := NewReplayAdaptor("replay", path)
+	a.Speed = 0
+	a.Connect()
+
+	// the recording plays again once it has been played, without reconnecting
+	for i := 0; i < 3; i++ {
+		a.Play()
+		select {
+		case <-a.Done():
+		case <-time.After(time.Second):
+			t.Fatalf("recording was not played %v times", i+1)
+		}
+	}
+	a.Finalize()
+}
+
+func TestReplayAdaptorLoop(t *testing.T) {
+	path := writeTestRecording(t, Record{Device: "sensor", Event: "data", Data: 1})
+	defer os.RemoveAll(filepath.Dir(path))
+
+	a := NewReplayAdaptor("replay", path)
+	a.Loop = true
+	a.Speed = 0
+	d := NewReplayDriver(a, "sensor")
+	a.Connect()
+	d.Start()
+
+	count := make(chan bool, 3)
+	gobot.On(d.Event("data"), func(data interface{}) {
+		select {
+		case count <- true:
+		default:
+		}
+	})
+	a.Play()
+	for i := 0; i < 3; i++ {
+		select {
+		case <-count:
+		case <-time.After(time.Second):
+			t.Fatal("recording was not looped")
+		}
+	}
+	a.Finalize()
+	select {
+	case <-a.Done():
+	case <-time.After(time.Second):
+		t.Error("loop was not stopped")
+	}
+}
+
+func TestReplayRegistry(t *testing.T) {
+	c, err := gobot.NewAdaptor("replay", gobot.AdaptorSpec{
+		Name:   "replay",
+		Port:   "test.rec",
+		Params: gobot.Params{"speed": 2, "loop": true},
+	})
+	gobot.Assert(t, err, nil)
+	a := c.(*ReplayAdaptor)
+	gobot.Assert(t, a.Speed, 2.0)
+	gobot.Assert(t, a.Loop, true)
+
+	d, err := gobot.NewDriver("replay", a, gobot.DriverSpec{
+		Name:   "sensor",
+		Params: gobot.Params{"robot": "bot", "device": "imu"},
+	})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, d.(*ReplayDriver).Robot, "bot")
+	gobot.Assert(t, d.(*ReplayDriver).Device, "imu")
+}
@@ -0,0 +1,85 @@
+package replay
+
+import (
+	"sync"
+
+	"github.com/hybridgroup/gobot"
+)
+
+var _ gobot.Driver = (*ReplayDriver)(nil)
+
+// ReplayDriver republishes the recorded events of a device on identically
+// named events of its own, so work written for the recorded device runs
+// unchanged against the recording.
+type ReplayDriver struct {
+	name       string
+	connection *ReplayAdaptor
+	// Robot is the name of the robot whose records are replayed, or empty
+	// for any robot.
+	Robot string
+	// Device is the name of the device whose records are replayed, or empty
+	// for the events of the robot itself. Defaults to the name of the driver.
+	Device  string
+	mutex   sync.RWMutex
+	running bool
+	gobot.Eventer
+}
+
+// NewReplayDriver returns a new ReplayDriver given a ReplayAdaptor and name,
+// replaying the records of the device of the same name.
+func NewReplayDriver(a *ReplayAdaptor, name string) *ReplayDriver {
+	d := &ReplayDriver{
+		name:       name,
+		connection: a,
+		Device:     name,
+		Eventer:    gobot.NewEventer(),
+	}
+	a.register(d)
+	return d
+}
+
+// Name returns the ReplayDrivers name
+func (d *ReplayDriver) Name() string { return d.name }
+
+// Connection returns the ReplayDrivers connection
+func (d *ReplayDriver) Connection() gobot.Connection { return d.connection }
+
+// Start adds an event for each event name recorded for the device. Events are
+// delivered to callbacks in order.
+func (d *ReplayDriver) Start() (errs []error) {
+	for _, rec := range d.connection.Records() {
+		if d.replays(rec) && d.Event(rec.Event) == nil {
+			d.AddEvent(rec.Event)
+			d.Event(rec.Event).SetQueue(QueueSize, gobot.Block)
+		}
+	}
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.running = true
+	return
+}
+
+// Halt stops publishing events.
+func (d *ReplayDriver) Halt() (errs []error) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	d.running = false
+	return
+}
+
+// replays returns true if the record is of the source of the driver.
+func (d *ReplayDriver) replays(rec Record) bool {
+	return rec.Device == d.Device && (d.Robot == "" || rec.Robot == d.Robot)
+}
+
+func (d *ReplayDriver) isRunning() bool {
+	d.mutex.RLock()
+	defer d.mutex.RUnlock()
+	return d.running
+}
+
+func (d *ReplayDriver) publish(rec Record) {
+	if d.isRunning() && d.replays(rec) {
+		gobot.Publish(d.Event(rec.Event), rec.Data)
+	}
+}
@@ -0,0 +1,73 @@
+package replay
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestReplayDriver(t *testing.T) {
+	path := writeTestRecording(t,
+		Record{Offset: 0, Robot: "bot", Device: "sensor", Event: "data", Data: 1},
+		Record{Offset: time.Millisecond, Robot: "bot", Device: "sensor", Event: "data", Data: 2},
+		Record{Offset: 2 * time.Millisecond, Robot: "other", Device: "sensor", Event: "data", Data: 3},
+		Record{Offset: 3 * time.Millisecond, Robot: "bot", Device: "sensor", Event: "push", Data: nil},
+		Record{Offset: 4 * time.Millisecond, Robot: "bot", Device: "button", Event: "push", Data: nil},
+		Record{Offset: 5 * time.Millisecond, Robot: "bot", Device: "sensor", Event: "data", Data: 4},
+	)
+	defer os.RemoveAll(filepath.Dir(path))
+
+	a := NewReplayAdaptor("replay", path)
+	d := NewReplayDriver(a, "sensor")
+	d.Robot = "bot"
+	gobot.Assert(t, d.Name(), "sensor")
+	gobot.Assert(t, d.Connection().Name(), "replay")
+
+	a.Connect()
+	gobot.Assert(t, len(d.Start()), 0)
+	gobot.Refute(t, d.Event("data"), (*gobot.Event)(nil))
+	gobot.Refute(t, d.Event("push"), (*gobot.Event)(nil))
+
+	data := make(chan interface{}, 10)
+	gobot.On(d.Event("data"), func(v interface{}) {
+		data <- v
+	})
+	a.Play()
+	<-a.Done()
+
+	for _, want := range []interface{}{1, 2, 4} {
+		select {
+		case v := <-data:
+			gobot.Assert(t, v, want)
+		case <-time.After(time.Second):
+			t.Fatal("record was not replayed")
+		}
+	}
+	gobot.Assert(t, len(d.Halt()), 0)
+}
+
+func TestReplayDriverHalted(t *testing.T) {
+	path := writeTestRecording(t, Record{Device: "sensor", Event: "data", Data: 1})
+	defer os.RemoveAll(filepath.Dir(path))
+
+	a := NewReplayAdaptor("replay", path)
+	d := NewReplayDriver(a, "sensor")
+	a.Connect()
+	d.Start()
+	d.Halt()
+
+	data := make(chan interface{}, 1)
+	gobot.On(d.Event("data"), func(v interface{}) {
+		data <- v
+	})
+	a.Play()
+	<-a.Done()
+	select {
+	case <-data:
+		t.Error("halted driver published a record")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Package sim contains an in-memory Gobot adaptor simulating GPIO pins and an I2C
+bus, for running whole robots in tests and CI without hardware.
+
+The SimAdaptor implements the gpio DigitalReader, DigitalWriter, AnalogReader,
+PwmWriter and ServoWriter interfaces and the i2c I2c interface, so any gpio or
+i2c driver can use it:
+
+	package main
+
+	import (
+		"time"
+
+		"github.com/hybridgroup/gobot"
+		"github.com/hybridgroup/gobot/platforms/gpio"
+		"github.com/hybridgroup/gobot/platforms/sim"
+	)
+
+	func main() {
+		gbot := gobot.NewGobot()
+
+		simAdaptor := sim.NewSimAdaptor("sim")
+		led := gpio.NewLedDriver(simAdaptor, "led", "13")
+		button := gpio.NewButtonDriver(simAdaptor, "button", "2")
+
+		// press the button for half of every second
+		simAdaptor.Script("2", sim.Square(1*time.Second, 0, 1))
+
+		work := func() {
+			gobot.On(button.Event("push"), func(data interface{}) {
+				led.Toggle()
+			})
+		}
+
+		robot := gobot.NewRobot("bot",
+			[]gobot.Connection{simAdaptor},
+			[]gobot.Device{led, button},
+			work,
+		)
+
+		gbot.AddRobot(robot)
+
+		gbot.Start()
+	}
+
+Pins hold the last value written or set with Set. Reads of input pins can be
+scripted with a Waveform such as Square, Sine or Steps, or with a Sequence of
+values returned by consecutive reads.
+
+Virtual I2C peripherals are added by address with AddI2cPeripheral. A
+RegisterMap simulates the register based peripherals most sensors are.
+
+Every write is recorded in a timeline returned by Writes and PinWrites.
+*/
+package sim
@@ -0,0 +1,92 @@
+package sim
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrRegisterOutOfRange is the error resulting from accessing a register beyond
+// the end of a RegisterMap
+var ErrRegisterOutOfRange = errors.New("sim: register out of range")
+
+// I2cPeripheral is a virtual device on the I2C bus of a SimAdaptor.
+type I2cPeripheral interface {
+	// I2cWrite receives the bytes written to the peripheral.
+	I2cWrite(buf []byte) (err error)
+	// I2cRead returns the next n bytes read from the peripheral.
+	I2cRead(n int) (data []byte, err error)
+}
+
+// RegisterMap is an I2cPeripheral with byte registers addressed by a register
+// pointer, as most I2C sensors are. The first byte written sets the pointer,
+// the following bytes are written to consecutive registers. Reads return
+// consecutive registers from the pointer on. The pointer advances with every
+// byte read or written.
+type RegisterMap struct {
+	mutex     sync.Mutex
+	registers []byte
+	pointer   int
+}
+
+var _ I2cPeripheral = (*RegisterMap)(nil)
+
+// NewRegisterMap returns a new RegisterMap with size registers set to 0.
+func NewRegisterMap(size int) *RegisterMap {
+	return &RegisterMap{registers: make([]byte, size)}
+}
+
+// Set sets consecutive registers from reg on to values.
+func (r *RegisterMap) Set(reg int, values ...byte) (err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if reg < 0 || reg+len(values) > len(r.registers) {
+		return ErrRegisterOutOfRange
+	}
+	copy(r.registers[reg:], values)
+	return
+}
+
+// Get returns the value of a register.
+func (r *RegisterMap) Get(reg int) (val byte, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if reg < 0 || reg >= len(r.registers) {
+		return 0, ErrRegisterOutOfRange
+	}
+	return r.registers[reg], nil
+}
+
+// Registers returns a copy of all registers.
+func (r *RegisterMap) Registers() []byte {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]byte{}, r.registers...)
+}
+
+// I2cWrite implements the I2cPeripheral interface
+func (r *RegisterMap) I2cWrite(buf []byte) (err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if len(buf) == 0 {
+		return
+	}
+	pointer := int(buf[0])
+	if pointer+len(buf)-1 > len(r.registers) {
+		return ErrRegisterOutOfRange
+	}
+	copy(r.registers[pointer:], buf[1:])
+	r.pointer = pointer + len(buf) - 1
+	return
+}
+
+// I2cRead implements the I2cPeripheral interface
+func (r *RegisterMap) I2cRead(n int) (data []byte, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.pointer+n > len(r.registers) {
+		return nil, ErrRegisterOutOfRange
+	}
+	data = append([]byte{}, r.registers[r.pointer:r.pointer+n]...)
+	r.pointer += n
+	return
+}
@@ -0,0 +1,30 @@
+package sim
+
+import (
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestRegisterMap(t *testing.T) {
+	r := NewRegisterMap(4)
+	gobot.Assert(t, r.Set(1, 0x10, 0x20), nil)
+	gobot.Assert(t, r.Set(3, 0x30, 0x40), ErrRegisterOutOfRange)
+	gobot.Assert(t, r.Registers(), []byte{0, 0x10, 0x20, 0})
+
+	gobot.Assert(t, r.I2cWrite([]byte{1}), nil)
+	data, err := r.I2cRead(2)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, data, []byte{0x10, 0x20})
+	data, _ = r.I2cRead(1)
+	gobot.Assert(t, data, []byte{0})
+	_, err = r.I2cRead(1)
+	gobot.Assert(t, err, ErrRegisterOutOfRange)
+
+	gobot.Assert(t, r.I2cWrite([]byte{2, 0xAA, 0xBB}), nil)
+	val, _ := r.Get(3)
+	gobot.Assert(t, val, byte(0xBB))
+	gobot.Assert(t, r.I2cWrite([]byte{3, 1, 2}), ErrRegisterOutOfRange)
+	_, err = r.Get(4)
+	gobot.Assert(t, err, ErrRegisterOutOfRange)
+}
@@ -0,0 +1,9 @@
+package sim
+
+import "github.com/hybridgroup/gobot"
+
+func init() {
+	gobot.RegisterAdaptor("sim", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		return NewSimAdaptor(s.Name), nil
+	})
+}
@@ -0,0 +1,265 @@
+package sim
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+	"github.com/hybridgroup/gobot/platforms/i2c"
+)
+
+var _ gobot.Adaptor = (*SimAdaptor)(nil)
+
+var _ gpio.DigitalReader = (*SimAdaptor)(nil)
+var _ gpio.DigitalWriter = (*SimAdaptor)(nil)
+var _ gpio.AnalogReader = (*SimAdaptor)(nil)
+var _ gpio.PwmWriter = (*SimAdaptor)(nil)
+var _ gpio.ServoWriter = (*SimAdaptor)(nil)
+
+var _ i2c.I2c = (*SimAdaptor)(nil)
+
+// WriteKind is the kind of a Write.
+type WriteKind string
+
+const (
+	// DigitalWrite is the kind of a Write by DigitalWrite
+	DigitalWrite WriteKind = "digital"
+	// PwmWrite is the kind of a Write by PwmWrite
+	PwmWrite WriteKind = "pwm"
+	// ServoWrite is the kind of a Write by ServoWrite
+	ServoWrite WriteKind = "servo"
+	// I2cWrite is the kind of a Write by I2cWrite
+	I2cWrite WriteKind = "i2c"
+)
+
+// Write is a write recorded in the timeline of a SimAdaptor.
+type Write struct {
+	Time time.Time
+	Kind WriteKind
+	// Pin is the pin written to by DigitalWrite, PwmWrite and ServoWrite.
+	Pin   string
+	Value byte
+	// Address is the address written to by I2cWrite.
+	Address int
+	Data    []byte
+}
+
+// SimAdaptor is an in-memory adaptor simulating GPIO pins and an I2C bus, for
+// running whole robots without hardware.
+//
+// Pins hold the last value written or set. Input pins can be scripted with a
+// Waveform or a sequence of values. Virtual I2C peripherals are added by
+// address. Every write is recorded in a timeline.
+type SimAdaptor struct {
+	name      string
+	mutex     sync.Mutex
+	pins      map[string]int
+	waveforms map[string]waveform
+	sequences map[string][]int
+	devices   map[int]I2cPeripheral
+	writes    []Write
+	now       func() time.Time
+}
+
+// waveform is a Waveform scripted on a pin at start.
+type waveform struct {
+	f     Waveform
+	start time.Time
+}
+
+// NewSimAdaptor returns a new SimAdaptor given a name.
+func NewSimAdaptor(name string) *SimAdaptor {
+	return &SimAdaptor{
+		name:      name,
+		pins:      make(map[string]int),
+		waveforms: make(map[string]waveform),
+		sequences: make(map[string][]int),
+		devices:   make(map[int]I2cPeripheral),
+		now:       gobot.Now,
+	}
+}
+
+// Name returns the SimAdaptors name
+func (s *SimAdaptor) Name() string { return s.name }
+
+// Connect implements the Adaptor interface
+func (s *SimAdaptor) Connect() (errs []error) { return }
+
+// Finalize implements the Adaptor interface
+func (s *SimAdaptor) Finalize() (errs []error) { return }
+
+// Set sets the value of a pin, replacing any script.
+func (s *SimAdaptor) Set(pin string, val int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unscript(pin)
+	s.pins[pin] = val
+}
+
+// Script makes reads of a pin return the value of f for the time elapsed since
+// Script was called, replacing any previous script.
+func (s *SimAdaptor) Script(pin string, f Waveform) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unscript(pin)
+	s.waveforms[pin] = waveform{f: f, start: s.now()}
+}
+
+// Sequence makes consecutive reads of a pin return values in order, after
+// which the pin holds the last value. Replaces any previous script.
+func (s *SimAdaptor) Sequence(pin string, values ...int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.unscript(pin)
+	s.sequences[pin] = values
+}
+
+// Pin returns the current value of a pin without consuming the values of a
+// Sequence.
+func (s *SimAdaptor) Pin(pin string) int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if w, ok := s.waveforms[pin]; ok {
+		return w.f(s.now().Sub(w.start))
+	}
+	return s.pins[pin]
+}
+
+// AddI2cPeripheral adds a virtual I2C peripheral at address.
+func (s *SimAdaptor) AddI2cPeripheral(address int, p I2cPeripheral) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.devices[address] = p
+}
+
+// Writes returns the timeline of all writes so far.
+func (s *SimAdaptor) Writes() []Write {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]Write{}, s.writes...)
+}
+
+// PinWrites returns the values written to a pin so far, in order.
+func (s *SimAdaptor) PinWrites(pin string) []byte {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	values := []byte{}
+	for _, w := range s.writes {
+		if w.Kind != I2cWrite && w.Pin == pin {
+			values = append(values, w.Value)
+		}
+	}
+	return values
+}
+
+// ClearWrites empties the timeline.
+func (s *SimAdaptor) ClearWrites() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.writes = nil
+}
+
+// DigitalRead returns the value of a pin
+func (s *SimAdaptor) DigitalRead(pin string) (val int, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.read(pin), nil
+}
+
+// AnalogRead returns the value of a pin
+func (s *SimAdaptor) AnalogRead(pin string) (val int, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.read(pin), nil
+}
+
+// DigitalWrite sets the value of a pin
+func (s *SimAdaptor) DigitalWrite(pin string, val byte) (err error) {
+	s.write(Write{Kind: DigitalWrite, Pin: pin, Value: val})
+	return
+}
+
+// PwmWrite sets the value of a pin
+func (s *SimAdaptor) PwmWrite(pin string, val byte) (err error) {
+	s.write(Write{Kind: PwmWrite, Pin: pin, Value: val})
+	return
+}
+
+// ServoWrite sets the value of a pin
+func (s *SimAdaptor) ServoWrite(pin string, val byte) (err error) {
+	s.write(Write{Kind: ServoWrite, Pin: pin, Value: val})
+	return
+}
+
+// I2cStart starts the I2C peripheral at address. Returns an error if there is
+// none.
+func (s *SimAdaptor) I2cStart(address int) (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	_, err = s.device(address)
+	return
+}
+
+// I2cWrite writes buf to the I2C peripheral at address
+func (s *SimAdaptor) I2cWrite(address int, buf []byte) (err error) {
+	s.mutex.Lock()
+	p, err := s.device(address)
+	s.mutex.Unlock()
+	if err != nil {
+		return
+	}
+	s.write(Write{Kind: I2cWrite, Address: address, Data: append([]byte{}, buf...)})
+	return p.I2cWrite(buf)
+}
+
+// I2cRead reads len bytes from the I2C peripheral at address
+func (s *SimAdaptor) I2cRead(address int, len int) (data []byte, err error) {
+	s.mutex.Lock()
+	p, err := s.device(address)
+	s.mutex.Unlock()
+	if err != nil {
+		return
+	}
+	return p.I2cRead(len)
+}
+
+func (s *SimAdaptor) device(address int) (I2cPeripheral, error) {
+	if p, ok := s.devices[address]; ok {
+		return p, nil
+	}
+	return nil, fmt.Errorf("sim: no i2c peripheral at address 0x%x", address)
+}
+
+func (s *SimAdaptor) write(w Write) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	w.Time = s.now()
+	if w.Kind != I2cWrite {
+		s.unscript(w.Pin)
+		s.pins[w.Pin] = int(w.Value)
+	}
+	s.writes = append(s.writes, w)
+}
+
+func (s *SimAdaptor) read(pin string) int {
+	if w, ok := s.waveforms[pin]; ok {
+		return w.f(s.now().Sub(w.start))
+	}
+	if values, ok := s.sequences[pin]; ok && len(values) > 0 {
+		s.pins[pin] = values[0]
+		s.sequences[pin] = values[1:]
+	}
+	return s.pins[pin]
+}
+
+// unscript removes the script of a pin, which holds the last value of its
+// Waveform.
+func (s *SimAdaptor) unscript(pin string) {
+	if w, ok := s.waveforms[pin]; ok {
+		s.pins[pin] = w.f(s.now().Sub(w.start))
+	}
+	delete(s.waveforms, pin)
+	delete(s.sequences, pin)
+}
@@ -0,0 +1,137 @@
+package sim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"github.com/hybridgroup/gobot/platforms/gpio"
+)
+
+func initTestSimAdaptor() (*SimAdaptor, *time.Time) {
+	now := time.Unix(0, 0)
+	s := NewSimAdaptor("sim")
+	s.now = func() time.Time { return now }
+	return s, &now
+}
+
+func TestSimAdaptor(t *testing.T) {
+	s, _ := initTestSimAdaptor()
+	gobot.Assert(t, s.Name(), "sim")
+	gobot.Assert(t, len(s.Connect()), 0)
+	gobot.Assert(t, len(s.Finalize()), 0)
+
+	s.Set("1", 1)
+	val, err := s.DigitalRead("1")
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, val, 1)
+
+	s.Set("A0", 512)
+	val, _ = s.AnalogRead("A0")
+	gobot.Assert(t, val, 512)
+	gobot.Assert(t, s.Pin("unknown"), 0)
+}
+
+func TestSimAdaptorWrites(t *testing.T) {
+	s, now := initTestSimAdaptor()
+
+	gobot.Assert(t, s.DigitalWrite("13", 1), nil)
+	*now = now.Add(time.Second)
+	gobot.Assert(t, s.PwmWrite("13", 128), nil)
+	gobot.Assert(t, s.ServoWrite("9", 90), nil)
+
+	gobot.Assert(t, s.Pin("13"), 128)
+	gobot.Assert(t, s.PinWrites("13"), []byte{1, 128})
+
+	writes := s.Writes()
+	gobot.Assert(t, len(writes), 3)
+	gobot.Assert(t, writes[0], Write{Time: time.Unix(0, 0), Kind: DigitalWrite, Pin: "13", Value: 1})
+	gobot.Assert(t, writes[2].Kind, ServoWrite)
+	gobot.Assert(t, writes[2].Time, time.Unix(1, 0))
+
+	s.ClearWrites()
+	gobot.Assert(t, len(s.Writes()), 0)
+}
+
+func TestSimAdaptorScript(t *testing.T) {
+	s, now := initTestSimAdaptor()
+
+	s.Script("2", Square(time.Second, 0, 1))
+	val, _ := s.DigitalRead("2")
+	gobot.Assert(t, val, 0)
+	*now = now.Add(600 * time.Millisecond)
+	val, _ = s.DigitalRead("2")
+	gobot.Assert(t, val, 1)
+	gobot.Assert(t, s.Pin("2"), 1)
+
+	s.Sequence("2", 0, 1, 0)
+	gobot.Assert(t, s.Pin("2"), 1)
+	for _, want := range []int{0, 1, 0, 0} {
+		val, _ = s.DigitalRead("2")
+		gobot.Assert(t, val, want)
+	}
+
+	s.Script("2", Constant(1))
+	s.DigitalWrite("2", 0)
+	gobot.Assert(t, s.Pin("2"), 0)
+}
+
+func TestSimAdaptorI2c(t *testing.T) {
+	s, _ := initTestSimAdaptor()
+	r := NewRegisterMap(16)
+	r.Set(0x0A, 1, 2, 3)
+	s.AddI2cPeripheral(0x42, r)
+
+	gobot.Assert(t, s.I2cStart(0x42), nil)
+	gobot.Assert(t, s.I2cStart(0x43).Error(), "sim: no i2c peripheral at address 0x43")
+
+	gobot.Assert(t, s.I2cWrite(0x42, []byte{0x0A}), nil)
+	data, err := s.I2cRead(0x42, 3)
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, data, []byte{1, 2, 3})
+
+	gobot.Assert(t, s.I2cWrite(0x42, []byte{0x00, 0xFF}), nil)
+	val, _ := r.Get(0)
+	gobot.Assert(t, val, byte(0xFF))
+	gobot.Assert(t, s.Writes()[1], Write{Time: time.Unix(0, 0), Kind: I2cWrite, Address: 0x42, Data: []byte{0x00, 0xFF}})
+
+	_, err = s.I2cRead(0x43, 1)
+	gobot.Refute(t, err, nil)
+	gobot.Refute(t, s.I2cWrite(0x43, []byte{0}), nil)
+}
+
+func TestSimAdaptorRobot(t *testing.T) {
+	s := NewSimAdaptor("sim")
+	led := gpio.NewLedDriver(s, "led", "13")
+	button := gpio.NewButtonDriver(s, "button", "2", time.Millisecond)
+
+	work := func() {
+		gobot.On(button.Event(gpio.Push), func(data interface{}) {
+			led.On()
+		})
+	}
+	robot := gobot.NewRobot("bot",
+		[]gobot.Connection{s},
+		[]gobot.Device{led, button},
+		work,
+	)
+	gobot.Assert(t, len(robot.Start()), 0)
+	defer robot.Stop()
+
+	s.Set("2", 1)
+	deadline := time.After(time.Second)
+	for s.Pin("13") != 1 {
+		select {
+		case <-deadline:
+			t.Fatal("led was not turned on")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	gobot.Assert(t, s.PinWrites("13"), []byte{1})
+}
+
+func TestSimRegistry(t *testing.T) {
+	a, err := gobot.NewAdaptor("sim", gobot.AdaptorSpec{Name: "sim"})
+	gobot.Assert(t, err, nil)
+	gobot.Assert(t, a.Name(), "sim")
+}
@@ -0,0 +1,63 @@
+package sim
+
+import (
+	"math"
+	"time"
+)
+
+// Waveform returns the value of an input pin given the time elapsed since it
+// was scripted.
+type Waveform func(elapsed time.Duration) int
+
+// Step is a value a Steps Waveform holds from At on.
+type Step struct {
+	At    time.Duration
+	Value int
+}
+
+// Constant returns a Waveform which is always value.
+func Constant(value int) Waveform {
+	return func(time.Duration) int { return value }
+}
+
+// Square returns a Waveform which is low for the first half of each period and
+// high for the second half.
+func Square(period time.Duration, low, high int) Waveform {
+	return func(elapsed time.Duration) int {
+		if period <= 0 || elapsed%period < period/2 {
+			return low
+		}
+		return high
+	}
+}
+
+// Sine returns a Waveform oscillating between min and max with period,
+// starting at their mean.
+func Sine(period time.Duration, min, max int) Waveform {
+	return func(elapsed time.Duration) int {
+		if period <= 0 {
+			return (min + max) / 2
+		}
+		phase := 2 * math.Pi * float64(elapsed%period) / float64(period)
+		return int(math.Floor(float64(min) + float64(max-min)*(1+math.Sin(phase))/2 + 0.5))
+	}
+}
+
+// Steps returns a Waveform holding the value of the last step whose At has
+// passed, and the value of the first step before that. Steps must be ordered
+// by At.
+func Steps(steps ...Step) Waveform {
+	return func(elapsed time.Duration) int {
+		if len(steps) == 0 {
+			return 0
+		}
+		value := steps[0].Value
+		for _, s := range steps {
+			if s.At > elapsed {
+				break
+			}
+			value = s.Value
+		}
+		return value
+	}
+}
@@ -0,0 +1,38 @@
+package sim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestConstant(t *testing.T) {
+	gobot.Assert(t, Constant(5)(time.Hour), 5)
+}
+
+func TestSquare(t *testing.T) {
+	w := Square(10*time.Millisecond, 0, 1)
+	gobot.Assert(t, w(0), 0)
+	gobot.Assert(t, w(4*time.Millisecond), 0)
+	gobot.Assert(t, w(5*time.Millisecond), 1)
+	gobot.Assert(t, w(12*time.Millisecond), 0)
+	gobot.Assert(t, Square(0, 0, 1)(time.Second), 0)
+}
+
+func TestSine(t *testing.T) {
+	w := Sine(4*time.Second, 0, 1000)
+	gobot.Assert(t, w(0), 500)
+	gobot.Assert(t, w(time.Second), 1000)
+	gobot.Assert(t, w(2*time.Second), 500)
+	gobot.Assert(t, w(3*time.Second), 0)
+	gobot.Assert(t, Sine(0, 0, 1000)(time.Second), 500)
+}
+
+func TestSteps(t *testing.T) {
+	w := Steps(Step{At: time.Second, Value: 1}, Step{At: 2 * time.Second, Value: 2})
+	gobot.Assert(t, w(0), 1)
+	gobot.Assert(t, w(time.Second), 1)
+	gobot.Assert(t, w(3*time.Second), 2)
+	gobot.Assert(t, Steps()(time.Second), 0)
+}
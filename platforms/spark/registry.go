@@ -0,0 +1,24 @@
+package spark
+
+import (
+	"errors"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func init() {
+	gobot.RegisterAdaptor("spark", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		deviceID, err := s.Params.String("device_id", "")
+		if err != nil {
+			return nil, err
+		}
+		accessToken, err := s.Params.String("access_token", "")
+		if err != nil {
+			return nil, err
+		}
+		if deviceID == "" || accessToken == "" {
+			return nil, errors.New("spark requires the device_id and access_token params")
+		}
+		return NewSparkCoreAdaptor(s.Name, deviceID, accessToken), nil
+	})
+}
@@ -23,18 +23,17 @@ Example:
 		adaptor := sphero.NewSpheroAdaptor("sphero", "/dev/rfcomm0")
 		driver := sphero.NewSpheroDriver(adaptor, "sphero")
 
-		work := func() {
-			gobot.Every(3*time.Second, func() {
-				driver.Roll(30, uint16(gobot.Rand(360)))
-			})
-		}
-
 		robot := gobot.NewRobot("sphero",
 			[]gobot.Connection{adaptor},
 			[]gobot.Device{driver},
-			work,
 		)
 
+		robot.Work = func() {
+			robot.Every(3*time.Second, func() {
+				driver.Roll(30, uint16(gobot.Rand(360)))
+			})
+		}
+
 		gbot.AddRobot(robot)
 
 		gbot.Start()
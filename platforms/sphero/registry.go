@@ -0,0 +1,20 @@
+package sphero
+
+import (
+	"errors"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func init() {
+	gobot.RegisterAdaptor("sphero", func(s gobot.AdaptorSpec) (gobot.Adaptor, error) {
+		return NewSpheroAdaptor(s.Name, s.Port), nil
+	})
+	gobot.RegisterDriver("sphero", func(c gobot.Connection, s gobot.DriverSpec) (gobot.Driver, error) {
+		a, ok := c.(*SpheroAdaptor)
+		if !ok {
+			return nil, errors.New("sphero driver requires a sphero connection")
+		}
+		return NewSpheroDriver(a, s.Name), nil
+	})
+}
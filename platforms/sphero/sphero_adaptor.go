@@ -1,13 +1,18 @@
 package sphero
 
 import (
+	"context"
 	"io"
+	"sync"
 
 	"github.com/hybridgroup/gobot"
 	"github.com/tarm/goserial"
 )
 
 var _ gobot.Adaptor = (*SpheroAdaptor)(nil)
+var _ gobot.HealthChecker = (*SpheroAdaptor)(nil)
+var _ gobot.Reconnector = (*SpheroAdaptor)(nil)
+var _ gobot.ContextConnector = (*SpheroAdaptor)(nil)
 
 // Represents a Connection to a Sphero
 type SpheroAdaptor struct {
@@ -16,6 +21,8 @@ type SpheroAdaptor struct {
 	sp        io.ReadWriteCloser
 	connected bool
 	connect   func(string) (io.ReadWriteCloser, error)
+	mutex     sync.Mutex
+	err       error
 }
 
 // NewSpheroAdaptor returns a new SpheroAdaptor given a name and port
@@ -33,15 +40,38 @@ func (a *SpheroAdaptor) Port() string { return a.port }
 
 // Connect initiates a connection to the Sphero. Returns true on successful connection.
 func (a *SpheroAdaptor) Connect() (errs []error) {
-	if sp, err := a.connect(a.Port()); err != nil {
+	return a.ConnectContext(context.Background())
+}
+
+// ConnectContext connects to the Sphero like Connect, giving up once ctx is
+// done, in which case a serial port opened afterwards is closed.
+func (a *SpheroAdaptor) ConnectContext(ctx context.Context) (errs []error) {
+	sp, err := gobot.OpenContext(ctx, func() (io.ReadWriteCloser, error) {
+		return a.connect(a.Port())
+	})
+	if err != nil {
 		return []error{err}
-	} else {
-		a.sp = sp
-		a.connected = true
 	}
+	a.sp = gobot.MeterReadWriteCloser(sp, "serial", a.Name())
+	a.connected = true
+	a.setErr(nil)
 	return
 }
 
+// Healthy returns the error which broke the connection to the Sphero, or nil
+// if reading from and writing to it works.
+func (a *SpheroAdaptor) Healthy() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.err
+}
+
+func (a *SpheroAdaptor) setErr(err error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.err = err
+}
+
 // Reconnect attempts to reconnect to the Sphero. If the Sphero has an active connection
 // it will first close that connection and then establish a new connection.
 // Returns true on Successful reconnection
@@ -1,9 +1,11 @@
 package sphero
 
 import (
+	"context"
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/hybridgroup/gobot"
 )
@@ -83,3 +85,43 @@ func TestSpheroAdaptorConnect(t *testing.T) {
 
 	gobot.Assert(t, a.Connect()[0], errors.New("connect error"))
 }
+
+func TestSpheroAdaptorHealthy(t *testing.T) {
+	a := initTestSpheroAdaptor()
+	a.Connect()
+	gobot.Assert(t, a.Healthy(), nil)
+
+	a.setErr(errors.New("read error"))
+	gobot.Assert(t, a.Healthy(), errors.New("read error"))
+
+	testAdaptorClose = func() error { return nil }
+	gobot.Assert(t, len(a.Reconnect()), 0)
+	gobot.Assert(t, a.Healthy(), nil)
+}
+
+func TestSpheroAdaptorConnectContext(t *testing.T) {
+	a := initTestSpheroAdaptor()
+	release := make(chan bool)
+	a.connect = func(string) (io.ReadWriteCloser, error) {
+		<-release
+		return &nullReadWriteCloser{}, nil
+	}
+	closed := make(chan bool, 1)
+	testAdaptorClose = func() error {
+		closed <- true
+		return nil
+	}
+	defer func() { testAdaptorClose = func() error { return nil } }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	gobot.Assert(t, a.ConnectContext(ctx), []error{context.DeadlineExceeded})
+
+	// the port opened after giving up is closed
+	close(release)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Error("serial port was not closed")
+	}
+}
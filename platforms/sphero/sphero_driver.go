@@ -32,6 +32,7 @@ type SpheroDriver struct {
 	syncResponse    [][]uint8
 	packetChannel   chan *packet
 	responseChannel chan []uint8
+	halt            chan bool
 	gobot.Eventer
 	gobot.Commander
 }
@@ -39,16 +40,17 @@ type SpheroDriver struct {
 // NewSpheroDriver returns a new SpheroDriver given a SpheroAdaptor and name.
 //
 // Adds the following API Commands:
-// 	"ConfigureLocator" - See SpheroDriver.ConfigureLocator
-// 	"Roll" - See SpheroDriver.Roll
-// 	"Stop" - See SpheroDriver.Stop
-// 	"GetRGB" - See SpheroDriver.GetRGB
-//	"ReadLocator" - See SpheroDriver.ReadLocator
-// 	"SetBackLED" - See SpheroDriver.SetBackLED
-// 	"SetHeading" - See SpheroDriver.SetHeading
-// 	"SetStabilization" - See SpheroDriver.SetStabilization
-//  "SetDataStreaming" - See SpheroDriver.SetDataStreaming
-//  "SetRotationRate" - See SpheroDriver.SetRotationRate
+//
+//		"ConfigureLocator" - See SpheroDriver.ConfigureLocator
+//		"Roll" - See SpheroDriver.Roll
+//		"Stop" - See SpheroDriver.Stop
+//		"GetRGB" - See SpheroDriver.GetRGB
+//		"ReadLocator" - See SpheroDriver.ReadLocator
+//		"SetBackLED" - See SpheroDriver.SetBackLED
+//		"SetHeading" - See SpheroDriver.SetHeading
+//		"SetStabilization" - See SpheroDriver.SetStabilization
+//	 "SetDataStreaming" - See SpheroDriver.SetDataStreaming
+//	 "SetRotationRate" - See SpheroDriver.SetRotationRate
 func NewSpheroDriver(a *SpheroAdaptor, name string) *SpheroDriver {
 	s := &SpheroDriver{
 		name:            name,
@@ -63,7 +65,15 @@ func NewSpheroDriver(a *SpheroAdaptor, name string) *SpheroDriver {
 	s.AddEvent(Collision)
 	s.AddEvent(SensorData)
 
-	s.AddCommand("SetRGB", func(params map[string]interface{}) interface{} {
+	commander := s.Commander.(gobot.SchemaCommander)
+	commander.AddCommandSchema("SetRGB", &gobot.CommandSchema{
+		Description: "Sets the color of the sphero",
+		Params: []gobot.Param{
+			gobot.Param{Name: "r", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "g", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "b", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		r := uint8(params["r"].(float64))
 		g := uint8(params["g"].(float64))
 		b := uint8(params["b"].(float64))
@@ -71,7 +81,13 @@ func NewSpheroDriver(a *SpheroAdaptor, name string) *SpheroDriver {
 		return nil
 	})
 
-	s.AddCommand("Roll", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("Roll", &gobot.CommandSchema{
+		Description: "Rolls the sphero at speed towards heading",
+		Params: []gobot.Param{
+			gobot.Param{Name: "speed", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "heading", Type: gobot.IntegerParam, Required: true}.Range(0, 359),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		speed := uint8(params["speed"].(float64))
 		heading := uint16(params["heading"].(float64))
 		s.Roll(speed, heading)
@@ -91,31 +107,60 @@ func NewSpheroDriver(a *SpheroAdaptor, name string) *SpheroDriver {
 		return s.ReadLocator()
 	})
 
-	s.AddCommand("SetBackLED", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("SetBackLED", &gobot.CommandSchema{
+		Description: "Sets the brightness of the back led",
+		Params: []gobot.Param{
+			gobot.Param{Name: "level", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		level := uint8(params["level"].(float64))
 		s.SetBackLED(level)
 		return nil
 	})
 
-	s.AddCommand("SetRotationRate", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("SetRotationRate", &gobot.CommandSchema{
+		Description: "Sets the rotation rate of the sphero",
+		Params: []gobot.Param{
+			gobot.Param{Name: "level", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		level := uint8(params["level"].(float64))
 		s.SetRotationRate(level)
 		return nil
 	})
 
-	s.AddCommand("SetHeading", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("SetHeading", &gobot.CommandSchema{
+		Description: "Sets the heading of the sphero",
+		Params: []gobot.Param{
+			gobot.Param{Name: "heading", Type: gobot.IntegerParam, Required: true}.Range(0, 359),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		heading := uint16(params["heading"].(float64))
 		s.SetHeading(heading)
 		return nil
 	})
 
-	s.AddCommand("SetStabilization", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("SetStabilization", &gobot.CommandSchema{
+		Description: "Enables or disables the stabilization of the sphero",
+		Params: []gobot.Param{
+			{Name: "enable", Type: gobot.BoolParam, Required: true},
+		},
+	}, func(params map[string]interface{}) interface{} {
 		on := params["enable"].(bool)
 		s.SetStabilization(on)
 		return nil
 	})
 
-	s.AddCommand("SetDataStreaming", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("SetDataStreaming", &gobot.CommandSchema{
+		Description: "Configures the sensor data streaming of the sphero",
+		Params: []gobot.Param{
+			gobot.Param{Name: "N", Type: gobot.IntegerParam, Required: true}.Range(0, 65535),
+			gobot.Param{Name: "M", Type: gobot.IntegerParam, Required: true}.Range(0, 65535),
+			gobot.Param{Name: "Mask", Type: gobot.IntegerParam, Required: true}.Range(0, 4294967295),
+			gobot.Param{Name: "Pcnt", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "Mask2", Type: gobot.IntegerParam, Required: true}.Range(0, 4294967295),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		N := uint16(params["N"].(float64))
 		M := uint16(params["M"].(float64))
 		Mask := uint32(params["Mask"].(float64))
@@ -126,7 +171,15 @@ func NewSpheroDriver(a *SpheroAdaptor, name string) *SpheroDriver {
 		return nil
 	})
 
-	s.AddCommand("ConfigureLocator", func(params map[string]interface{}) interface{} {
+	commander.AddCommandSchema("ConfigureLocator", &gobot.CommandSchema{
+		Description: "Configures the locator of the sphero",
+		Params: []gobot.Param{
+			gobot.Param{Name: "Flags", Type: gobot.IntegerParam, Required: true}.Range(0, 255),
+			gobot.Param{Name: "X", Type: gobot.IntegerParam, Required: true}.Range(-32768, 32767),
+			gobot.Param{Name: "Y", Type: gobot.IntegerParam, Required: true}.Range(-32768, 32767),
+			gobot.Param{Name: "YawTare", Type: gobot.IntegerParam, Required: true}.Range(-32768, 32767),
+		},
+	}, func(params map[string]interface{}) interface{} {
 		Flags := uint8(params["Flags"].(float64))
 		X := int16(params["X"].(float64))
 		Y := int16(params["Y"].(float64))
@@ -150,29 +203,47 @@ func (s *SpheroDriver) adaptor() *SpheroAdaptor {
 // Returns true on successful start.
 //
 // Emits the Events:
-// 	Collision  sphero.CollisionPacket - On Collision Detected
-// 	SensorData sphero.DataStreamingPacket - On Data Streaming event
-// 	Error      error- On error while processing asynchronous response
+//
+//	Collision  sphero.CollisionPacket - On Collision Detected
+//	SensorData sphero.DataStreamingPacket - On Data Streaming event
+//	Error      error- On error while processing asynchronous response
 func (s *SpheroDriver) Start() (errs []error) {
+	halt := make(chan bool)
+	s.halt = halt
+
 	go func() {
 		for {
-			packet := <-s.packetChannel
-			err := s.write(packet)
-			if err != nil {
-				gobot.Publish(s.Event(Error), err)
+			select {
+			case <-halt:
+				return
+			case packet := <-s.packetChannel:
+				err := s.write(packet)
+				if err != nil {
+					s.adaptor().setErr(err)
+					gobot.Publish(s.Event(Error), err)
+				}
 			}
 		}
 	}()
 
 	go func() {
 		for {
-			response := <-s.responseChannel
-			s.syncResponse = append(s.syncResponse, response)
+			select {
+			case <-halt:
+				return
+			case response := <-s.responseChannel:
+				s.syncResponse = append(s.syncResponse, response)
+			}
 		}
 	}()
 
 	go func() {
 		for {
+			select {
+			case <-halt:
+				return
+			default:
+			}
 			header := s.readHeader()
 			if header != nil && len(header) != 0 {
 				body := s.readBody(header[4])
@@ -202,7 +273,11 @@ func (s *SpheroDriver) Start() (errs []error) {
 					s.handleDataStreaming(evt)
 				}
 			}
-			time.Sleep(100 * time.Millisecond)
+			select {
+			case <-halt:
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
 		}
 	}()
 
@@ -213,13 +288,19 @@ func (s *SpheroDriver) Start() (errs []error) {
 }
 
 // Halt halts the SpheroDriver and sends a SpheroDriver.Stop command to the Sphero.
+// It then stops reading from and writing to the Sphero until started again.
 // Returns true on successful halt.
 func (s *SpheroDriver) Halt() (errs []error) {
 	if s.adaptor().connected {
-		gobot.Every(10*time.Millisecond, func() {
+		stop := gobot.Every(10*time.Millisecond, func() {
 			s.Stop()
 		})
 		time.Sleep(1 * time.Second)
+		stop.Stop()
+	}
+	if s.halt != nil {
+		close(s.halt)
+		s.halt = nil
 	}
 	return
 }
@@ -398,6 +479,7 @@ func (s *SpheroDriver) readNextChunk(length int) []uint8 {
 		time.Sleep(1 * time.Millisecond)
 		n, err := s.adaptor().sp.Read(read[bytesRead:])
 		if err != nil {
+			s.adaptor().setErr(err)
 			return nil
 		}
 		bytesRead += n
@@ -71,6 +71,19 @@ func TestSpheroDriver(t *testing.T) {
 	gobot.Assert(t, d.Connection().Name(), "bot")
 }
 
+func TestSpheroDriverCommandParams(t *testing.T) {
+	d := initTestSpheroDriver()
+
+	ret := d.Command("SetRGB")(map[string]interface{}{"r": 100.0, "g": 300.0, "b": 100.0})
+	gobot.Assert(t, ret.(error).Error(), `Param "g" must be between 0 and 255, got 300`)
+
+	ret = d.Command("Roll")(map[string]interface{}{"speed": 100.0})
+	gobot.Assert(t, ret.(error).Error(), `Param "heading" is required`)
+
+	ret = d.Command("SetStabilization")(map[string]interface{}{"enable": "yes"})
+	gobot.Assert(t, ret.(error).Error(), `Param "enable" must be a boolean, got yes`)
+}
+
 func TestSpheroDriverStart(t *testing.T) {
 	d := initTestSpheroDriver()
 	gobot.Assert(t, len(d.Start()), 0)
@@ -80,6 +93,16 @@ func TestSpheroDriverHalt(t *testing.T) {
 	d := initTestSpheroDriver()
 	d.adaptor().connected = true
 	gobot.Assert(t, len(d.Halt()), 0)
+
+	d = initTestSpheroDriver()
+	gobot.Assert(t, len(d.Start()), 0)
+	halt := d.halt
+	gobot.Assert(t, len(d.Halt()), 0)
+	select {
+	case <-halt:
+	default:
+		t.Errorf("reading was not halted")
+	}
 }
 
 func TestSpheroDriverSetDataStreaming(t *testing.T) {
@@ -0,0 +1,161 @@
+package gobot
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Params holds the free-form parameters of a connection or device definition.
+type Params map[string]interface{}
+
+// String returns the string stored under key, or def if there is none.
+func (p Params) String(key string, def string) (string, error) {
+	v, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+	if s, ok := v.(string); ok {
+		return s, nil
+	}
+	return def, fmt.Errorf("Param %q must be a string, got %v", key, v)
+}
+
+// Int returns the number stored under key as an int, or def if there is none.
+func (p Params) Int(key string, def int) (int, error) {
+	f, err := p.Float(key, float64(def))
+	return int(f), err
+}
+
+// Float returns the number stored under key as a float64, or def if there is none.
+func (p Params) Float(key string, def float64) (float64, error) {
+	v, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	}
+	return def, fmt.Errorf("Param %q must be a number, got %v", key, v)
+}
+
+// Bool returns the bool stored under key, or def if there is none.
+func (p Params) Bool(key string, def bool) (bool, error) {
+	v, ok := p[key]
+	if !ok {
+		return def, nil
+	}
+	if b, ok := v.(bool); ok {
+		return b, nil
+	}
+	return def, fmt.Errorf("Param %q must be a bool, got %v", key, v)
+}
+
+// AdaptorSpec describes an Adaptor to be built by an AdaptorFactory.
+type AdaptorSpec struct {
+	Name   string
+	Port   string
+	Params Params
+}
+
+// DriverSpec describes a Driver to be built by a DriverFactory.
+type DriverSpec struct {
+	Name string
+	Pin  string
+	// Interval is the polling interval of the Driver, 0 if none was given.
+	Interval time.Duration
+	Params   Params
+}
+
+// Intervals returns the polling interval of the DriverSpec in the form taken by
+// the optional time.Duration arguments of driver constructors.
+func (s DriverSpec) Intervals() []time.Duration {
+	if s.Interval == 0 {
+		return nil
+	}
+	return []time.Duration{s.Interval}
+}
+
+// AdaptorFactory returns a new Adaptor given an AdaptorSpec.
+type AdaptorFactory func(spec AdaptorSpec) (Adaptor, error)
+
+// DriverFactory returns a new Driver given the Connection it uses and a DriverSpec.
+type DriverFactory func(conn Connection, spec DriverSpec) (Driver, error)
+
+var registry = struct {
+	sync.RWMutex
+	adaptors map[string]AdaptorFactory
+	drivers  map[string]DriverFactory
+}{
+	adaptors: make(map[string]AdaptorFactory),
+	drivers:  make(map[string]DriverFactory),
+}
+
+// RegisterAdaptor makes an AdaptorFactory available by kind, such as "firmata".
+// Platform packages register their adaptors when they are imported.
+func RegisterAdaptor(kind string, f AdaptorFactory) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.adaptors[kind] = f
+}
+
+// RegisterDriver makes a DriverFactory available by kind, such as "gpio.led".
+// Platform packages register their drivers when they are imported.
+func RegisterDriver(kind string, f DriverFactory) {
+	registry.Lock()
+	defer registry.Unlock()
+	registry.drivers[kind] = f
+}
+
+// NewAdaptor returns a new Adaptor of the registered kind given an AdaptorSpec.
+func NewAdaptor(kind string, spec AdaptorSpec) (Adaptor, error) {
+	registry.RLock()
+	f, ok := registry.adaptors[kind]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("Unknown adaptor %q", kind)
+	}
+	return f(spec)
+}
+
+// NewDriver returns a new Driver of the registered kind given the Connection it
+// uses and a DriverSpec.
+func NewDriver(kind string, conn Connection, spec DriverSpec) (Driver, error) {
+	registry.RLock()
+	f, ok := registry.drivers[kind]
+	registry.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("Unknown driver %q", kind)
+	}
+	return f(conn, spec)
+}
+
+// AdaptorKinds returns the sorted kinds of all registered adaptors.
+func AdaptorKinds() (kinds []string) {
+	registry.RLock()
+	defer registry.RUnlock()
+	for kind := range registry.adaptors {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return
+}
+
+// DriverKinds returns the sorted kinds of all registered drivers.
+func DriverKinds() (kinds []string) {
+	registry.RLock()
+	defer registry.RUnlock()
+	for kind := range registry.drivers {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return
+}
@@ -0,0 +1,71 @@
+package gobot
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+	RegisterAdaptor("test", func(spec AdaptorSpec) (Adaptor, error) {
+		return newTestAdaptor(spec.Name, spec.Port), nil
+	})
+	RegisterDriver("test.driver", func(conn Connection, spec DriverSpec) (Driver, error) {
+		if spec.Pin == "" {
+			return nil, errors.New("pin is required")
+		}
+		return newTestDriver(conn.(*testAdaptor), spec.Name, spec.Pin), nil
+	})
+
+	Assert(t, AdaptorKinds(), []string{"test"})
+	Assert(t, DriverKinds(), []string{"test.driver"})
+
+	a, err := NewAdaptor("test", AdaptorSpec{Name: "conn", Port: "/dev/null"})
+	Assert(t, err, nil)
+	Assert(t, a.Name(), "conn")
+	Assert(t, a.(Porter).Port(), "/dev/null")
+
+	d, err := NewDriver("test.driver", a, DriverSpec{Name: "dev", Pin: "13"})
+	Assert(t, err, nil)
+	Assert(t, d.Name(), "dev")
+	Assert(t, d.Connection(), Connection(a))
+
+	_, err = NewDriver("test.driver", a, DriverSpec{Name: "dev"})
+	Assert(t, err, errors.New("pin is required"))
+
+	_, err = NewAdaptor("unknown", AdaptorSpec{})
+	Assert(t, err.Error(), "Unknown adaptor \"unknown\"")
+
+	_, err = NewDriver("unknown", a, DriverSpec{})
+	Assert(t, err.Error(), "Unknown driver \"unknown\"")
+}
+
+func TestParams(t *testing.T) {
+	p := Params{"s": "str", "n": 12.0, "b": true}
+
+	s, err := p.String("s", "")
+	Assert(t, s, "str")
+	Assert(t, err, nil)
+	s, err = p.String("missing", "def")
+	Assert(t, s, "def")
+	_, err = p.String("n", "")
+	Refute(t, err, nil)
+
+	n, err := p.Int("n", 0)
+	Assert(t, n, 12)
+	Assert(t, err, nil)
+	n, _ = p.Int("missing", 3)
+	Assert(t, n, 3)
+	_, err = p.Float("s", 0)
+	Refute(t, err, nil)
+
+	b, err := p.Bool("b", false)
+	Assert(t, b, true)
+	_, err = p.Bool("s", false)
+	Refute(t, err, nil)
+}
+
+func TestDriverSpecIntervals(t *testing.T) {
+	Assert(t, len(DriverSpec{}.Intervals()), 0)
+	Assert(t, DriverSpec{Interval: time.Second}.Intervals(), []time.Duration{time.Second})
+}
@@ -1,25 +1,59 @@
 package gobot
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"strings"
+	"sync"
+	"time"
 )
 
+// State is the lifecycle state of a Robot.
+type State string
+
+const (
+	// StateIdle is the State of a Robot which was never started
+	StateIdle State = "idle"
+	// StateConnecting is the State of a Robot starting its Connections
+	StateConnecting State = "connecting"
+	// StateStarting is the State of a Robot starting its Devices
+	StateStarting State = "starting"
+	// StateRunning is the State of a Robot whose Connections and Devices are started
+	StateRunning State = "running"
+	// StateStopping is the State of a Robot halting its Devices and finalizing its Connections
+	StateStopping State = "stopping"
+	// StateStopped is the State of a Robot which was stopped
+	StateStopped State = "stopped"
+	// StateFailed is the State of a Robot whose Connections or Devices failed to start
+	StateFailed State = "failed"
+)
+
+// StateEvent is the Robot event published with the new State whenever it changes
+const StateEvent = "state"
+
 // JSONRobot a JSON representation of a Robot.
 type JSONRobot struct {
-	Name        string            `json:"name"`
-	Commands    []string          `json:"commands"`
-	Connections []*JSONConnection `json:"connections"`
-	Devices     []*JSONDevice     `json:"devices"`
+	Name           string                    `json:"name"`
+	State          State                     `json:"state"`
+	Tags           []string                  `json:"tags"`
+	Events         []string                  `json:"events"`
+	Commands       []string                  `json:"commands"`
+	CommandSchemas map[string]*CommandSchema `json:"command_schemas"`
+	Connections    []*JSONConnection         `json:"connections"`
+	Devices        []*JSONDevice             `json:"devices"`
 }
 
 // NewJSONRobot returns a JSONRobot given a Robot.
 func NewJSONRobot(robot *Robot) *JSONRobot {
 	jsonRobot := &JSONRobot{
-		Name:        robot.Name,
-		Commands:    []string{},
-		Connections: []*JSONConnection{},
-		Devices:     []*JSONDevice{},
+		Name:           robot.Name,
+		State:          robot.State(),
+		Tags:           robot.Tags(),
+		Events:         eventNames(robot),
+		Commands:       []string{},
+		CommandSchemas: commandSchemas(robot.Commander),
+		Connections:    []*JSONConnection{},
+		Devices:        []*JSONDevice{},
 	}
 
 	for command := range robot.Commands() {
@@ -38,10 +72,48 @@ func NewJSONRobot(robot *Robot) *JSONRobot {
 // It containes it's own work routine and a collection of
 // custom commands to control a robot remotely via the Gobot api.
 type Robot struct {
-	Name        string
-	Work        func()
-	connections *Connections
-	devices     *Devices
+	Name string
+	Work func()
+	// Backoff spaces out the attempts to reconnect a failed Connection, and
+	// the restarts of a Robot whose PanicPolicy is PanicRestart.
+	Backoff Backoff
+	// HealthInterval is how often Connections implementing HealthChecker are
+	// checked while the Robot is running.
+	HealthInterval time.Duration
+	// PanicPolicy decides what happens to the Robot after its work, one of its
+	// event callbacks or one of its commands panics.
+	PanicPolicy PanicPolicy
+	// MaxRestarts is how many times PanicRestart restarts the Robot before
+	// stopping it for good, counting from the last time it was stopped
+	// other than by a restart. Zero restarts it without limit.
+	MaxRestarts int
+	// HaltTimeout is how long each Device is given to halt when the Robot
+	// stops or rolls back a failed start, unless SetHaltTimeout gave it
+	// another. Connections rolled back are given as long to finalize, and
+	// Connections reconnected as long for each attempt. Zero waits for as
+	// long as the Device takes.
+	HaltTimeout time.Duration
+	// WatchdogTimeout is how often the running Robot must receive a
+	// Heartbeat before its actuators are driven to their safe state. Zero
+	// disables the watchdog.
+	WatchdogTimeout time.Duration
+	haltTimeouts    map[string]time.Duration
+	dependencies    map[string][]string
+	watchdog        *watchdog
+	connections     *Connections
+	devices         *Devices
+	supervisor      *supervisor
+	schedules       []*Schedule
+	restarts        int
+	jobs            context.Context
+	stopJobs        context.CancelFunc
+	tags            map[string]bool
+	logger          Logger
+	logMutex        sync.RWMutex
+	mutex           sync.RWMutex
+	attachMutex     sync.Mutex
+	stateMutex      sync.RWMutex
+	state           State
 	Commander
 	Eventer
 }
@@ -56,11 +128,23 @@ func (r *Robots) Len() int {
 
 // Start calls the Start method of each Robot in the collection
 func (r *Robots) Start() (errs []error) {
-	for _, robot := range *r {
-		if errs = robot.Start(); len(errs) > 0 {
+	return r.StartContext(context.Background())
+}
+
+// StartContext calls the StartContext method of each Robot in the collection.
+// If a Robot fails to start, the Robots which were already started are rolled
+// back in reverse order.
+func (r *Robots) StartContext(ctx context.Context) (errs []error) {
+	for n, robot := range *r {
+		if errs = robot.StartContext(ctx); len(errs) > 0 {
 			for i, err := range errs {
 				errs[i] = fmt.Errorf("Robot %q: %v", robot.Name, err)
 			}
+			for i := n - 1; i >= 0; i-- {
+				for _, err := range (*r)[i].rollback() {
+					errs = append(errs, fmt.Errorf("Robot %q: %v", (*r)[i].Name, err))
+				}
+			}
 			return
 		}
 	}
@@ -69,12 +153,15 @@ func (r *Robots) Start() (errs []error) {
 
 // Stop calls the Stop method of each Robot in the collection
 func (r *Robots) Stop() (errs []error) {
+	return r.StopContext(context.Background())
+}
+
+// StopContext calls the StopContext method of each Robot in the collection,
+// stopping every Robot even if some of them fail to stop.
+func (r *Robots) StopContext(ctx context.Context) (errs []error) {
 	for _, robot := range *r {
-		if errs = robot.Stop(); len(errs) > 0 {
-			for i, err := range errs {
-				errs[i] = fmt.Errorf("Robot %q: %v", robot.Name, err)
-			}
-			return
+		for _, err := range robot.StopContext(ctx) {
+			errs = append(errs, fmt.Errorf("Robot %q: %v", robot.Name, err))
 		}
 	}
 	return
@@ -99,121 +186,473 @@ func NewRobot(name string, v ...interface{}) *Robot {
 	}
 
 	r := &Robot{
-		Name:        name,
-		connections: &Connections{},
-		devices:     &Devices{},
-		Work:        nil,
-		Eventer:     NewEventer(),
-		Commander:   NewCommander(),
+		Name:           name,
+		connections:    &Connections{},
+		devices:        &Devices{},
+		Work:           nil,
+		state:          StateIdle,
+		Backoff:        DefaultBackoff,
+		MaxRestarts:    DefaultMaxRestarts,
+		HealthInterval: DefaultHealthInterval,
+		HaltTimeout:    DefaultHaltTimeout,
+		haltTimeouts:   make(map[string]time.Duration),
+		dependencies:   make(map[string][]string),
+		Eventer:        NewEventer(),
+		Commander:      NewCommander(),
 	}
 
-	log.Println("Initializing Robot", r.Name, "...")
+	r.AddEvent(ErrorEvent)
+	r.AddEvent(StateEvent)
+	r.AddEvent(SafeStateEvent)
+	// deliver state changes in order, dropping the oldest for slow callbacks
+	r.Event(StateEvent).SetQueue(16, DropOldest)
+	r.AddEvent(Disconnected)
+	r.AddEvent(Reconnecting)
+	r.AddEvent(Reconnected)
+
+	l := r.Logger()
+	l.Log(InfoLevel, "Initializing Robot "+r.Name+"...", nil)
 
 	for i := range v {
 		switch v[i].(type) {
 		case []Connection:
-			log.Println("Initializing connections...")
+			l.Log(InfoLevel, "Initializing connections...", nil)
 			for _, connection := range v[i].([]Connection) {
 				c := r.AddConnection(connection)
-				log.Println("Initializing connection", c.Name(), "...")
+				l.Log(InfoLevel, "Initializing connection "+c.Name()+"...", connectionFields(c))
 			}
 		case []Device:
-			log.Println("Initializing devices...")
+			l.Log(InfoLevel, "Initializing devices...", nil)
 			for _, device := range v[i].([]Device) {
 				d := r.AddDevice(device)
-				log.Println("Initializing device", d.Name(), "...")
+				l.Log(InfoLevel, "Initializing device "+d.Name()+"...", deviceFields(d))
 			}
 		case func():
 			r.Work = v[i].(func())
 		}
 	}
 
+	for _, err := range r.CheckDependencies() {
+		l.Log(ErrorLevel, err.Error(), nil)
+	}
+
 	return r
 }
 
+// NewCheckedRobot returns a new Robot given the same arguments as NewRobot, or
+// an error listing the unknown dependencies and dependency cycles of its
+// Connections and Devices, which would keep it from starting.
+func NewCheckedRobot(name string, v ...interface{}) (*Robot, error) {
+	r := NewRobot(name, v...)
+	if errs := r.CheckDependencies(); len(errs) > 0 {
+		msgs := []string{}
+		for _, err := range errs {
+			msgs = append(msgs, err.Error())
+		}
+		return nil, fmt.Errorf("Robot %q: %v", r.Name, strings.Join(msgs, "; "))
+	}
+	return r, nil
+}
+
 // Start a Robot's Connections, Devices, and work.
 func (r *Robot) Start() (errs []error) {
-	log.Println("Starting Robot", r.Name, "...")
-	if cerrs := r.Connections().Start(); len(cerrs) > 0 {
+	return r.StartContext(context.Background())
+}
+
+// StartContext starts a Robot's Connections, Devices, and work, each after its
+// dependencies. Cancelling ctx aborts a Connection or Device which is still
+// starting. If any Connection or Device fails to start, everything started so
+// far is halted and finalized in reverse order. Nothing starts if the
+// dependencies are not sound.
+func (r *Robot) StartContext(ctx context.Context) (errs []error) {
+	l := r.Logger()
+	l.Log(InfoLevel, "Starting Robot "+r.Name+"...", nil)
+	r.attachMutex.Lock()
+	connections, devices, oerrs := r.startOrder()
+	if len(oerrs) > 0 {
+		r.attachMutex.Unlock()
+		errs = append(errs, oerrs...)
+		r.setState(StateFailed)
+		return
+	}
+	r.setState(StateConnecting)
+	r.attachMutex.Unlock()
+	if cerrs := connections.start(ctx, l, r.HaltTimeout); len(cerrs) > 0 {
 		errs = append(errs, cerrs...)
+		r.setState(StateFailed)
 		return
 	}
-	if derrs := r.Devices().Start(); len(derrs) > 0 {
+	r.measureConnections(1)
+	r.setState(StateStarting)
+	if derrs := devices.start(ctx, l, r.haltTimeout); len(derrs) > 0 {
 		errs = append(errs, derrs...)
+		errs = append(errs, connections.rollback(l, r.HaltTimeout)...)
+		r.measureConnections(0)
+		r.setState(StateFailed)
 		return
 	}
+	r.supervise()
+	r.guardEvents(r)
+	r.Connections().Each(func(c Connection) { r.guardEvents(c) })
+	r.Devices().Each(func(d Device) { r.guardEvents(d) })
+	r.cancelJobs(true)
+	r.setState(StateRunning)
+	r.watch()
 	if r.Work != nil {
-		log.Println("Starting work...")
-		r.Work()
+		l.Log(InfoLevel, "Starting work...", nil)
+		r.Protect(r.Work)
 	}
 	return
 }
 
 // Stop stops a Robot's connections and Devices
 func (r *Robot) Stop() (errs []error) {
-	log.Println("Stopping Robot", r.Name, "...")
-	if heers := r.Devices().Halt(); len(heers) > 0 {
+	return r.StopContext(context.Background())
+}
+
+// StopContext stops a Robot's connections and Devices, each before its
+// dependencies, giving up on any which have not stopped by the time ctx is
+// done.
+func (r *Robot) StopContext(ctx context.Context) (errs []error) {
+	r.mutex.Lock()
+	r.restarts = 0
+	r.mutex.Unlock()
+	return r.stop(ctx)
+}
+
+// stop stops the Robot without resetting the count of its restarts.
+func (r *Robot) stop(ctx context.Context) (errs []error) {
+	r.Logger().Log(InfoLevel, "Stopping Robot "+r.Name+"...", nil)
+	r.attachMutex.Lock()
+	r.setState(StateStopping)
+	r.attachMutex.Unlock()
+	r.cancelJobs(false)
+	r.unschedule()
+	r.unsupervise(ctx)
+	r.unwatch()
+	errs = append(errs, r.safeState(ctx, SafeStateShutdown, nil)...)
+	connections, devices := r.stopOrder()
+	if heers := devices.halt(ctx, r.Logger(), r.haltTimeout); len(heers) > 0 {
 		for _, err := range heers {
 			errs = append(errs, err)
 		}
 	}
 
-	if ceers := r.Connections().Finalize(); len(ceers) > 0 {
+	if ceers := connections.FinalizeContext(ctx); len(ceers) > 0 {
 		for _, err := range ceers {
 			errs = append(errs, err)
 		}
 	}
 
+	r.measureConnections(0)
+	r.setState(StateStopped)
 	return errs
 }
 
-// Devices returns all devices associated with this Robot.
+// rollback halts a Robot's Devices and finalizes its Connections in reverse
+// order, undoing a successful start.
+func (r *Robot) rollback() (errs []error) {
+	l := r.Logger()
+	l.Log(InfoLevel, "Rolling back Robot "+r.Name+"...", nil)
+	r.attachMutex.Lock()
+	r.setState(StateStopping)
+	r.attachMutex.Unlock()
+	r.cancelJobs(false)
+	r.unschedule()
+	r.unsupervise(context.Background())
+	r.unwatch()
+	connections, devices, _ := r.startOrder()
+	errs = append(errs, devices.rollback(l, r.haltTimeout)...)
+	errs = append(errs, connections.rollback(l, r.HaltTimeout)...)
+	r.measureConnections(0)
+	r.setState(StateStopped)
+	return
+}
+
+// JobContext returns the context the jobs of the commands of the Robot and of
+// its Devices run with. It is cancelled when the Robot stops or its
+// actuators are driven to their safe state, and is already cancelled unless
+// the Robot is running.
+func (r *Robot) JobContext() context.Context {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.jobs == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		return ctx
+	}
+	return r.jobs
+}
+
+// cancelJobs cancels the jobs started so far with JobContext, renewing its
+// context for the jobs started afterwards if renew is true.
+func (r *Robot) cancelJobs(renew bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.stopJobs != nil {
+		r.stopJobs()
+	}
+	r.jobs, r.stopJobs = nil, nil
+	if renew {
+		r.jobs, r.stopJobs = context.WithCancel(context.Background())
+	}
+}
+
+// measureConnections sets the gobot_connection_up metric of all Connections of
+// the Robot to up.
+func (r *Robot) measureConnections(up float64) {
+	r.Connections().Each(func(c Connection) {
+		connectionUp.With(r.Name, c.Name()).Set(up)
+	})
+}
+
+// State returns the lifecycle State of the Robot.
+func (r *Robot) State() State {
+	r.stateMutex.RLock()
+	defer r.stateMutex.RUnlock()
+	return r.state
+}
+
+// setState changes the State of the Robot, publishing it on the StateEvent.
+func (r *Robot) setState(s State) {
+	r.stateMutex.Lock()
+	changed := r.state != s
+	r.state = s
+	r.stateMutex.Unlock()
+
+	if changed {
+		Publish(r.Event(StateEvent), s)
+	}
+}
+
+// Devices returns a snapshot of the devices associated with this Robot, which
+// is safe to use while devices are attached or detached.
 func (r *Robot) Devices() *Devices {
-	return r.devices
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	devices := make(Devices, len(*r.devices))
+	copy(devices, *r.devices)
+	return &devices
 }
 
 // AddDevice adds a new Device to the robots collection of devices. Returns the
 // added device.
 func (r *Robot) AddDevice(d Device) Device {
-	*r.devices = append(*r.Devices(), d)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	*r.devices = append(*r.devices, d)
+	if setter, ok := d.(LoggerSetter); ok {
+		setter.SetLogger(WithFields(r.Logger(), deviceFields(d)))
+	}
 	return d
 }
 
+// AttachDevice adds a new Device to the robots collection of devices, starting
+// it first if the Robot is running. The Device is not added if it fails to
+// start, if its name is already taken, or if its Connection or one of its
+// Dependencies does not belong to the Robot. Devices can not be attached while
+// the Robot is starting or stopping.
+func (r *Robot) AttachDevice(d Device) (errs []error) {
+	r.attachMutex.Lock()
+	defer r.attachMutex.Unlock()
+	if err := r.attachable("Device", d.Name()); err != nil {
+		return []error{err}
+	}
+	if r.Device(d.Name()) != nil {
+		return []error{fmt.Errorf("Device %q: already attached", d.Name())}
+	}
+	if c := d.Connection(); c != nil && r.Connection(c.Name()) == nil {
+		return []error{fmt.Errorf("Device %q: Connection %q is not attached", d.Name(), c.Name())}
+	}
+	if dependent, ok := d.(Dependent); ok {
+		for _, dep := range dependent.Dependencies() {
+			if r.Device(dep) == nil && r.Connection(dep) == nil {
+				return []error{fmt.Errorf("Device %q: dependency %q is not attached", d.Name(), dep)}
+			}
+		}
+	}
+	if r.State() == StateRunning {
+		if errs = (&Devices{d}).start(context.Background(), r.Logger(), r.haltTimeout); len(errs) > 0 {
+			return
+		}
+	}
+	r.guardEvents(d)
+	r.AddDevice(d)
+	return
+}
+
+// DetachDevice removes a Device from the robots collection of devices, halting
+// it if the Robot is running. A Device another Device depends on can not be
+// detached, nor can Devices be detached while the Robot is starting or stopping.
+func (r *Robot) DetachDevice(name string) (errs []error) {
+	r.attachMutex.Lock()
+	defer r.attachMutex.Unlock()
+	if err := r.attachable("Device", name); err != nil {
+		return []error{err}
+	}
+	r.mutex.Lock()
+	d, i := r.devices.find(name)
+	if d == nil {
+		r.mutex.Unlock()
+		return []error{fmt.Errorf("Device %q: not attached", name)}
+	}
+	if dependent := r.dependent(name); dependent != "" {
+		r.mutex.Unlock()
+		return []error{fmt.Errorf("Device %q: needed by Device %q", name, dependent)}
+	}
+	delete(r.dependencies, name)
+	*r.devices = append((*r.devices)[:i], (*r.devices)[i+1:]...)
+	r.mutex.Unlock()
+
+	if r.State() == StateRunning {
+		errs = (&Devices{d}).halt(context.Background(), r.Logger(), r.haltTimeout)
+	}
+	return
+}
+
+// attachable returns an error if the Device or Connection name, of the given
+// kind, can not be attached or detached because the Robot is starting or
+// stopping.
+func (r *Robot) attachable(kind, name string) error {
+	switch state := r.State(); state {
+	case StateConnecting, StateStarting, StateStopping:
+		return fmt.Errorf("%v %q: Robot is %v", kind, name, state)
+	}
+	return nil
+}
+
+// SetHaltTimeout sets how long the Device name is given to halt, in place of
+// the HaltTimeout of the Robot. Zero waits for as long as the Device takes.
+func (r *Robot) SetHaltTimeout(name string, timeout time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.haltTimeouts[name] = timeout
+}
+
+// haltTimeout returns how long d is given to halt.
+func (r *Robot) haltTimeout(d Device) time.Duration {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	if timeout, ok := r.haltTimeouts[d.Name()]; ok {
+		return timeout
+	}
+	return r.HaltTimeout
+}
+
 // Device returns a device given a name. Returns nil if the Device does not exist.
 func (r *Robot) Device(name string) Device {
 	if r == nil {
 		return nil
 	}
-	for _, device := range *r.devices {
-		if device.Name() == name {
-			return device
-		}
-	}
-	return nil
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	device, _ := r.devices.find(name)
+	return device
 }
 
-// Connections returns all connections associated with this robot.
+// Connections returns a snapshot of the connections associated with this
+// robot, which is safe to use while connections are attached or detached.
 func (r *Robot) Connections() *Connections {
-	return r.connections
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	connections := make(Connections, len(*r.connections))
+	copy(connections, *r.connections)
+	return &connections
 }
 
 // AddConnection adds a new connection to the robots collection of connections.
 // Returns the added connection.
 func (r *Robot) AddConnection(c Connection) Connection {
-	*r.connections = append(*r.Connections(), c)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	*r.connections = append(*r.connections, c)
+	if setter, ok := c.(LoggerSetter); ok {
+		setter.SetLogger(WithFields(r.Logger(), connectionFields(c)))
+	}
 	return c
 }
 
+// AttachConnection adds a new Connection to the robots collection of
+// connections, connecting it first if the Robot is running. The Connection is
+// not added if it fails to connect, if its name is already taken or if one of
+// its Dependencies does not belong to the Robot. Connections can not be
+// attached while the Robot is starting or stopping.
+func (r *Robot) AttachConnection(c Connection) (errs []error) {
+	r.attachMutex.Lock()
+	defer r.attachMutex.Unlock()
+	if err := r.attachable("Connection", c.Name()); err != nil {
+		return []error{err}
+	}
+	if r.Connection(c.Name()) != nil {
+		return []error{fmt.Errorf("Connection %q: already attached", c.Name())}
+	}
+	if dependent, ok := c.(Dependent); ok {
+		for _, dep := range dependent.Dependencies() {
+			if r.Connection(dep) == nil {
+				return []error{fmt.Errorf("Connection %q: dependency %q is not attached", c.Name(), dep)}
+			}
+		}
+	}
+	running := r.State() == StateRunning
+	if running {
+		if errs = (&Connections{c}).start(context.Background(), r.Logger(), r.HaltTimeout); len(errs) > 0 {
+			return
+		}
+	}
+	r.guardEvents(c)
+	r.AddConnection(c)
+	if running {
+		connectionUp.With(r.Name, c.Name()).Set(1)
+		r.supervise()
+	}
+	return
+}
+
+// DetachConnection removes a Connection from the robots collection of
+// connections, finalizing it if the Robot is running. A Connection still used
+// by a Device, or which a Device or Connection depends on, can not be detached,
+// nor can Connections be detached while the Robot is starting or stopping.
+func (r *Robot) DetachConnection(name string) (errs []error) {
+	r.attachMutex.Lock()
+	defer r.attachMutex.Unlock()
+	if err := r.attachable("Connection", name); err != nil {
+		return []error{err}
+	}
+	r.mutex.Lock()
+	c, i := r.connections.find(name)
+	if c == nil {
+		r.mutex.Unlock()
+		return []error{fmt.Errorf("Connection %q: not attached", name)}
+	}
+	for _, d := range *r.devices {
+		if d.Connection() != nil && d.Connection().Name() == name {
+			r.mutex.Unlock()
+			return []error{fmt.Errorf("Connection %q: used by Device %q", name, d.Name())}
+		}
+	}
+	if dependent := r.dependent(name); dependent != "" {
+		r.mutex.Unlock()
+		return []error{fmt.Errorf("Connection %q: needed by %q", name, dependent)}
+	}
+	delete(r.dependencies, name)
+	*r.connections = append((*r.connections)[:i], (*r.connections)[i+1:]...)
+	r.mutex.Unlock()
+
+	if r.State() == StateRunning {
+		errs = (&Connections{c}).Finalize()
+		connectionUp.With(r.Name, c.Name()).Set(0)
+	}
+	return
+}
+
 // Connection returns a connection given a name. Returns nil if the Connection
 // does not exist.
 func (r *Robot) Connection(name string) Connection {
 	if r == nil {
 		return nil
 	}
-	for _, connection := range *r.connections {
-		if connection.Name() == name {
-			return connection
-		}
-	}
-	return nil
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	connection, _ := r.connections.find(name)
+	return connection
 }
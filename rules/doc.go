@@ -0,0 +1,49 @@
+/*
+Package rules binds the events of a robot's devices to commands without
+writing work functions.
+
+A rule runs its actions, the commands of devices or of the robot itself,
+whenever its condition is met. Rules are usually loaded from a JSON or YAML file:
+
+	rules:
+	  - name: toggle
+	    robot: bot
+	    when:
+	      device: button
+	      event: push
+	    then:
+	      - device: relay
+	        command: Toggle
+	  - name: dim
+	    robot: bot
+	    cooldown: 500ms
+	    when:
+	      all:
+	        - device: sensor
+	          event: data
+	          op: ">"
+	          value: 512
+	        - device: button
+	          event: push
+	    then:
+	      - device: led
+	        command: Brightness
+	        params:
+	          level: 64
+
+and added to an Engine once the robots are built:
+
+	engine := rules.NewEngine(gbot)
+	if err := engine.Load("rules.yaml"); err != nil {
+		log.Fatal(err)
+	}
+	gbot.Start()
+
+An event condition without op, such as the push of a button, is met only by
+its event. An event condition with op compares the last data of its event, so
+it can be combined with other conditions using all (AND) and any (OR).
+
+Rules only run while their robot is running. Events published while it is
+starting, stopping or stopped are ignored.
+*/
+package rules
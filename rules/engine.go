@@ -0,0 +1,280 @@
+package rules
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+	"gopkg.in/yaml.v2"
+)
+
+// Status is a Rule together with how it has run so far.
+type Status struct {
+	Rule
+	Runs      uint64     `json:"runs"`
+	LastRun   *time.Time `json:"last_run,omitempty"`
+	LastError string     `json:"last_error,omitempty"`
+}
+
+// Engine runs the actions of rules whenever their conditions are met.
+type Engine struct {
+	gobot *gobot.Gobot
+	mutex sync.RWMutex
+	rules map[string]*rule
+}
+
+// rule is a Rule added to an Engine.
+type rule struct {
+	Rule
+	robot    *gobot.Robot
+	cooldown time.Duration
+	subs     []*gobot.Subscription
+	mutex    sync.Mutex
+	last     map[source]interface{}
+	runs     uint64
+	lastRun  time.Time
+	lastErr  error
+}
+
+// NewEngine returns a new Engine for the robots of g.
+func NewEngine(g *gobot.Gobot) *Engine {
+	return &Engine{
+		gobot: g,
+		rules: make(map[string]*rule),
+	}
+}
+
+// Add adds a rule, subscribing to the events of its condition. A rule with the
+// same name is replaced. Returns an error if the rule is invalid or refers to a
+// robot, device or event which does not exist.
+func (e *Engine) Add(r Rule) error {
+	if err := r.Validate(); err != nil {
+		return err
+	}
+	robot := e.gobot.Robot(r.Robot)
+	if robot == nil {
+		return fmt.Errorf("Rule %q: No Robot found with the name %v", r.Name, r.Robot)
+	}
+	cooldown, _ := r.cooldown()
+	added := &rule{
+		Rule:     r,
+		robot:    robot,
+		cooldown: cooldown,
+		last:     make(map[source]interface{}),
+	}
+
+	events := map[source]*gobot.Event{}
+	for _, s := range r.When.sources() {
+		event, err := e.event(robot, s)
+		if err != nil {
+			return fmt.Errorf("Rule %q: %v", r.Name, err)
+		}
+		events[s] = event
+	}
+	for _, a := range r.Then {
+		if _, err := e.command(robot, a); err != nil {
+			return fmt.Errorf("Rule %q: %v", r.Name, err)
+		}
+	}
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if old, ok := e.rules[r.Name]; ok {
+		old.unsubscribe()
+	}
+	for s, event := range events {
+		s := s
+		sub, _ := gobot.On(event, func(data interface{}) {
+			added.trigger(e, s, data)
+		})
+		added.subs = append(added.subs, sub)
+	}
+	e.rules[r.Name] = added
+	return nil
+}
+
+// Remove removes the rule given a name. Returns an error if it does not exist.
+func (e *Engine) Remove(name string) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	r, ok := e.rules[name]
+	if !ok {
+		return fmt.Errorf("No Rule found with the name %v", name)
+	}
+	r.unsubscribe()
+	delete(e.rules, name)
+	return nil
+}
+
+// Rule returns the status of the rule given a name. Returns nil if the rule
+// does not exist.
+func (e *Engine) Rule(name string) *Status {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	if r, ok := e.rules[name]; ok {
+		return r.status()
+	}
+	return nil
+}
+
+// Rules returns the status of all rules sorted by name.
+func (e *Engine) Rules() []*Status {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+	statuses := []*Status{}
+	for _, r := range e.rules {
+		statuses = append(statuses, r.status())
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Name < statuses[j].Name })
+	return statuses
+}
+
+// Load adds the rules of the file at path. Files ending in .json are parsed as
+// JSON, all others as YAML. Stops at the first rule which cannot be added.
+func (e *Engine) Load(path string) error {
+	rules, err := Load(path)
+	if err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if err := e.Add(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load reads the rules of the file at path. Files ending in .json are parsed as
+// JSON, all others as YAML.
+func Load(path string) ([]Rule, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.ToLower(filepath.Ext(path)) == ".json" {
+		return ParseJSON(data)
+	}
+	return ParseYAML(data)
+}
+
+// ParseJSON parses rules listed under "rules" in JSON.
+func ParseJSON(data []byte) ([]Rule, error) {
+	doc := struct {
+		Rules []Rule `json:"rules"`
+	}{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Rules, nil
+}
+
+// ParseYAML parses rules listed under "rules" in YAML.
+func ParseYAML(data []byte) ([]Rule, error) {
+	doc := struct {
+		Rules []Rule `yaml:"rules"`
+	}{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc.Rules, nil
+}
+
+// event returns the event of the source of a condition.
+func (e *Engine) event(robot *gobot.Robot, s source) (*gobot.Event, error) {
+	var eventer gobot.Eventer = robot
+	if s.device != "" {
+		device := robot.Device(s.device)
+		if device == nil {
+			return nil, fmt.Errorf("No Device found with the name %v", s.device)
+		}
+		eventer, _ = device.(gobot.Eventer)
+	}
+	if eventer == nil || eventer.Event(s.event) == nil {
+		return nil, fmt.Errorf("No Event found with the name %v", s.event)
+	}
+	return eventer.Event(s.event), nil
+}
+
+// command returns the command of an action.
+func (e *Engine) command(robot *gobot.Robot, a Action) (func(map[string]interface{}) interface{}, error) {
+	var commander gobot.Commander = robot
+	if a.Device != "" {
+		device := robot.Device(a.Device)
+		if device == nil {
+			return nil, fmt.Errorf("No Device found with the name %v", a.Device)
+		}
+		commander, _ = device.(gobot.Commander)
+	}
+	if commander == nil || commander.Command(a.Command) == nil {
+		return nil, fmt.Errorf("Unknown Command %q", a.Command)
+	}
+	return commander.Command(a.Command), nil
+}
+
+// trigger records the data of an event and runs the actions if the robot is
+// running, the condition is met and the rule is not cooling down. The actions
+// run without holding the lock of the rule, so they may use the Engine.
+func (r *rule) trigger(e *Engine, s source, data interface{}) {
+	if r.robot.State() != gobot.StateRunning {
+		return
+	}
+	r.mutex.Lock()
+	r.last[s] = data
+	if r.Disabled || !r.When.eval(s, r.last) {
+		r.mutex.Unlock()
+		return
+	}
+	now := gobot.Now()
+	if r.runs > 0 && now.Sub(r.lastRun) < r.cooldown {
+		r.mutex.Unlock()
+		return
+	}
+	r.runs++
+	r.lastRun = now
+	r.lastErr = nil
+	actions := append([]Action{}, r.Then...)
+	r.mutex.Unlock()
+
+	for _, a := range actions {
+		f, err := e.command(r.robot, a)
+		if err == nil {
+			var result interface{}
+			if err = r.robot.Protect(func() { result = f(a.params(data)) }); err == nil {
+				err, _ = result.(error)
+			}
+		}
+		if err != nil {
+			r.mutex.Lock()
+			r.lastErr = err
+			r.mutex.Unlock()
+			r.robot.Logger().Log(gobot.ErrorLevel, err.Error(), gobot.Fields{"rule": r.Name})
+			return
+		}
+	}
+}
+
+func (r *rule) unsubscribe() {
+	for _, sub := range r.subs {
+		sub.Unsubscribe()
+	}
+}
+
+func (r *rule) status() *Status {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	s := &Status{Rule: r.Rule, Runs: r.runs}
+	if r.runs > 0 {
+		lastRun := r.lastRun
+		s.LastRun = &lastRun
+	}
+	if r.lastErr != nil {
+		s.LastError = r.lastErr.Error()
+	}
+	return s
+}
@@ -0,0 +1,231 @@
+package rules
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+type testDevice struct {
+	name string
+	gobot.Commander
+	gobot.Eventer
+}
+
+func (t *testDevice) Start() (errs []error)        { return }
+func (t *testDevice) Halt() (errs []error)         { return }
+func (t *testDevice) Name() string                 { return t.name }
+func (t *testDevice) Connection() gobot.Connection { return nil }
+
+func newTestDevice(name string) *testDevice {
+	d := &testDevice{name: name, Commander: gobot.NewCommander(), Eventer: gobot.NewEventer()}
+	d.AddEvent("push")
+	d.AddEvent("data")
+	return d
+}
+
+func initTestEngine() (*Engine, *testDevice, chan map[string]interface{}) {
+	calls := make(chan map[string]interface{}, 10)
+	button := newTestDevice("button")
+	relay := newTestDevice("relay")
+	relay.AddCommand("Toggle", func(params map[string]interface{}) interface{} {
+		calls <- params
+		return nil
+	})
+	relay.AddCommand("Fail", func(params map[string]interface{}) interface{} {
+		return errors.New("failed")
+	})
+
+	g := gobot.NewGobot()
+	g.AddRobot(gobot.NewRobot("bot", []gobot.Device{button, relay})).Start()
+	return NewEngine(g), button, calls
+}
+
+func waitCall(t *testing.T, calls chan map[string]interface{}) map[string]interface{} {
+	select {
+	case params := <-calls:
+		return params
+	case <-time.After(time.Second):
+		t.Error("command was not called")
+	}
+	return nil
+}
+
+func TestEngineAdd(t *testing.T) {
+	e, button, calls := initTestEngine()
+	err := e.Add(Rule{
+		Name:  "toggle",
+		Robot: "bot",
+		When:  Condition{Device: "button", Event: "push"},
+		Then:  []Action{{Device: "relay", Command: "Toggle", Params: map[string]interface{}{"value": Data, "n": 1}}},
+	})
+	gobot.Assert(t, err, nil)
+
+	gobot.Publish(button.Event("push"), "pushed")
+	gobot.Assert(t, waitCall(t, calls), map[string]interface{}{"value": "pushed", "n": 1})
+
+	<-time.After(10 * time.Millisecond)
+	s := e.Rule("toggle")
+	gobot.Assert(t, s.Runs, uint64(1))
+	gobot.Refute(t, s.LastRun, (*time.Time)(nil))
+	gobot.Assert(t, len(e.Rules()), 1)
+
+	gobot.Assert(t, e.Remove("toggle"), nil)
+	gobot.Assert(t, e.Rule("toggle"), (*Status)(nil))
+	gobot.Publish(button.Event("push"), "pushed")
+	select {
+	case <-calls:
+		t.Error("removed rule ran")
+	case <-time.After(10 * time.Millisecond):
+	}
+	gobot.Assert(t, e.Remove("toggle").Error(), "No Rule found with the name toggle")
+}
+
+func TestEngineAddErrors(t *testing.T) {
+	e, _, _ := initTestEngine()
+	r := Rule{
+		Name:  "toggle",
+		Robot: "bot",
+		When:  Condition{Device: "button", Event: "push"},
+		Then:  []Action{{Device: "relay", Command: "Toggle"}},
+	}
+
+	invalid := r
+	invalid.Robot = "other"
+	gobot.Assert(t, e.Add(invalid).Error(), `Rule "toggle": No Robot found with the name other`)
+
+	invalid = r
+	invalid.When.Device = "switch"
+	gobot.Assert(t, e.Add(invalid).Error(), `Rule "toggle": No Device found with the name switch`)
+
+	invalid = r
+	invalid.When.Event = "release"
+	gobot.Assert(t, e.Add(invalid).Error(), `Rule "toggle": No Event found with the name release`)
+
+	invalid = r
+	invalid.Then = []Action{{Device: "relay", Command: "On"}}
+	gobot.Assert(t, e.Add(invalid).Error(), `Rule "toggle": Unknown Command "On"`)
+}
+
+func TestEngineCooldownAndComposition(t *testing.T) {
+	e, button, calls := initTestEngine()
+	err := e.Add(Rule{
+		Name:     "dim",
+		Robot:    "bot",
+		Cooldown: "1h",
+		When: Condition{All: []Condition{
+			{Device: "button", Event: "push"},
+			{Device: "button", Event: "data", Op: ">", Value: 10},
+		}},
+		Then: []Action{{Device: "relay", Command: "Toggle"}},
+	})
+	gobot.Assert(t, err, nil)
+
+	gobot.Publish(button.Event("push"), nil)
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, e.Rule("dim").Runs, uint64(0))
+
+	gobot.Publish(button.Event("data"), 20)
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, e.Rule("dim").Runs, uint64(0))
+
+	gobot.Publish(button.Event("push"), nil)
+	waitCall(t, calls)
+	gobot.Publish(button.Event("push"), nil)
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, e.Rule("dim").Runs, uint64(1))
+}
+
+func TestEngineActionError(t *testing.T) {
+	e, button, _ := initTestEngine()
+	e.Add(Rule{
+		Name:  "fail",
+		Robot: "bot",
+		When:  Condition{Device: "button", Event: "push"},
+		Then:  []Action{{Device: "relay", Command: "Fail"}},
+	})
+
+	gobot.Publish(button.Event("push"), nil)
+	<-time.After(10 * time.Millisecond)
+	gobot.Assert(t, e.Rule("fail").LastError, "failed")
+}
+
+func TestEngineRobotStopped(t *testing.T) {
+	e, button, calls := initTestEngine()
+	e.Add(Rule{
+		Name:  "toggle",
+		Robot: "bot",
+		When:  Condition{Device: "button", Event: "push"},
+		Then:  []Action{{Device: "relay", Command: "Toggle"}},
+	})
+	e.gobot.Robot("bot").Stop()
+
+	gobot.Publish(button.Event("push"), nil)
+	select {
+	case <-calls:
+		t.Error("rule ran while the robot was stopped")
+	case <-time.After(10 * time.Millisecond):
+	}
+	gobot.Assert(t, e.Rule("toggle").Runs, uint64(0))
+}
+
+func TestEngineActionUsesEngine(t *testing.T) {
+	e, button, calls := initTestEngine()
+	button.AddCommand("Status", func(params map[string]interface{}) interface{} {
+		calls <- map[string]interface{}{"runs": e.Rule("status").Runs}
+		return nil
+	})
+	e.Add(Rule{
+		Name:  "status",
+		Robot: "bot",
+		When:  Condition{Device: "button", Event: "push"},
+		Then:  []Action{{Device: "button", Command: "Status"}},
+	})
+
+	gobot.Publish(button.Event("push"), nil)
+	gobot.Assert(t, waitCall(t, calls), map[string]interface{}{"runs": uint64(1)})
+}
+
+func TestEngineLoad(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "rules")
+	defer os.RemoveAll(dir)
+	yamlPath := filepath.Join(dir, "rules.yaml")
+	ioutil.WriteFile(yamlPath, []byte(`
+rules:
+  - name: toggle
+    robot: bot
+    cooldown: 10ms
+    when:
+      any:
+        - device: button
+          event: push
+    then:
+      - device: relay
+        command: Toggle
+        params:
+          level: 1
+`), 0644)
+	jsonPath := filepath.Join(dir, "rules.json")
+	ioutil.WriteFile(jsonPath, []byte(`{"rules": [
+  {"name": "json", "robot": "bot",
+   "when": {"device": "button", "event": "data", "op": "==", "value": 1},
+   "then": [{"device": "relay", "command": "Toggle"}]}
+]}`), 0644)
+
+	e, button, calls := initTestEngine()
+	gobot.Assert(t, e.Load(yamlPath), nil)
+	gobot.Assert(t, e.Load(jsonPath), nil)
+	gobot.Assert(t, e.Rule("toggle").Then[0].Params["level"], 1)
+	gobot.Assert(t, e.Rule("json").When.Value, 1.0)
+
+	gobot.Publish(button.Event("push"), nil)
+	gobot.Assert(t, waitCall(t, calls), map[string]interface{}{"level": 1})
+
+	_, err := Load(filepath.Join(dir, "missing.yaml"))
+	gobot.Refute(t, err, nil)
+}
@@ -0,0 +1,221 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Data is the action param value replaced by the data of the event which
+// triggered the rule.
+const Data = "$data"
+
+// Rule binds events of the devices of a robot to commands: when its condition
+// is met, its actions are run.
+type Rule struct {
+	Name string `json:"name" yaml:"name"`
+	// Robot is the name of the robot whose events and commands the rule uses.
+	Robot string    `json:"robot" yaml:"robot"`
+	When  Condition `json:"when" yaml:"when"`
+	Then  []Action  `json:"then" yaml:"then"`
+	// Cooldown is the minimum duration between two runs of the actions, such
+	// as "500ms", as accepted by time.ParseDuration.
+	Cooldown string `json:"cooldown,omitempty" yaml:"cooldown"`
+	Disabled bool   `json:"disabled,omitempty" yaml:"disabled"`
+}
+
+// Condition is either an event, optionally compared to a value, or the AND
+// (All) or OR (Any) of other conditions.
+//
+// An event condition without Op is met only by the event triggering the
+// evaluation. An event condition with Op is met if the last data of its event
+// compares to Value, so it can be combined with the conditions of other events.
+type Condition struct {
+	// Device is the name of the device publishing Event, or empty for an event
+	// of the robot itself.
+	Device string `json:"device,omitempty" yaml:"device"`
+	Event  string `json:"event,omitempty" yaml:"event"`
+	// Field selects the value of a key of event data which is a map.
+	Field string `json:"field,omitempty" yaml:"field"`
+	// Op is one of ==, !=, >, >=, < and <=.
+	Op    string      `json:"op,omitempty" yaml:"op"`
+	Value interface{} `json:"value,omitempty" yaml:"value"`
+
+	All []Condition `json:"all,omitempty" yaml:"all"`
+	Any []Condition `json:"any,omitempty" yaml:"any"`
+}
+
+// Action is a command run by a Rule.
+type Action struct {
+	// Device is the name of the device with Command, or empty for a command of
+	// the robot itself.
+	Device  string `json:"device,omitempty" yaml:"device"`
+	Command string `json:"command" yaml:"command"`
+	// Params are passed to Command. Values equal to Data are replaced by the
+	// data of the triggering event.
+	Params map[string]interface{} `json:"params,omitempty" yaml:"params"`
+}
+
+// Validate returns an error if the rule is incomplete.
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return errors.New("Rule has no name")
+	}
+	if r.Robot == "" {
+		return fmt.Errorf("Rule %q: no robot", r.Name)
+	}
+	if err := r.When.validate(); err != nil {
+		return fmt.Errorf("Rule %q: %v", r.Name, err)
+	}
+	if len(r.Then) == 0 {
+		return fmt.Errorf("Rule %q: no actions", r.Name)
+	}
+	for _, a := range r.Then {
+		if a.Command == "" {
+			return fmt.Errorf("Rule %q: action without command", r.Name)
+		}
+	}
+	if _, err := r.cooldown(); err != nil {
+		return fmt.Errorf("Rule %q: %v", r.Name, err)
+	}
+	return nil
+}
+
+func (r Rule) cooldown() (time.Duration, error) {
+	if r.Cooldown == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(r.Cooldown)
+}
+
+func (c Condition) validate() error {
+	composite := len(c.All) > 0 || len(c.Any) > 0
+	switch {
+	case composite && c.Event != "":
+		return errors.New("condition has both an event and all or any")
+	case !composite && c.Event == "":
+		return errors.New("condition has no event")
+	}
+	switch c.Op {
+	case "", "==", "!=", ">", ">=", "<", "<=":
+	default:
+		return fmt.Errorf("unknown op %q", c.Op)
+	}
+	for _, sub := range append(c.All, c.Any...) {
+		if err := sub.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// source identifies the event of a condition.
+type source struct {
+	device string
+	event  string
+}
+
+// sources returns the events the condition depends on.
+func (c Condition) sources() (s []source) {
+	if c.Event != "" {
+		return []source{{c.Device, c.Event}}
+	}
+	for _, sub := range append(c.All, c.Any...) {
+		s = append(s, sub.sources()...)
+	}
+	return
+}
+
+// eval returns whether the condition is met when trigger is published, given
+// the last data of each event.
+func (c Condition) eval(trigger source, last map[source]interface{}) bool {
+	switch {
+	case len(c.All) > 0:
+		for _, sub := range c.All {
+			if !sub.eval(trigger, last) {
+				return false
+			}
+		}
+		return true
+	case len(c.Any) > 0:
+		for _, sub := range c.Any {
+			if sub.eval(trigger, last) {
+				return true
+			}
+		}
+		return false
+	}
+
+	s := source{c.Device, c.Event}
+	if c.Op == "" {
+		return s == trigger
+	}
+	data, ok := last[s]
+	if !ok {
+		return false
+	}
+	if c.Field != "" {
+		m, ok := data.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		data = m[c.Field]
+	}
+	return compare(data, c.Op, c.Value)
+}
+
+// compare compares numbers numerically and anything else by its string form.
+func compare(a interface{}, op string, b interface{}) bool {
+	x, xok := number(a)
+	y, yok := number(b)
+	if xok && yok {
+		switch op {
+		case "==":
+			return x == y
+		case "!=":
+			return x != y
+		case ">":
+			return x > y
+		case ">=":
+			return x >= y
+		case "<":
+			return x < y
+		case "<=":
+			return x <= y
+		}
+		return false
+	}
+	switch op {
+	case "==":
+		return fmt.Sprint(a) == fmt.Sprint(b)
+	case "!=":
+		return fmt.Sprint(a) != fmt.Sprint(b)
+	}
+	return false
+}
+
+func number(v interface{}) (float64, bool) {
+	r := reflect.ValueOf(v)
+	switch r.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(r.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(r.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return r.Float(), true
+	}
+	return 0, false
+}
+
+// params returns the params of the action for data.
+func (a Action) params(data interface{}) map[string]interface{} {
+	params := make(map[string]interface{}, len(a.Params))
+	for k, v := range a.Params {
+		if v == Data {
+			v = data
+		}
+		params[k] = v
+	}
+	return params
+}
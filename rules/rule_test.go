@@ -0,0 +1,65 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func TestValidate(t *testing.T) {
+	r := Rule{
+		Name:  "toggle",
+		Robot: "bot",
+		When:  Condition{Device: "button", Event: "push"},
+		Then:  []Action{{Device: "relay", Command: "Toggle"}},
+	}
+	gobot.Assert(t, r.Validate(), nil)
+
+	invalid := r
+	invalid.Name = ""
+	gobot.Assert(t, invalid.Validate().Error(), "Rule has no name")
+
+	invalid = r
+	invalid.When = Condition{}
+	gobot.Assert(t, invalid.Validate().Error(), `Rule "toggle": condition has no event`)
+
+	invalid = r
+	invalid.When = Condition{All: []Condition{{Event: "push", Op: "~"}}}
+	gobot.Assert(t, invalid.Validate().Error(), `Rule "toggle": unknown op "~"`)
+
+	invalid = r
+	invalid.Then = nil
+	gobot.Assert(t, invalid.Validate().Error(), `Rule "toggle": no actions`)
+
+	invalid = r
+	invalid.Cooldown = "soon"
+	gobot.Refute(t, invalid.Validate(), nil)
+}
+
+func TestConditionEval(t *testing.T) {
+	push := source{"button", "push"}
+	data := source{"sensor", "data"}
+	c := Condition{All: []Condition{
+		{Device: "button", Event: "push"},
+		{Any: []Condition{
+			{Device: "sensor", Event: "data", Op: ">", Value: 512},
+			{Device: "sensor", Event: "data", Field: "mode", Op: "==", Value: "manual"},
+		}},
+	}}
+
+	gobot.Assert(t, c.eval(push, map[source]interface{}{}), false)
+	gobot.Assert(t, c.eval(push, map[source]interface{}{data: 600.0}), true)
+	gobot.Assert(t, c.eval(data, map[source]interface{}{data: 600.0}), false)
+	gobot.Assert(t, c.eval(push, map[source]interface{}{data: 100}), false)
+	gobot.Assert(t, c.eval(push, map[source]interface{}{data: map[string]interface{}{"mode": "manual"}}), true)
+}
+
+func TestCompare(t *testing.T) {
+	gobot.Assert(t, compare(1, "==", 1.0), true)
+	gobot.Assert(t, compare(uint8(3), "<=", 3), true)
+	gobot.Assert(t, compare(2.5, "<", 2), false)
+	gobot.Assert(t, compare("on", "==", "on"), true)
+	gobot.Assert(t, compare("on", "!=", "off"), true)
+	gobot.Assert(t, compare("on", ">", "off"), false)
+	gobot.Assert(t, compare(true, "==", true), true)
+}
@@ -0,0 +1,170 @@
+package gobot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// SafeStateEvent is the Robot event published with the SafeStateReason
+// whenever the Robot drives its actuators to their safe state
+const SafeStateEvent = "safe-state"
+
+// SafeStateReason is why a Robot drove its actuators to their safe state.
+type SafeStateReason string
+
+const (
+	// SafeStateShutdown is the SafeStateReason of a Robot stopping
+	SafeStateShutdown SafeStateReason = "shutdown"
+	// SafeStatePanic is the SafeStateReason of a Robot whose work, one of its
+	// event callbacks or one of its commands panicked
+	SafeStatePanic SafeStateReason = "panic"
+	// SafeStateDisconnect is the SafeStateReason of a Robot which lost one of
+	// its Connections, driving only the Devices using it
+	SafeStateDisconnect SafeStateReason = "disconnect"
+	// SafeStateWatchdog is the SafeStateReason of a Robot which received no
+	// Heartbeat within its WatchdogTimeout
+	SafeStateWatchdog SafeStateReason = "watchdog"
+)
+
+// SafeStater is the interface that describes a Driver of an actuator which can
+// be driven to a state it is safe to leave it in, such as a motor stopped or a
+// relay open. Robots drive their SafeStaters to their safe state when they
+// stop, when they panic, when they lose a Connection and when their watchdog
+// expires.
+type SafeStater interface {
+	SafeState() (errs []error)
+}
+
+// SafeState drives every Device of the Robot implementing SafeStater to its
+// safe state, giving each as long as it is given to halt, and publishes reason
+// on the SafeStateEvent. The jobs of running commands are cancelled first.
+// Errors are logged as well as returned.
+func (r *Robot) SafeState(reason SafeStateReason) (errs []error) {
+	return r.safeState(context.Background(), reason, nil)
+}
+
+// safeState drives the SafeStaters of the Robot using the Connection c, or all
+// of them if c is nil, to their safe state, giving up on any which has not
+// reached it by the time ctx is done.
+func (r *Robot) safeState(ctx context.Context, reason SafeStateReason, c Connection) (errs []error) {
+	l := WithFields(r.Logger(), Fields{"reason": reason})
+	level := WarnLevel
+	if reason == SafeStateShutdown {
+		level = InfoLevel
+	}
+	l.Log(level, "Driving devices to their safe state...", nil)
+	r.cancelJobs(r.State() == StateRunning)
+
+	r.Devices().Each(func(d Device) {
+		s, ok := d.(SafeStater)
+		if !ok {
+			return
+		}
+		if c != nil && (d.Connection() == nil || d.Connection().Name() != c.Name()) {
+			return
+		}
+		timeout := r.haltTimeout(d)
+		derrs, timedOut := within(ctx, timeout, func(ctx context.Context) []error {
+			return withContext(ctx, s.SafeState, nil)
+		})
+		if timedOut {
+			derrs = []error{fmt.Errorf("safe state timed out after %v", timeout)}
+		}
+		for _, err := range derrs {
+			err = fmt.Errorf("Device %q: %v", d.Name(), err)
+			l.Log(ErrorLevel, err.Error(), deviceFields(d))
+			errs = append(errs, err)
+		}
+	})
+
+	Publish(r.Event(SafeStateEvent), reason)
+	return
+}
+
+// Heartbeat tells the watchdog of the Robot that its work is still alive. Once
+// the Robot is running with a WatchdogTimeout, it must call Heartbeat at least
+// that often, or its actuators are driven to their safe state. The watchdog is
+// armed again by the next Heartbeat.
+func (r *Robot) Heartbeat() {
+	r.mutex.RLock()
+	w := r.watchdog
+	r.mutex.RUnlock()
+	if w == nil {
+		return
+	}
+	select {
+	case w.beat <- struct{}{}:
+	default:
+	}
+}
+
+// watchdog drives the actuators of a Robot to their safe state when its work
+// stops sending heartbeats.
+type watchdog struct {
+	robot   *Robot
+	timeout time.Duration
+	beat    chan struct{}
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// watch starts the watchdog of r if it has a WatchdogTimeout, unless it is
+// already running.
+func (r *Robot) watch() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.watchdog != nil || r.WatchdogTimeout <= 0 {
+		return
+	}
+
+	w := &watchdog{
+		robot:   r,
+		timeout: r.WatchdogTimeout,
+		beat:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+	r.watchdog = w
+	w.wg.Add(1)
+	go w.run()
+}
+
+// unwatch stops the watchdog of r and waits for it to return.
+func (r *Robot) unwatch() {
+	r.mutex.Lock()
+	w := r.watchdog
+	r.watchdog = nil
+	r.mutex.Unlock()
+	if w == nil {
+		return
+	}
+	close(w.done)
+	w.wg.Wait()
+}
+
+func (w *watchdog) run() {
+	defer w.wg.Done()
+
+	for {
+		timer := currentClock().NewTimer(w.timeout)
+		select {
+		case <-w.done:
+			timer.Stop()
+			return
+		case <-w.beat:
+			timer.Stop()
+			continue
+		case <-timer.C():
+		}
+
+		w.robot.Logger().Log(ErrorLevel, "No heartbeat within "+w.timeout.String(), nil)
+		w.robot.SafeState(SafeStateWatchdog)
+
+		select {
+		case <-w.done:
+			return
+		case <-w.beat:
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package gobot
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testSafeDriver struct {
+	testDriver
+	mutex sync.Mutex
+	calls []string
+	err   error
+}
+
+func (t *testSafeDriver) SafeState() (errs []error) {
+	t.record("safe " + t.name)
+	if t.err != nil {
+		errs = append(errs, t.err)
+	}
+	return
+}
+
+func (t *testSafeDriver) Halt() (errs []error) {
+	t.record("halt " + t.name)
+	return
+}
+
+func (t *testSafeDriver) record(call string) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.calls = append(t.calls, call)
+}
+
+func (t *testSafeDriver) recorded() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]string{}, t.calls...)
+}
+
+func newTestSafeDriver(name string) *testSafeDriver {
+	return &testSafeDriver{testDriver: testDriver{name: name, Commander: NewCommander()}}
+}
+
+func watchSafeState(r *Robot) chan interface{} {
+	reasons := make(chan interface{}, 8)
+	On(r.Event(SafeStateEvent), func(data interface{}) { reasons <- data })
+	return reasons
+}
+
+func expectSafeState(t *testing.T, reasons chan interface{}, reason SafeStateReason) {
+	select {
+	case data := <-reasons:
+		Assert(t, data, reason)
+	case <-time.After(time.Second):
+		t.Fatalf("%v was not published", reason)
+	}
+}
+
+func TestRobotSafeState(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	safe := newTestSafeDriver("Device1")
+	failing := newTestSafeDriver("Device2")
+	failing.err = errors.New("stuck")
+	r := NewRobot("Robot1", []Device{safe, &testDriver{name: "Device3", Commander: NewCommander()}, failing})
+	reasons := watchSafeState(r)
+
+	errs := r.SafeState("estop")
+	Assert(t, len(errs), 1)
+	Assert(t, errs[0].Error(), `Device "Device2": stuck`)
+	Assert(t, safe.recorded(), []string{"safe Device1"})
+	expectSafeState(t, reasons, "estop")
+}
+
+func TestRobotStopSafeState(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	safe := newTestSafeDriver("Device1")
+	r := NewRobot("Robot1", []Device{safe})
+	reasons := watchSafeState(r)
+
+	Assert(t, len(r.Start()), 0)
+	Assert(t, len(r.Stop()), 0)
+	Assert(t, safe.recorded(), []string{"safe Device1", "halt Device1"})
+	expectSafeState(t, reasons, SafeStateShutdown)
+}
+
+func TestRobotSafeStateCancelsJobs(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := NewRobot("Robot1", []Device{newTestSafeDriver("Device1")})
+	Assert(t, r.JobContext().Err(), context.Canceled)
+	Assert(t, len(r.Start()), 0)
+
+	wait := func(ctx context.Context, params map[string]interface{}, progress func(float64)) interface{} {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	j := StartJobContext(r.JobContext(), "1", "wait", wait, nil, r.Protect)
+	r.SafeState("estop")
+	Assert(t, j.Wait().State, JobCancelled)
+	Assert(t, r.JobContext().Err(), nil)
+
+	j = StartJobContext(r.JobContext(), "2", "wait", wait, nil, r.Protect)
+	r.Stop()
+	Assert(t, j.Wait().State, JobCancelled)
+	Assert(t, r.JobContext().Err(), context.Canceled)
+}
+
+func TestRobotPanicSafeState(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	safe := newTestSafeDriver("Device1")
+	r := NewRobot("Robot1", []Device{safe}, func() { panic("oops") })
+	reasons := watchSafeState(r)
+
+	Assert(t, len(r.Start()), 0)
+	expectSafeState(t, reasons, SafeStatePanic)
+	Assert(t, safe.recorded(), []string{"safe Device1"})
+	r.Stop()
+}
+
+func TestRobotDisconnectSafeState(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	a := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "flaky"}}
+	safe := newTestSafeDriver("Device1")
+	safe.connection = a
+	other := newTestSafeDriver("Device2")
+	other.connection = newTestAdaptor("other", "")
+	r := NewRobot("Robot1", []Connection{a, other.connection}, []Device{safe, other})
+	r.HealthInterval = time.Millisecond
+	r.Backoff = Backoff{Initial: time.Millisecond}
+	reasons := watchSafeState(r)
+
+	Assert(t, len(r.Start()), 0)
+	a.fail(errors.New("unplugged"))
+	expectSafeState(t, reasons, SafeStateDisconnect)
+	Assert(t, safe.recorded(), []string{"safe Device1"})
+	Assert(t, len(other.recorded()), 0)
+	r.Stop()
+}
+
+func TestRobotWatchdog(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	safe := newTestSafeDriver("Device1")
+	r := NewRobot("Robot1", []Device{safe})
+	r.WatchdogTimeout = 20 * time.Millisecond
+	reasons := watchSafeState(r)
+
+	// heartbeats keep the watchdog from expiring
+	Assert(t, len(r.Start()), 0)
+	for i := 0; i < 5; i++ {
+		<-time.After(5 * time.Millisecond)
+		r.Heartbeat()
+	}
+	Assert(t, len(safe.recorded()), 0)
+
+	// it expires once, and is armed again by the next heartbeat
+	expectSafeState(t, reasons, SafeStateWatchdog)
+	<-time.After(40 * time.Millisecond)
+	Assert(t, safe.recorded(), []string{"safe Device1"})
+	r.Heartbeat()
+	expectSafeState(t, reasons, SafeStateWatchdog)
+
+	Assert(t, len(r.Stop()), 0)
+	expectSafeState(t, reasons, SafeStateShutdown)
+}
@@ -0,0 +1,219 @@
+package gobot
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Schedule is a handle to a function run periodically or once by Every, After
+// or Cron, which can be used to stop it.
+type Schedule struct {
+	skip     bool
+	jitter   time.Duration
+	running  int32
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// ScheduleOption configures a Schedule.
+type ScheduleOption func(s *Schedule)
+
+// SkipIfRunning skips a run of the scheduled function while the previous run
+// has not returned yet, so that slow runs do not overlap.
+func SkipIfRunning() ScheduleOption {
+	return func(s *Schedule) { s.skip = true }
+}
+
+// Jitter delays each run of the scheduled function by a random duration up to d,
+// spreading out schedules which would otherwise fire at the same time.
+func Jitter(d time.Duration) ScheduleOption {
+	return func(s *Schedule) { s.jitter = d }
+}
+
+// Every triggers f every t time until the returned Schedule is stopped. Unless
+// the SkipIfRunning option is given, it does not wait for the previous
+// execution of f to finish before it fires the next f. A non-positive t returns
+// a Schedule which is already stopped and never fires f. A panic of f is
+// recovered and logged to the DefaultLogger. The Schedule is not stopped when
+// a Robot stops; use Robot.Every in the work of a Robot.
+func Every(t time.Duration, f func(), opts ...ScheduleOption) *Schedule {
+	return every(t, protected(f), opts)
+}
+
+// After triggers f after t duration, unless the returned Schedule is stopped
+// before. A panic of f is recovered and logged to the DefaultLogger. The
+// Schedule is not stopped when a Robot stops; use Robot.After in the work of a
+// Robot.
+func After(t time.Duration, f func(), opts ...ScheduleOption) *Schedule {
+	return after(t, protected(f), opts)
+}
+
+// Cron triggers f at the times matching the cron expression spec until the
+// returned Schedule is stopped. See ParseCron for the accepted expressions. A
+// panic of f is recovered and logged to the DefaultLogger. The Schedule is not
+// stopped when a Robot stops; use Robot.Cron in the work of a Robot.
+func Cron(spec string, f func(), opts ...ScheduleOption) (*Schedule, error) {
+	return cron(spec, protected(f), opts)
+}
+
+// protected returns a function running f which logs the panics of f to the
+// DefaultLogger instead of crashing the process.
+func protected(f func()) func() {
+	return func() {
+		if err := Protect(f); err != nil {
+			DefaultLogger().Log(ErrorLevel, fmt.Sprintf("Recovered from %v", err), Fields{"stack": string(err.(*PanicError).Stack)})
+		}
+	}
+}
+
+func every(t time.Duration, f func(), opts []ScheduleOption) *Schedule {
+	if t <= 0 {
+		s := newSchedule(opts)
+		s.Stop()
+		return s
+	}
+	return newSchedule(opts).start(f, func(time.Time) (time.Duration, bool) {
+		return t, true
+	})
+}
+
+func after(t time.Duration, f func(), opts []ScheduleOption) *Schedule {
+	fired := false
+	return newSchedule(opts).start(f, func(time.Time) (time.Duration, bool) {
+		if fired {
+			return 0, false
+		}
+		fired = true
+		return t, true
+	})
+}
+
+func cron(spec string, f func(), opts []ScheduleOption) (*Schedule, error) {
+	expr, err := ParseCron(spec)
+	if err != nil {
+		return nil, err
+	}
+	return newSchedule(opts).start(f, func(now time.Time) (time.Duration, bool) {
+		next, ok := expr.Next(now)
+		return next.Sub(now), ok
+	}), nil
+}
+
+// Stop stops the Schedule. A run of the scheduled function already in progress
+// is not interrupted. Calling Stop more than once has no effect.
+func (s *Schedule) Stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// Stopped returns true once the Schedule was stopped or will not fire anymore.
+func (s *Schedule) Stopped() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func newSchedule(opts []ScheduleOption) *Schedule {
+	s := &Schedule{done: make(chan struct{})}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// start fires f after each delay returned by next until next returns false or
+// the Schedule is stopped.
+func (s *Schedule) start(f func(), next func(now time.Time) (time.Duration, bool)) *Schedule {
+	go func() {
+		for {
+			clock := currentClock()
+			d, ok := next(clock.Now())
+			if !ok {
+				s.Stop()
+				return
+			}
+			if s.jitter > 0 {
+				d += time.Duration(Rand(int(s.jitter)))
+			}
+			timer := clock.NewTimer(d)
+			select {
+			case <-s.done:
+				timer.Stop()
+				return
+			case <-timer.C():
+				s.fire(f)
+			}
+		}
+	}()
+	return s
+}
+
+func (s *Schedule) fire(f func()) {
+	if !s.skip {
+		go f()
+		return
+	}
+	if !atomic.CompareAndSwapInt32(&s.running, 0, 1) {
+		return
+	}
+	go func() {
+		defer atomic.StoreInt32(&s.running, 0)
+		f()
+	}()
+}
+
+// Every is similar to the package level Every, except that f is protected
+// against panics and the Schedule is stopped when the Robot stops.
+func (r *Robot) Every(t time.Duration, f func(), opts ...ScheduleOption) *Schedule {
+	return r.schedule(every(t, r.protected(f), opts))
+}
+
+// After is similar to the package level After, except that f is protected
+// against panics and the Schedule is stopped when the Robot stops.
+func (r *Robot) After(t time.Duration, f func(), opts ...ScheduleOption) *Schedule {
+	return r.schedule(after(t, r.protected(f), opts))
+}
+
+// Cron is similar to the package level Cron, except that f is protected
+// against panics and the Schedule is stopped when the Robot stops.
+func (r *Robot) Cron(spec string, f func(), opts ...ScheduleOption) (*Schedule, error) {
+	s, err := cron(spec, r.protected(f), opts)
+	if err != nil {
+		return nil, err
+	}
+	return r.schedule(s), nil
+}
+
+func (r *Robot) protected(f func()) func() {
+	return func() { r.Protect(f) }
+}
+
+// schedule ties s to the lifecycle of the Robot, forgetting the Schedules
+// which already stopped.
+func (r *Robot) schedule(s *Schedule) *Schedule {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	schedules := []*Schedule{s}
+	for _, schedule := range r.schedules {
+		if !schedule.Stopped() {
+			schedules = append(schedules, schedule)
+		}
+	}
+	r.schedules = schedules
+	return s
+}
+
+// unschedule stops all Schedules of the Robot.
+func (r *Robot) unschedule() {
+	r.mutex.Lock()
+	schedules := r.schedules
+	r.schedules = nil
+	r.mutex.Unlock()
+	for _, s := range schedules {
+		s.Stop()
+	}
+}
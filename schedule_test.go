@@ -0,0 +1,172 @@
+package gobot
+
+import (
+	"log"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEveryStop(t *testing.T) {
+	var runs int32
+	s := Every(time.Millisecond, func() {
+		atomic.AddInt32(&runs, 1)
+	})
+	<-time.After(10 * time.Millisecond)
+	s.Stop()
+	s.Stop()
+	Assert(t, s.Stopped(), true)
+
+	<-time.After(2 * time.Millisecond)
+	stopped := atomic.LoadInt32(&runs)
+	Refute(t, stopped, int32(0))
+	<-time.After(10 * time.Millisecond)
+	Assert(t, atomic.LoadInt32(&runs), stopped)
+}
+
+func TestEveryNonPositive(t *testing.T) {
+	fired := make(chan bool, 1)
+	for _, d := range []time.Duration{0, -time.Millisecond} {
+		s := Every(d, func() { fired <- true })
+		Assert(t, s.Stopped(), true)
+	}
+	log.SetOutput(&NullReadWriteCloser{})
+	r := NewRobot("Robot1")
+	Assert(t, r.Every(0, func() { fired <- true }).Stopped(), true)
+
+	select {
+	case <-fired:
+		t.Error("non-positive Every fired")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestEverySkipIfRunning(t *testing.T) {
+	var running, overlaps int32
+	s := Every(time.Millisecond, func() {
+		if atomic.AddInt32(&running, 1) > 1 {
+			atomic.AddInt32(&overlaps, 1)
+		}
+		<-time.After(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}, SkipIfRunning())
+	<-time.After(30 * time.Millisecond)
+	s.Stop()
+	Assert(t, atomic.LoadInt32(&overlaps), int32(0))
+}
+
+func TestAfterStop(t *testing.T) {
+	fired := make(chan bool, 1)
+	s := After(5*time.Millisecond, func() { fired <- true })
+	s.Stop()
+	select {
+	case <-fired:
+		t.Error("stopped After fired")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	s = After(time.Millisecond, func() { fired <- true }, Jitter(time.Millisecond))
+	<-fired
+	<-time.After(time.Millisecond)
+	Assert(t, s.Stopped(), true)
+}
+
+func TestParseCron(t *testing.T) {
+	_, err := ParseCron("* * * *")
+	Assert(t, err.Error(), "Cron expression \"* * * *\" must have 5 fields")
+	_, err = ParseCron("60 * * * *")
+	Assert(t, err.Error(), "Cron expression \"60 * * * *\": \"60\" is out of range 0-59")
+	_, err = ParseCron("*/0 * * * *")
+	Refute(t, err, nil)
+	_, err = ParseCron("a * * * *")
+	Refute(t, err, nil)
+
+	base := time.Date(2015, time.March, 14, 9, 26, 53, 0, time.UTC)
+	tests := []struct {
+		spec string
+		next time.Time
+	}{
+		{"* * * * *", time.Date(2015, time.March, 14, 9, 27, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2015, time.March, 14, 9, 30, 0, 0, time.UTC)},
+		{"0 8-10 * * *", time.Date(2015, time.March, 14, 10, 0, 0, 0, time.UTC)},
+		{"30 6 * * 1", time.Date(2015, time.March, 16, 6, 30, 0, 0, time.UTC)},
+		{"0 0 1,15 * *", time.Date(2015, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2015, time.March, 20, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2016, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2015, time.March, 14, 10, 0, 0, 0, time.UTC)},
+	}
+	for _, test := range tests {
+		e, err := ParseCron(test.spec)
+		Assert(t, err, nil)
+		next, ok := e.Next(base)
+		Assert(t, ok, true)
+		Assert(t, next, test.next)
+	}
+
+	e, _ := ParseCron("0 0 31 2 *")
+	_, ok := e.Next(base)
+	Assert(t, ok, false)
+}
+
+func TestCron(t *testing.T) {
+	_, err := Cron("bad", func() {})
+	Refute(t, err, nil)
+
+	s, err := Cron("* * * * *", func() {})
+	Assert(t, err, nil)
+	s.Stop()
+}
+
+func TestRobotSchedulesStopWithRobot(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := NewRobot("R")
+	var runs int32
+	var every, after, cron *Schedule
+	r.Work = func() {
+		every = r.Every(time.Millisecond, func() { atomic.AddInt32(&runs, 1) })
+		after = r.After(time.Hour, func() {})
+		cron, _ = r.Cron("@daily", func() {})
+	}
+	Assert(t, len(r.Start()), 0)
+	<-time.After(5 * time.Millisecond)
+	Assert(t, len(r.Stop()), 0)
+
+	Assert(t, every.Stopped(), true)
+	Assert(t, after.Stopped(), true)
+	Assert(t, cron.Stopped(), true)
+	Refute(t, atomic.LoadInt32(&runs), int32(0))
+}
+
+func TestRobotEveryPanic(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	r := NewRobot("R")
+	errs := make(chan *PanicError, 1)
+	On(r.Event(ErrorEvent), func(data interface{}) {
+		errs <- data.(*PanicError)
+	})
+	s := r.After(time.Millisecond, func() { panic("scheduled") })
+	Assert(t, waitPanicError(t, errs).Value, "scheduled")
+	s.Stop()
+}
+
+func TestAfterPanic(t *testing.T) {
+	l := &testLogger{}
+	defer SetDefaultLogger(DefaultLogger())
+	SetDefaultLogger(l)
+	done := make(chan bool)
+	After(time.Millisecond, func() {
+		defer close(done)
+		panic("scheduled")
+	})
+	<-done
+	deadline := time.After(time.Second)
+	for len(l.Records()) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("panic was not logged")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	Assert(t, l.Records()[0].level, ErrorLevel)
+	Assert(t, l.Records()[0].msg, "Recovered from panic: scheduled")
+}
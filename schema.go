@@ -0,0 +1,141 @@
+package gobot
+
+import (
+	"fmt"
+	"math"
+)
+
+// ParamType is the type of a command parameter.
+type ParamType string
+
+const (
+	// NumberParam is a parameter taking any number
+	NumberParam ParamType = "number"
+	// IntegerParam is a parameter taking a whole number
+	IntegerParam ParamType = "integer"
+	// StringParam is a parameter taking a string
+	StringParam ParamType = "string"
+	// BoolParam is a parameter taking true or false
+	BoolParam ParamType = "boolean"
+)
+
+// Param describes a parameter of a command.
+type Param struct {
+	Name        string      `json:"name"`
+	Type        ParamType   `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Required    bool        `json:"required"`
+	Default     interface{} `json:"default,omitempty"`
+	Min         *float64    `json:"min,omitempty"`
+	Max         *float64    `json:"max,omitempty"`
+}
+
+// Range returns a copy of the number Param p restricted to values from min to max.
+func (p Param) Range(min, max float64) Param {
+	p.Min, p.Max = &min, &max
+	return p
+}
+
+// CommandSchema describes a command and its parameters.
+type CommandSchema struct {
+	Description string  `json:"description,omitempty"`
+	Params      []Param `json:"params"`
+}
+
+// ParamError is the error resulting from command parameters not matching the
+// CommandSchema of the command.
+type ParamError struct {
+	Param  string
+	Reason string
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("Param %q %v", e.Param, e.Reason)
+}
+
+// Validate checks params against the schema and returns a copy of them with the
+// defaults of missing parameters filled in. Numbers are converted to float64,
+// as they are when decoded from JSON. Parameters which are not part of the
+// schema are passed through unchecked. Returns a *ParamError if params do not
+// match the schema.
+func (s *CommandSchema) Validate(params map[string]interface{}) (map[string]interface{}, error) {
+	valid := make(map[string]interface{}, len(params))
+	for name, value := range params {
+		valid[name] = value
+	}
+
+	for _, p := range s.Params {
+		value, ok := params[p.Name]
+		if !ok || value == nil {
+			if p.Required {
+				return nil, &ParamError{Param: p.Name, Reason: "is required"}
+			}
+			if p.Default != nil {
+				valid[p.Name] = p.Default
+			}
+			continue
+		}
+
+		switch p.Type {
+		case NumberParam, IntegerParam:
+			kind := "a number"
+			if p.Type == IntegerParam {
+				kind = "an integer"
+			}
+			n, ok := toFloat64(value)
+			if !ok || (p.Type == IntegerParam && n != math.Trunc(n)) {
+				return nil, &ParamError{Param: p.Name, Reason: fmt.Sprintf("must be %v, got %v", kind, value)}
+			}
+			if (p.Min != nil && n < *p.Min) || (p.Max != nil && n > *p.Max) {
+				return nil, &ParamError{Param: p.Name, Reason: fmt.Sprintf("must be between %v and %v, got %v", bound(p.Min), bound(p.Max), value)}
+			}
+			valid[p.Name] = n
+		case StringParam:
+			if _, ok := value.(string); !ok {
+				return nil, &ParamError{Param: p.Name, Reason: fmt.Sprintf("must be a string, got %v", value)}
+			}
+		case BoolParam:
+			if _, ok := value.(bool); !ok {
+				return nil, &ParamError{Param: p.Name, Reason: fmt.Sprintf("must be a boolean, got %v", value)}
+			}
+		}
+	}
+	return valid, nil
+}
+
+func bound(b *float64) interface{} {
+	if b == nil {
+		return "any"
+	}
+	return *b
+}
+
+func toFloat64(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case float32:
+		return float64(n), true
+	case int:
+		return float64(n), true
+	case int8:
+		return float64(n), true
+	case int16:
+		return float64(n), true
+	case int32:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case uint:
+		return float64(n), true
+	case uint8:
+		return float64(n), true
+	case uint16:
+		return float64(n), true
+	case uint32:
+		return float64(n), true
+	case uint64:
+		return float64(n), true
+	}
+	return 0, false
+}
@@ -0,0 +1,56 @@
+package gobot
+
+import "testing"
+
+func testSchema() *CommandSchema {
+	return &CommandSchema{
+		Description: "test",
+		Params: []Param{
+			Param{Name: "level", Type: IntegerParam, Required: true}.Range(0, 255),
+			{Name: "name", Type: StringParam, Default: "gobot"},
+			{Name: "enable", Type: BoolParam},
+			{Name: "scale", Type: NumberParam},
+		},
+	}
+}
+
+func TestCommandSchemaValidate(t *testing.T) {
+	s := testSchema()
+
+	params, err := s.Validate(map[string]interface{}{"level": 10, "scale": 0.5, "extra": "x"})
+	Assert(t, err, nil)
+	Assert(t, params["level"], 10.0)
+	Assert(t, params["scale"], 0.5)
+	Assert(t, params["name"], "gobot")
+	Assert(t, params["extra"], "x")
+	_, ok := params["enable"]
+	Assert(t, ok, false)
+
+	params, err = s.Validate(map[string]interface{}{"level": 255.0, "name": "bot", "enable": true})
+	Assert(t, err, nil)
+	Assert(t, params["name"], "bot")
+	Assert(t, params["enable"], true)
+}
+
+func TestCommandSchemaValidateErrors(t *testing.T) {
+	s := testSchema()
+
+	_, err := s.Validate(map[string]interface{}{})
+	Assert(t, err, &ParamError{Param: "level", Reason: "is required"})
+	Assert(t, err.Error(), `Param "level" is required`)
+
+	_, err = s.Validate(map[string]interface{}{"level": "10"})
+	Assert(t, err.Error(), `Param "level" must be an integer, got 10`)
+
+	_, err = s.Validate(map[string]interface{}{"level": 1.5})
+	Assert(t, err.Error(), `Param "level" must be an integer, got 1.5`)
+
+	_, err = s.Validate(map[string]interface{}{"level": 256.0})
+	Assert(t, err.Error(), `Param "level" must be between 0 and 255, got 256`)
+
+	_, err = s.Validate(map[string]interface{}{"level": 1.0, "name": 1.0})
+	Assert(t, err.Error(), `Param "name" must be a string, got 1`)
+
+	_, err = s.Validate(map[string]interface{}{"level": 1.0, "enable": "yes"})
+	Assert(t, err.Error(), `Param "enable" must be a boolean, got yes`)
+}
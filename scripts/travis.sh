@@ -23,6 +23,13 @@ do
   cat tmp.cov | grep -v "mode: set" >> profile.cov
 done
 
+# 64-bit atomics need 8-byte alignment on 32-bit platforms
+GOARCH=386 go test -run 'Event|OnQueued|Subscription' github.com/hybridgroup/gobot
+if [ $? -ne 0 ]
+then
+  EXITCODE=1
+fi
+
 if [ $EXITCODE -ne 0 ]
 then
   exit $EXITCODE
@@ -0,0 +1,53 @@
+/*
+Package stream provides composable operators over gobot Events.
+
+Each operator subscribes to one or more source Events and returns a new,
+derived Event. Derived Events can be subscribed to with gobot.On like any other
+Event, fed into further operators, or registered on an Eventer so that the api
+package streams them like any other device event.
+
+Example:
+
+	package main
+
+	import (
+		"fmt"
+		"time"
+
+		"github.com/hybridgroup/gobot"
+		"github.com/hybridgroup/gobot/platforms/firmata"
+		"github.com/hybridgroup/gobot/platforms/gpio"
+		"github.com/hybridgroup/gobot/stream"
+	)
+
+	func main() {
+		gbot := gobot.NewGobot()
+
+		firmataAdaptor := firmata.NewFirmataAdaptor("arduino", "/dev/ttyACM0")
+		sensor := gpio.NewAnalogSensorDriver(firmataAdaptor, "sensor", "0")
+
+		// available from the api as /api/robots/bot/devices/sensor/events/average
+		sensor.RegisterEvent("average", stream.Average(sensor.Event(gpio.Data), 10))
+
+		work := func() {
+			hot := stream.Threshold(sensor.Event("average"), 800)
+			gobot.On(stream.Debounce(hot, 500*time.Millisecond), func(data interface{}) {
+				fmt.Println("hot:", data)
+			})
+		}
+
+		robot := gobot.NewRobot("bot",
+			[]gobot.Connection{firmataAdaptor},
+			[]gobot.Device{sensor},
+			work,
+		)
+
+		gbot.AddRobot(robot)
+
+		gbot.Start()
+	}
+
+Operators receive data from their sources in the order it was published, so
+samples are never reordered between a sensor and its derived Events.
+*/
+package stream
@@ -0,0 +1,198 @@
+package stream
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+// queueSize is the size of the queue through which each operator receives the
+// data of its sources.
+const queueSize = 32
+
+// subscribe delivers the data published on e to f, in order.
+func subscribe(e *gobot.Event, f func(interface{})) {
+	gobot.OnQueued(e, queueSize, gobot.Block, f)
+}
+
+// Map returns an Event which receives f(data) for each data published on e.
+func Map(e *gobot.Event, f func(interface{}) interface{}) *gobot.Event {
+	out := gobot.NewEvent()
+	subscribe(e, func(data interface{}) {
+		gobot.Publish(out, f(data))
+	})
+	return out
+}
+
+// Filter returns an Event which receives the data published on e for which f
+// returns true.
+func Filter(e *gobot.Event, f func(interface{}) bool) *gobot.Event {
+	out := gobot.NewEvent()
+	subscribe(e, func(data interface{}) {
+		if f(data) {
+			gobot.Publish(out, data)
+		}
+	})
+	return out
+}
+
+// Debounce returns an Event which receives the most recent data published on e
+// once no new data has been published on e for d.
+func Debounce(e *gobot.Event, d time.Duration) *gobot.Event {
+	out := gobot.NewEvent()
+	var mutex sync.Mutex
+	var timer *time.Timer
+	subscribe(e, func(data interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(d, func() {
+			gobot.Publish(out, data)
+		})
+	})
+	return out
+}
+
+// Throttle returns an Event which receives at most one data published on e
+// every d. Data published on e before d has elapsed since the last data was
+// passed on is discarded.
+func Throttle(e *gobot.Event, d time.Duration) *gobot.Event {
+	out := gobot.NewEvent()
+	var last time.Time
+	subscribe(e, func(data interface{}) {
+		if now := time.Now(); last.IsZero() || now.Sub(last) >= d {
+			last = now
+			gobot.Publish(out, data)
+		}
+	})
+	return out
+}
+
+// Window returns an Event which receives the last n data published on e as a
+// []interface{}, oldest first, each time data is published on e once at least n
+// data have been published.
+func Window(e *gobot.Event, n int) *gobot.Event {
+	out := gobot.NewEvent()
+	window := []interface{}{}
+	subscribe(e, func(data interface{}) {
+		window = append(window, data)
+		if len(window) > n {
+			window = window[1:]
+		}
+		if len(window) == n {
+			values := make([]interface{}, n)
+			copy(values, window)
+			gobot.Publish(out, values)
+		}
+	})
+	return out
+}
+
+// Average returns an Event which receives the float64 moving average of the last
+// n numeric data published on e. Data which is not numeric is ignored.
+func Average(e *gobot.Event, n int) *gobot.Event {
+	out := gobot.NewEvent()
+	window := []float64{}
+	sum := 0.0
+	subscribe(e, func(data interface{}) {
+		value, ok := toFloat(data)
+		if !ok {
+			return
+		}
+		window = append(window, value)
+		sum += value
+		if len(window) > n {
+			sum -= window[0]
+			window = window[1:]
+		}
+		gobot.Publish(out, sum/float64(len(window)))
+	})
+	return out
+}
+
+// Distinct returns an Event which receives the data published on e which differs
+// from the data published before it.
+func Distinct(e *gobot.Event) *gobot.Event {
+	out := gobot.NewEvent()
+	var last interface{}
+	first := true
+	subscribe(e, func(data interface{}) {
+		if first || !reflect.DeepEqual(data, last) {
+			first = false
+			last = data
+			gobot.Publish(out, data)
+		}
+	})
+	return out
+}
+
+// Threshold returns an Event which receives true when the numeric data published
+// on e rises to or above limit and false when it falls back below limit. Data
+// which is not numeric is ignored.
+func Threshold(e *gobot.Event, limit float64) *gobot.Event {
+	numeric := Filter(e, func(data interface{}) bool {
+		_, ok := toFloat(data)
+		return ok
+	})
+	return Distinct(Map(numeric, func(data interface{}) interface{} {
+		value, _ := toFloat(data)
+		return value >= limit
+	}))
+}
+
+// Merge returns an Event which receives the data published on each of es.
+func Merge(es ...*gobot.Event) *gobot.Event {
+	out := gobot.NewEvent()
+	for _, e := range es {
+		subscribe(e, func(data interface{}) {
+			gobot.Publish(out, data)
+		})
+	}
+	return out
+}
+
+// Zip returns an Event which receives a []interface{} pairing the data published
+// on a with the data published on b, in the order they were published.
+func Zip(a *gobot.Event, b *gobot.Event) *gobot.Event {
+	out := gobot.NewEvent()
+	var mutex sync.Mutex
+	as := []interface{}{}
+	bs := []interface{}{}
+	zip := func() {
+		for len(as) > 0 && len(bs) > 0 {
+			gobot.Publish(out, []interface{}{as[0], bs[0]})
+			as, bs = as[1:], bs[1:]
+		}
+	}
+	subscribe(a, func(data interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		as = append(as, data)
+		zip()
+	})
+	subscribe(b, func(data interface{}) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		bs = append(bs, data)
+		zip()
+	})
+	return out
+}
+
+// toFloat converts numeric data to a float64.
+func toFloat(data interface{}) (float64, bool) {
+	v := reflect.ValueOf(data)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
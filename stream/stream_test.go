@@ -0,0 +1,142 @@
+package stream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hybridgroup/gobot"
+)
+
+func collect(e *gobot.Event) chan interface{} {
+	c := make(chan interface{}, 100)
+	gobot.OnQueued(e, 100, gobot.Block, func(data interface{}) {
+		c <- data
+	})
+	return c
+}
+
+func receive(t *testing.T, c chan interface{}) interface{} {
+	select {
+	case data := <-c:
+		return data
+	case <-time.After(1 * time.Second):
+		t.Errorf("Event was not published")
+	}
+	return nil
+}
+
+func publish(e *gobot.Event, values ...interface{}) {
+	for _, value := range values {
+		gobot.Publish(e, value)
+	}
+}
+
+func TestMap(t *testing.T) {
+	e := gobot.NewEvent()
+	c := collect(Map(e, func(data interface{}) interface{} {
+		return data.(int) * 2
+	}))
+	publish(e, 1, 2, 3)
+	gobot.Assert(t, receive(t, c), 2)
+	gobot.Assert(t, receive(t, c), 4)
+	gobot.Assert(t, receive(t, c), 6)
+}
+
+func TestFilter(t *testing.T) {
+	e := gobot.NewEvent()
+	c := collect(Filter(e, func(data interface{}) bool {
+		return data.(int) > 1
+	}))
+	publish(e, 1, 2, 3)
+	gobot.Assert(t, receive(t, c), 2)
+	gobot.Assert(t, receive(t, c), 3)
+}
+
+func TestDebounce(t *testing.T) {
+	e := gobot.NewEvent()
+	c := collect(Debounce(e, 20*time.Millisecond))
+	publish(e, 1, 2, 3)
+	gobot.Assert(t, receive(t, c), 3)
+	select {
+	case data := <-c:
+		t.Errorf("Debounce published %v more than once", data)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	e := gobot.NewEvent()
+	c := collect(Throttle(e, 1*time.Hour))
+	publish(e, 1, 2, 3)
+	gobot.Assert(t, receive(t, c), 1)
+	select {
+	case data := <-c:
+		t.Errorf("Throttle published %v within its interval", data)
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestWindow(t *testing.T) {
+	e := gobot.NewEvent()
+	c := collect(Window(e, 2))
+	publish(e, 1, 2, 3)
+	gobot.Assert(t, receive(t, c), []interface{}{1, 2})
+	gobot.Assert(t, receive(t, c), []interface{}{2, 3})
+}
+
+func TestAverage(t *testing.T) {
+	e := gobot.NewEvent()
+	c := collect(Average(e, 2))
+	publish(e, 2, "ignored", 4.0, uint8(8))
+	gobot.Assert(t, receive(t, c), 2.0)
+	gobot.Assert(t, receive(t, c), 3.0)
+	gobot.Assert(t, receive(t, c), 6.0)
+}
+
+func TestDistinct(t *testing.T) {
+	e := gobot.NewEvent()
+	c := collect(Distinct(e))
+	publish(e, 1, 1, 2, 2, 1)
+	gobot.Assert(t, receive(t, c), 1)
+	gobot.Assert(t, receive(t, c), 2)
+	gobot.Assert(t, receive(t, c), 1)
+}
+
+func TestThreshold(t *testing.T) {
+	e := gobot.NewEvent()
+	c := collect(Threshold(e, 10))
+	publish(e, 1, 5, 10, 20, "ignored", 3)
+	gobot.Assert(t, receive(t, c), false)
+	gobot.Assert(t, receive(t, c), true)
+	gobot.Assert(t, receive(t, c), false)
+}
+
+func TestMerge(t *testing.T) {
+	a := gobot.NewEvent()
+	b := gobot.NewEvent()
+	c := collect(Merge(a, b))
+	publish(a, 1)
+	gobot.Assert(t, receive(t, c), 1)
+	publish(b, 2)
+	gobot.Assert(t, receive(t, c), 2)
+}
+
+func TestZip(t *testing.T) {
+	a := gobot.NewEvent()
+	b := gobot.NewEvent()
+	c := collect(Zip(a, b))
+	publish(a, 1, 2)
+	publish(b, "a", "b")
+	gobot.Assert(t, receive(t, c), []interface{}{1, "a"})
+	gobot.Assert(t, receive(t, c), []interface{}{2, "b"})
+}
+
+func TestRegisterDerivedEvent(t *testing.T) {
+	eventer := gobot.NewEventer()
+	eventer.AddEvent("data")
+	eventer.RegisterEvent("average", Average(eventer.Event("data"), 3))
+
+	c := collect(eventer.Event("average"))
+	publish(eventer.Event("data"), 3)
+	gobot.Assert(t, receive(t, c), 3.0)
+}
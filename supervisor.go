@@ -0,0 +1,281 @@
+package gobot
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	// Disconnected is the Robot event published when a Connection reports itself unhealthy
+	Disconnected = "disconnected"
+	// Reconnecting is the Robot event published before each attempt to reconnect a Connection
+	Reconnecting = "reconnecting"
+	// Reconnected is the Robot event published once a Connection and the Devices using it are running again
+	Reconnected = "reconnected"
+)
+
+// HealthChecker is the interface which describes a Connection able to report
+// whether it is still working. A Robot supervises the Connections which
+// implement it and reconnects them once they fail.
+type HealthChecker interface {
+	// Healthy returns nil if the Connection is working, or the error which broke it.
+	Healthy() error
+}
+
+// Reconnector is the interface which describes a Connection able to reestablish
+// itself after failing. Supervised Connections which do not implement it are
+// finalized and connected again instead.
+type Reconnector interface {
+	Reconnect() (errs []error)
+}
+
+// ConnectionEvent is the data of the Disconnected, Reconnecting and Reconnected
+// Robot events.
+type ConnectionEvent struct {
+	// Connection is the name of the supervised Connection.
+	Connection string
+	// Attempt is the number of the reconnection attempt, 0 for Disconnected.
+	Attempt int
+	// Err is the error which broke the Connection or failed the last attempt.
+	Err error
+}
+
+// Backoff is the policy used to space out attempts to reconnect a Connection.
+type Backoff struct {
+	// Initial is the delay before the first attempt.
+	Initial time.Duration
+	// Max caps the delay between attempts.
+	Max time.Duration
+	// Multiplier grows the delay after each failed attempt.
+	Multiplier float64
+	// MaxAttempts gives up on a Connection after as many failed attempts, no longer
+	// checking it, 0 never gives up.
+	MaxAttempts int
+}
+
+// DefaultBackoff is the Backoff of a new Robot.
+var DefaultBackoff = Backoff{
+	Initial:    500 * time.Millisecond,
+	Max:        30 * time.Second,
+	Multiplier: 2,
+}
+
+// DefaultHealthInterval is how often a new Robot checks its Connections.
+var DefaultHealthInterval = 1 * time.Second
+
+// Delay returns how long to wait before the given attempt, starting at 1.
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := float64(b.Initial)
+	for i := 1; i < attempt; i++ {
+		delay *= b.Multiplier
+		if b.Max > 0 && delay >= float64(b.Max) {
+			return b.Max
+		}
+	}
+	if b.Max > 0 && delay > float64(b.Max) {
+		return b.Max
+	}
+	return time.Duration(delay)
+}
+
+// supervisor checks the health of a Robot's Connections and reconnects them,
+// each on its own so that a Connection being recovered does not hold up the
+// others.
+type supervisor struct {
+	robot  *Robot
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+	mutex  sync.Mutex
+	// recovering holds the Connections being recovered, givenUp those which
+	// failed MaxAttempts times and are no longer checked.
+	recovering map[string]bool
+	givenUp    map[string]bool
+}
+
+// supervise starts supervising the Connections of r which implement
+// HealthChecker, unless it is already doing so.
+func (r *Robot) supervise() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.supervisor != nil {
+		return
+	}
+
+	supervised := false
+	for _, c := range *r.connections {
+		if _, ok := c.(HealthChecker); ok {
+			supervised = true
+		}
+	}
+	if !supervised {
+		return
+	}
+
+	s := &supervisor{
+		robot:      r,
+		recovering: make(map[string]bool),
+		givenUp:    make(map[string]bool),
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	r.supervisor = s
+	s.wg.Add(1)
+	go s.run()
+}
+
+// unsupervise stops supervising the Connections of r, waiting until ctx is done
+// for a reconnection in progress to give up.
+func (r *Robot) unsupervise(ctx context.Context) {
+	r.mutex.Lock()
+	s := r.supervisor
+	r.supervisor = nil
+	r.mutex.Unlock()
+	if s == nil {
+		return
+	}
+	s.cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+	}
+}
+
+func (s *supervisor) run() {
+	defer s.wg.Done()
+
+	interval := s.robot.HealthInterval
+	if interval <= 0 {
+		interval = DefaultHealthInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, c := range *s.robot.Connections() {
+			h, ok := c.(HealthChecker)
+			if !ok || !s.check(c.Name()) {
+				continue
+			}
+			if err := h.Healthy(); err != nil {
+				s.begin(c.Name())
+				s.wg.Add(1)
+				go s.recover(c, err)
+			}
+		}
+	}
+}
+
+// check reports whether the Connection name is to be checked, that is it is
+// neither being recovered nor given up on.
+func (s *supervisor) check(name string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return !s.recovering[name] && !s.givenUp[name]
+}
+
+func (s *supervisor) begin(name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.recovering[name] = true
+}
+
+// end records the end of the recovery of the Connection name, which gave up
+// on it if recovered is false.
+func (s *supervisor) end(name string, recovered bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.recovering, name)
+	if !recovered {
+		s.givenUp[name] = true
+	}
+}
+
+// recover reconnects c and restarts the Devices using it, backing off between
+// failed attempts. Once MaxAttempts attempts have failed, c is given up on
+// and no longer checked.
+func (s *supervisor) recover(c Connection, err error) {
+	defer s.wg.Done()
+	r := s.robot
+	l := WithFields(r.Logger(), connectionFields(c))
+	l.Log(WarnLevel, fmt.Sprintf("Connection %v disconnected: %v", c.Name(), err), nil)
+	connectionUp.With(r.Name, c.Name()).Set(0)
+	Publish(r.Event(Disconnected), ConnectionEvent{Connection: c.Name(), Err: err})
+	r.safeState(s.ctx, SafeStateDisconnect, c)
+
+	for attempt := 1; r.Backoff.MaxAttempts == 0 || attempt <= r.Backoff.MaxAttempts; attempt++ {
+		Publish(r.Event(Reconnecting), ConnectionEvent{Connection: c.Name(), Attempt: attempt, Err: err})
+		select {
+		case <-s.ctx.Done():
+			s.end(c.Name(), true)
+			return
+		case <-time.After(r.Backoff.Delay(attempt)):
+		}
+
+		l.Log(InfoLevel, fmt.Sprintf("Reconnecting Connection %v, attempt %v...", c.Name(), attempt), Fields{"attempt": attempt})
+		errs, timedOut := within(s.ctx, r.HaltTimeout, func(ctx context.Context) []error {
+			return reconnect(ctx, c)
+		})
+		if timedOut {
+			errs = []error{fmt.Errorf("reconnect timed out after %v", r.HaltTimeout)}
+		}
+		if len(errs) > 0 {
+			err = errs[0]
+			continue
+		}
+		if errs := r.restartDevices(s.ctx, c); len(errs) > 0 {
+			err = errs[0]
+			continue
+		}
+		connectionUp.With(r.Name, c.Name()).Set(1)
+		connectionReconnects.With(r.Name, c.Name()).Inc()
+		Publish(r.Event(Reconnected), ConnectionEvent{Connection: c.Name(), Attempt: attempt})
+		s.end(c.Name(), true)
+		return
+	}
+
+	l.Log(ErrorLevel, fmt.Sprintf("Giving up on Connection %v: %v", c.Name(), err), nil)
+	s.end(c.Name(), false)
+}
+
+// reconnect reconnects c, abandoning the attempt once ctx is done. A
+// Reconnect abandoned is finalized should it succeed.
+func reconnect(ctx context.Context, c Connection) (errs []error) {
+	if rc, ok := c.(Reconnector); ok {
+		return withContext(ctx, rc.Reconnect, c.Finalize)
+	}
+	if errs = finalizeContext(ctx, c); ctx.Err() != nil {
+		return
+	}
+	return connectContext(ctx, c)
+}
+
+// restartDevices halts and starts again the Devices using c, each after its
+// dependencies, giving each its halt timeout to halt and again to start.
+func (r *Robot) restartDevices(ctx context.Context, c Connection) (errs []error) {
+	_, devices, _ := r.startOrder()
+	devices.Each(func(d Device) {
+		if d.Connection() == nil || d.Connection().Name() != c.Name() {
+			return
+		}
+		timeout := r.haltTimeout(d)
+		haltWithin(ctx, d, timeout)
+		derrs, _ := within(ctx, timeout, func(ctx context.Context) []error { return startContext(ctx, d) })
+		for _, err := range derrs {
+			errs = append(errs, fmt.Errorf("Device %q: %v", d.Name(), err))
+		}
+	})
+	return
+}
@@ -0,0 +1,316 @@
+package gobot
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testFlakyAdaptor struct {
+	testAdaptor
+	mutex         sync.Mutex
+	err           error
+	reconnectErrs []error
+	blocked       chan bool
+}
+
+func (t *testFlakyAdaptor) Connect() (errs []error)  { return }
+func (t *testFlakyAdaptor) Finalize() (errs []error) { return }
+
+func (t *testFlakyAdaptor) Healthy() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.err
+}
+
+func (t *testFlakyAdaptor) Reconnect() (errs []error) {
+	if t.blocked != nil {
+		<-t.blocked
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.reconnectErrs) > 0 {
+		errs = append(errs, t.reconnectErrs[0])
+		t.reconnectErrs = t.reconnectErrs[1:]
+		return
+	}
+	t.err = nil
+	return
+}
+
+func (t *testFlakyAdaptor) fail(err error, reconnectErrs ...error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.err = err
+	t.reconnectErrs = reconnectErrs
+}
+
+type testRestartDriver struct {
+	testDriver
+	starts chan bool
+}
+
+func (t *testRestartDriver) Start() (errs []error) {
+	t.starts <- true
+	return
+}
+func (t *testRestartDriver) Halt() (errs []error) { return }
+
+func TestBackoffDelay(t *testing.T) {
+	b := Backoff{Initial: 100 * time.Millisecond, Max: time.Second, Multiplier: 2}
+	Assert(t, b.Delay(1), 100*time.Millisecond)
+	Assert(t, b.Delay(2), 200*time.Millisecond)
+	Assert(t, b.Delay(4), 800*time.Millisecond)
+	Assert(t, b.Delay(5), time.Second)
+	Assert(t, b.Delay(100), time.Second)
+}
+
+func TestRobotReconnect(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	a := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "flaky"}}
+	d := &testRestartDriver{testDriver: testDriver{name: "device", connection: a}, starts: make(chan bool, 10)}
+	other := &testRestartDriver{testDriver: testDriver{name: "other", connection: newTestAdaptor("other", "")}, starts: make(chan bool, 10)}
+	r := NewRobot("robot", []Connection{a, other.connection}, []Device{d, other})
+	r.HealthInterval = time.Millisecond
+	r.Backoff = Backoff{Initial: time.Millisecond, Multiplier: 1}
+
+	events := make(chan string, 10)
+	attempts := make(chan int, 10)
+	for _, name := range []string{Disconnected, Reconnecting, Reconnected} {
+		name := name
+		On(r.Event(name), func(data interface{}) {
+			Assert(t, data.(ConnectionEvent).Connection, "flaky")
+			if name == Reconnecting {
+				attempts <- data.(ConnectionEvent).Attempt
+			}
+			events <- name
+		})
+	}
+
+	Assert(t, len(r.Start()), 0)
+	<-d.starts
+	<-other.starts
+
+	a.fail(errors.New("unplugged"), errors.New("still unplugged"))
+
+	select {
+	case <-d.starts:
+	case <-time.After(time.Second):
+		t.Fatal("device was not restarted")
+	}
+
+	select {
+	case <-other.starts:
+		t.Error("device of another connection was restarted")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	got := map[string]int{}
+	timeout := time.After(time.Second)
+	for got[Reconnected] == 0 {
+		select {
+		case name := <-events:
+			got[name]++
+		case <-timeout:
+			t.Fatal("reconnected was not published")
+		}
+	}
+	Assert(t, got[Disconnected], 1)
+	Assert(t, <-attempts, 1)
+	Assert(t, <-attempts, 2)
+	Assert(t, a.Healthy(), nil)
+
+	Assert(t, len(r.Stop()), 0)
+	Assert(t, r.supervisor, (*supervisor)(nil))
+}
+
+func TestRobotReconnectGivesUp(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	a := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "flaky"}}
+	r := NewRobot("robot", []Connection{a})
+	r.HealthInterval = time.Millisecond
+	r.Backoff = Backoff{Initial: time.Millisecond, MaxAttempts: 2}
+
+	reconnecting := make(chan bool, 10)
+	disconnected := make(chan bool, 10)
+	On(r.Event(Reconnecting), func(data interface{}) {
+		reconnecting <- true
+	})
+	On(r.Event(Disconnected), func(data interface{}) {
+		disconnected <- true
+	})
+	a.fail(errors.New("unplugged"), errors.New("still unplugged"), errors.New("still unplugged"))
+	Assert(t, len(r.Start()), 0)
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-reconnecting:
+		case <-time.After(time.Second):
+			t.Fatal("reconnecting was not published")
+		}
+	}
+
+	// the connection is given up on rather than recovered again
+	<-time.After(20 * time.Millisecond)
+	Refute(t, a.Healthy(), nil)
+	Assert(t, len(disconnected), 1)
+	Assert(t, len(reconnecting), 0)
+	r.supervisor.mutex.Lock()
+	Assert(t, r.supervisor.givenUp["flaky"], true)
+	r.supervisor.mutex.Unlock()
+	Assert(t, len(r.Stop()), 0)
+}
+
+func TestRobotReconnectEach(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	stuck := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "stuck"}, blocked: make(chan bool)}
+	a := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "flaky"}}
+	r := NewRobot("robot", []Connection{stuck, a})
+	r.HealthInterval = time.Millisecond
+	r.Backoff = Backoff{Initial: time.Millisecond, Multiplier: 1}
+
+	reconnected := make(chan interface{}, 10)
+	On(r.Event(Reconnected), func(data interface{}) {
+		reconnected <- data.(ConnectionEvent).Connection
+	})
+	Assert(t, len(r.Start()), 0)
+
+	// a connection which does not reconnect does not hold up the others
+	stuck.fail(errors.New("unplugged"))
+	a.fail(errors.New("unplugged"))
+	select {
+	case name := <-reconnected:
+		Assert(t, name, "flaky")
+	case <-time.After(time.Second):
+		t.Fatal("flaky was not reconnected")
+	}
+
+	close(stuck.blocked)
+	Assert(t, len(r.Stop()), 0)
+}
+
+func TestRobotReconnectHaltTimeout(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	a := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "flaky"}}
+	d := &testRestartDriver{testDriver: testDriver{name: "device", connection: a}, starts: make(chan bool, 10)}
+	hung := &testHangingDriver{
+		testDriver: testDriver{name: "hung", connection: a, Commander: NewCommander()},
+		hang:       make(chan struct{}),
+	}
+	r := NewRobot("robot", []Connection{a}, []Device{hung, d})
+	r.HealthInterval = time.Millisecond
+	r.Backoff = Backoff{Initial: time.Millisecond}
+	r.SetHaltTimeout("hung", 10*time.Millisecond)
+
+	Assert(t, len(r.Start()), 0)
+	<-d.starts
+
+	// a device which does not halt does not hold up the restart of the others
+	a.fail(errors.New("unplugged"))
+	select {
+	case <-d.starts:
+	case <-time.After(time.Second):
+		t.Fatal("device was not restarted")
+	}
+	close(hung.hang)
+	r.Stop()
+}
+
+func TestRobotStopDuringReconnect(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	a := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "flaky"}}
+	r := NewRobot("robot", []Connection{a})
+	r.HealthInterval = time.Millisecond
+	r.Backoff = Backoff{Initial: time.Hour}
+
+	reconnecting := make(chan bool, 1)
+	On(r.Event(Reconnecting), func(data interface{}) {
+		reconnecting <- true
+	})
+
+	Assert(t, len(r.Start()), 0)
+	a.fail(errors.New("unplugged"))
+	select {
+	case <-reconnecting:
+	case <-time.After(time.Second):
+		t.Fatal("reconnecting was not published")
+	}
+
+	stopped := make(chan bool)
+	go func() {
+		r.Stop()
+		stopped <- true
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("stop waited for the backoff")
+	}
+}
+
+func TestRobotReconnectTimeout(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	a := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "flaky"}, blocked: make(chan bool)}
+	r := NewRobot("robot", []Connection{a})
+	r.HealthInterval = time.Millisecond
+	r.Backoff = Backoff{Initial: time.Millisecond, Multiplier: 1}
+	r.HaltTimeout = 10 * time.Millisecond
+
+	attempts := make(chan int, 10)
+	On(r.Event(Reconnecting), func(data interface{}) {
+		attempts <- data.(ConnectionEvent).Attempt
+	})
+
+	Assert(t, len(r.Start()), 0)
+	a.fail(errors.New("unplugged"))
+
+	// an attempt which hangs is given up on after the halt timeout
+	for attempt := 1; attempt <= 2; attempt++ {
+		select {
+		case got := <-attempts:
+			Assert(t, got, attempt)
+		case <-time.After(time.Second):
+			t.Fatalf("attempt %v was not made", attempt)
+		}
+	}
+	close(a.blocked)
+	r.Stop()
+}
+
+func TestRobotStopDuringHungReconnect(t *testing.T) {
+	log.SetOutput(&NullReadWriteCloser{})
+	a := &testFlakyAdaptor{testAdaptor: testAdaptor{name: "flaky"}, blocked: make(chan bool)}
+	r := NewRobot("robot", []Connection{a})
+	r.HealthInterval = time.Millisecond
+	r.Backoff = Backoff{Initial: time.Millisecond}
+	r.HaltTimeout = 0
+
+	reconnecting := make(chan bool, 1)
+	On(r.Event(Reconnecting), func(data interface{}) {
+		reconnecting <- true
+	})
+
+	Assert(t, len(r.Start()), 0)
+	a.fail(errors.New("unplugged"))
+	select {
+	case <-reconnecting:
+	case <-time.After(time.Second):
+		t.Fatal("reconnecting was not published")
+	}
+	// the attempt is made once the backoff has passed
+	<-time.After(20 * time.Millisecond)
+
+	stopped := make(chan bool)
+	go func() {
+		r.Stop()
+		stopped <- true
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Error("stop waited for the reconnect")
+	}
+	close(a.blocked)
+}
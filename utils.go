@@ -1,17 +1,17 @@
 package gobot
 
 import (
+	"context"
 	"crypto/rand"
 	"errors"
 	"fmt"
-	"log"
+	"io"
 	"math"
 	"math/big"
 	"reflect"
 	"runtime"
 	"strings"
 	"testing"
-	"time"
 )
 
 var (
@@ -22,7 +22,7 @@ var (
 var eventError = func(e *Event) (err error) {
 	if e == nil {
 		err = ErrUnknownEvent
-		log.Println(err.Error())
+		DefaultLogger().Log(ErrorLevel, err.Error(), nil)
 		return
 	}
 	return
@@ -54,24 +54,6 @@ func Refute(t *testing.T, a interface{}, b interface{}) {
 	}
 }
 
-// Every triggers f every t time until the end of days. It does not wait for the
-// previous execution of f to finish before it fires the next f.
-func Every(t time.Duration, f func()) {
-	c := time.Tick(t)
-
-	go func() {
-		for {
-			<-c
-			go f()
-		}
-	}()
-}
-
-// After triggers f after t duration.
-func After(t time.Duration, f func()) {
-	time.AfterFunc(t, f)
-}
-
 // Publish emits val to all subscribers of e. Returns ErrUnknownEvent if Event
 // does not exist.
 func Publish(e *Event, val interface{}) (err error) {
@@ -81,20 +63,42 @@ func Publish(e *Event, val interface{}) (err error) {
 	return
 }
 
-// On executes f when e is Published to. Returns ErrUnknownEvent if Event
-// does not exist.
-func On(e *Event, f func(s interface{})) (err error) {
+// On executes f when e is Published to and returns a Subscription which can be
+// used to stop executing f. Returns ErrUnknownEvent if Event does not exist.
+func On(e *Event, f func(s interface{})) (sub *Subscription, err error) {
+	if err = eventError(e); err == nil {
+		sub = e.subscribe(f, false, -1, 0, false)
+	}
+	return
+}
+
+// OnQueued is similar to On except that f receives data in the order it was
+// Published, from a single goroutine, through a queue holding at most size
+// values. When the queue is full, policy decides what happens to new data.
+// Returns ErrUnknownEvent if Event does not exist.
+func OnQueued(e *Event, size int, policy OverflowPolicy, f func(s interface{})) (sub *Subscription, err error) {
+	if err = eventError(e); err == nil {
+		sub = e.subscribe(f, false, size, policy, false)
+	}
+	return
+}
+
+// OnQueuedSample is similar to OnQueued except that f receives data as a
+// Sample, stamped with the time it was Published rather than the time f is
+// called, which lags behind while the queue is backed up. Returns
+// ErrUnknownEvent if Event does not exist.
+func OnQueuedSample(e *Event, size int, policy OverflowPolicy, f func(s Sample)) (sub *Subscription, err error) {
 	if err = eventError(e); err == nil {
-		e.Callbacks = append(e.Callbacks, callback{f, false})
+		sub = e.subscribe(func(data interface{}) { f(data.(Sample)) }, false, size, policy, true)
 	}
 	return
 }
 
 // Once is similar to On except that it only executes f one time. Returns
 //ErrUnknownEvent if Event does not exist.
-func Once(e *Event, f func(s interface{})) (err error) {
+func Once(e *Event, f func(s interface{})) (sub *Subscription, err error) {
 	if err = eventError(e); err == nil {
-		e.Callbacks = append(e.Callbacks, callback{f, true})
+		sub = e.subscribe(f, true, 0, 0, false)
 	}
 	return
 }
@@ -123,3 +127,98 @@ func ToScale(input, min, max float64) float64 {
 		return i
 	}
 }
+
+// withContext calls f and returns its errors, or the error of ctx if ctx is
+// done before f returns. In the latter case f is left running in the
+// background, and undo, unless it is nil, is called once f returns without
+// errors, undoing what f did after it was abandoned.
+func withContext(ctx context.Context, f func() []error, undo func() []error) []error {
+	if ctx.Done() == nil {
+		return f()
+	}
+	if err := ctx.Err(); err != nil {
+		return []error{err}
+	}
+
+	done := make(chan []error)
+	abandoned := make(chan struct{})
+	go func() {
+		errs := f()
+		select {
+		case done <- errs:
+		case <-abandoned:
+			if len(errs) == 0 && undo != nil {
+				undo()
+			}
+		}
+	}()
+
+	select {
+	case errs := <-done:
+		return errs
+	case <-ctx.Done():
+		close(abandoned)
+		return []error{ctx.Err()}
+	}
+}
+
+// OpenContext calls open, which opens a port such as a serial port, and
+// returns the port, or the error of ctx if ctx is done first. A port opened
+// once ctx is done is closed. Adaptors use it to implement ContextConnector.
+func OpenContext(ctx context.Context, open func() (io.ReadWriteCloser, error)) (io.ReadWriteCloser, error) {
+	if ctx.Done() == nil {
+		return open()
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	type opened struct {
+		rwc io.ReadWriteCloser
+		err error
+	}
+	done := make(chan opened)
+	abandoned := make(chan struct{})
+	go func() {
+		rwc, err := open()
+		select {
+		case done <- opened{rwc, err}:
+		case <-abandoned:
+			if err == nil && rwc != nil {
+				rwc.Close()
+			}
+		}
+	}()
+
+	select {
+	case o := <-done:
+		return o.rwc, o.err
+	case <-ctx.Done():
+		close(abandoned)
+		return nil, ctx.Err()
+	}
+}
+
+// CloseOnDone closes c once ctx is done, aborting a read or write in progress
+// on it, until the returned stop is called. stop, which must be called once,
+// reports whether c was closed.
+func CloseOnDone(ctx context.Context, c io.Closer) (stop func() bool) {
+	if ctx.Done() == nil {
+		return func() bool { return false }
+	}
+	stopped := make(chan struct{})
+	closed := make(chan bool, 1)
+	go func() {
+		select {
+		case <-ctx.Done():
+			c.Close()
+			closed <- true
+		case <-stopped:
+			closed <- false
+		}
+	}()
+	return func() bool {
+		close(stopped)
+		return <-closed
+	}
+}
@@ -1,7 +1,10 @@
 package gobot
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"testing"
 	"time"
 )
@@ -44,13 +47,14 @@ func TestEvery(t *testing.T) {
 	i := 0
 	begin := time.Now().UnixNano()
 	sem := make(chan int64, 1)
-	Every(2*time.Millisecond, func() {
+	s := Every(2*time.Millisecond, func() {
 		i++
 		if i == 2 {
 			sem <- time.Now().UnixNano()
 		}
 	})
 	end := <-sem
+	s.Stop()
 	if end-begin < 4000000 {
 		t.Error("Test should have taken at least 4 milliseconds")
 	}
@@ -97,7 +101,7 @@ func TestOn(t *testing.T) {
 	Assert(t, i, 10)
 
 	var e1 = (*Event)(nil)
-	err := On(e1, func(data interface{}) {
+	_, err := On(e1, func(data interface{}) {
 		i = data.(int)
 	})
 	Assert(t, err, ErrUnknownEvent)
@@ -118,12 +122,48 @@ func TestOnce(t *testing.T) {
 	Assert(t, i, 30)
 
 	var e1 = (*Event)(nil)
-	err := Once(e1, func(data interface{}) {
+	_, err := Once(e1, func(data interface{}) {
 		i = data.(int)
 	})
 	Assert(t, err, ErrUnknownEvent)
 }
 
+func TestUnsubscribe(t *testing.T) {
+	c := make(chan interface{}, 2)
+	e := NewEvent()
+	sub, _ := On(e, func(data interface{}) {
+		c <- data
+	})
+	Assert(t, e.SubscriberCount(), 1)
+	Publish(e, 10)
+	Assert(t, <-c, 10)
+
+	sub.Unsubscribe()
+	sub.Unsubscribe()
+	Assert(t, e.SubscriberCount(), 0)
+	Publish(e, 20)
+	select {
+	case data := <-c:
+		t.Errorf("Unsubscribed callback received %v", data)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestOnConcurrent(t *testing.T) {
+	e := NewEvent()
+	subs := make(chan *Subscription, 100)
+	for i := 0; i < 100; i++ {
+		go func() {
+			sub, _ := On(e, func(data interface{}) {})
+			subs <- sub
+		}()
+	}
+	for i := 0; i < 100; i++ {
+		<-subs
+	}
+	Assert(t, e.SubscriberCount(), 100)
+}
+
 func TestFromScale(t *testing.T) {
 	Assert(t, FromScale(5, 0, 10), 0.5)
 }
@@ -141,3 +181,62 @@ func TestRand(t *testing.T) {
 		t.Error(fmt.Sprintf("%v should not equal %v", a, b))
 	}
 }
+
+// testPort records that it was closed.
+type testPort struct {
+	NullReadWriteCloser
+	closed chan bool
+}
+
+func (t *testPort) Close() error {
+	t.closed <- true
+	return nil
+}
+
+func TestOpenContext(t *testing.T) {
+	port := &testPort{closed: make(chan bool, 1)}
+	open := func() (io.ReadWriteCloser, error) { return port, nil }
+	rwc, err := OpenContext(context.Background(), open)
+	Assert(t, err, nil)
+	Assert(t, rwc, io.ReadWriteCloser(port))
+
+	_, err = OpenContext(context.Background(), func() (io.ReadWriteCloser, error) {
+		return nil, errors.New("open error")
+	})
+	Assert(t, err, errors.New("open error"))
+
+	// a port opened once ctx is done is closed
+	release := make(chan bool)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rwc, err = OpenContext(ctx, func() (io.ReadWriteCloser, error) {
+		<-release
+		return open()
+	})
+	Assert(t, rwc, nil)
+	Assert(t, err, context.DeadlineExceeded)
+	close(release)
+	select {
+	case <-port.closed:
+	case <-time.After(time.Second):
+		t.Error("port was not closed")
+	}
+}
+
+func TestCloseOnDone(t *testing.T) {
+	port := &testPort{closed: make(chan bool, 1)}
+	ctx, cancel := context.WithCancel(context.Background())
+	stop := CloseOnDone(ctx, port)
+	Assert(t, stop(), false)
+	cancel()
+	Assert(t, len(port.closed), 0)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	stop = CloseOnDone(ctx, port)
+	cancel()
+	<-port.closed
+	Assert(t, stop(), true)
+
+	Assert(t, CloseOnDone(context.Background(), port)(), false)
+}
+